@@ -34,6 +34,8 @@ type Category struct {
 	Strings []string `json:"strings"`
 	// Ints holds the value of the "ints" field.
 	Ints []int `json:"ints"`
+	// Time the category was deleted, if it has been deleted.
+	DeletedAt *time.Time `json:"deleted_at"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the CategoryQuery when eager-loading is set.
 	Edges        CategoryEdges `json:"edges"`
@@ -69,7 +71,7 @@ func (*Category) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullInt64)
 		case category.FieldName, category.FieldReadonly, category.FieldSkipInSpec, category.FieldNillable:
 			values[i] = new(sql.NullString)
-		case category.FieldCreatedAt, category.FieldUpdatedAt:
+		case category.FieldCreatedAt, category.FieldUpdatedAt, category.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -145,6 +147,13 @@ func (_m *Category) assignValues(columns []string, values []any) error {
 					return fmt.Errorf("unmarshal field ints: %w", err)
 				}
 			}
+		case category.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				_m.DeletedAt = new(time.Time)
+				*_m.DeletedAt = value.Time
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -211,6 +220,11 @@ func (_m *Category) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("ints=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Ints))
+	builder.WriteString(", ")
+	if v := _m.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
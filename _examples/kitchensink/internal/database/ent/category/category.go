@@ -30,6 +30,8 @@ const (
 	FieldStrings = "strings"
 	// FieldInts holds the string denoting the ints field in the database.
 	FieldInts = "ints"
+	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
+	FieldDeletedAt = "deleted_at"
 	// EdgePets holds the string denoting the pets edge name in mutations.
 	EdgePets = "pets"
 	// Table holds the table name of the category in the database.
@@ -52,6 +54,7 @@ var Columns = []string{
 	FieldNillable,
 	FieldStrings,
 	FieldInts,
+	FieldDeletedAt,
 }
 
 var (
@@ -119,6 +122,11 @@ func ByNillable(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNillable, opts...).ToFunc()
 }
 
+// ByDeletedAt orders the results by the deleted_at field.
+func ByDeletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletedAt, opts...).ToFunc()
+}
+
 // ByPetsCount orders the results by pets count.
 func ByPetsCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
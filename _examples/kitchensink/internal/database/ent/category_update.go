@@ -134,6 +134,26 @@ func (_u *CategoryUpdate) ClearInts() *CategoryUpdate {
 	return _u
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *CategoryUpdate) SetDeletedAt(v time.Time) *CategoryUpdate {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *CategoryUpdate) SetNillableDeletedAt(v *time.Time) *CategoryUpdate {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *CategoryUpdate) ClearDeletedAt() *CategoryUpdate {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
 // AddPetIDs adds the "pets" edge to the Pet entity by IDs.
 func (_u *CategoryUpdate) AddPetIDs(ids ...int) *CategoryUpdate {
 	_u.mutation.AddPetIDs(ids...)
@@ -260,6 +280,12 @@ func (_u *CategoryUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.IntsCleared() {
 		_spec.ClearField(category.FieldInts, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(category.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(category.FieldDeletedAt, field.TypeTime)
+	}
 	if _u.mutation.PetsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -429,6 +455,26 @@ func (_u *CategoryUpdateOne) ClearInts() *CategoryUpdateOne {
 	return _u
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *CategoryUpdateOne) SetDeletedAt(v time.Time) *CategoryUpdateOne {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *CategoryUpdateOne) SetNillableDeletedAt(v *time.Time) *CategoryUpdateOne {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *CategoryUpdateOne) ClearDeletedAt() *CategoryUpdateOne {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
 // AddPetIDs adds the "pets" edge to the Pet entity by IDs.
 func (_u *CategoryUpdateOne) AddPetIDs(ids ...int) *CategoryUpdateOne {
 	_u.mutation.AddPetIDs(ids...)
@@ -585,6 +631,12 @@ func (_u *CategoryUpdateOne) sqlSave(ctx context.Context) (_node *Category, err
 	if _u.mutation.IntsCleared() {
 		_spec.ClearField(category.FieldInts, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(category.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(category.FieldDeletedAt, field.TypeTime)
+	}
 	if _u.mutation.PetsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -15,11 +15,15 @@ import (
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/category"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/follows"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/friendship"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/history"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/idempotencykey"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/pet"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/post"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/settings"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/skipped"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/user"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/webhookdelivery"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/webhooksubscription"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -80,14 +84,18 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			category.Table:   category.ValidColumn,
-			follows.Table:    follows.ValidColumn,
-			friendship.Table: friendship.ValidColumn,
-			pet.Table:        pet.ValidColumn,
-			post.Table:       post.ValidColumn,
-			settings.Table:   settings.ValidColumn,
-			skipped.Table:    skipped.ValidColumn,
-			user.Table:       user.ValidColumn,
+			category.Table:            category.ValidColumn,
+			follows.Table:             follows.ValidColumn,
+			friendship.Table:          friendship.ValidColumn,
+			history.Table:             history.ValidColumn,
+			idempotencykey.Table:      idempotencykey.ValidColumn,
+			pet.Table:                 pet.ValidColumn,
+			post.Table:                post.ValidColumn,
+			settings.Table:            settings.ValidColumn,
+			skipped.Table:             skipped.ValidColumn,
+			user.Table:                user.ValidColumn,
+			webhookdelivery.Table:     webhookdelivery.ValidColumn,
+			webhooksubscription.Table: webhooksubscription.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
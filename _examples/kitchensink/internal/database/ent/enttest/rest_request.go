@@ -64,13 +64,24 @@ func (r Response[T]) Must(t *testing.T) Response[T] {
 
 // Request executes a request against the TestServer, and returns the response recorder and
 // response, auto-marshalling JSON to the provided type. If T is "string", the response body
-// is returned as-is.
+// is returned as-is. If _data is an [io.Reader], it is sent as the request body as-is.
 func Request[T any](ctx context.Context, s *TestServer, _method, _path string, _data any) (_resp Response[T]) {
 	s.t.Helper()
+	return RequestWithHeaders[T](ctx, s, _method, _path, nil, _data)
+}
+
+// RequestWithHeaders is the same as [Request], but also sets the provided request headers,
+// which take precedence over any default headers (e.g. Content-Type).
+func RequestWithHeaders[T any](ctx context.Context, s *TestServer, _method, _path string, _headers http.Header, _data any) (_resp Response[T]) {
+	s.t.Helper()
 
 	var _body io.Reader
+	_encoded := false
 
-	if _data != nil && _data != http.NoBody {
+	if _reader, ok := _data.(io.Reader); ok {
+		// Raw bodies (e.g. CSV or multipart forms) are sent as-is.
+		_body = _reader
+	} else if _data != nil {
 		_buf := &bytes.Buffer{}
 		_enc := json.NewEncoder(_buf)
 		err := _enc.Encode(_data)
@@ -78,14 +89,19 @@ func Request[T any](ctx context.Context, s *TestServer, _method, _path string, _
 			s.t.Fatalf("failed to encode request body: %v", err)
 		}
 		_body = _buf
+		_encoded = true
 	}
 
 	_req := httptest.NewRequest(_method, _path, _body).WithContext(ctx)
 
-	if _data != nil && _data != http.NoBody {
+	if _encoded {
 		_req.Header.Set("Content-Type", "application/json")
 	}
 
+	for k, v := range _headers {
+		_req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
 	_resp.Data = httptest.NewRecorder()
 	_resp.Data.Body = &bytes.Buffer{}
 
@@ -96,7 +112,7 @@ func Request[T any](ctx context.Context, s *TestServer, _method, _path string, _
 	}
 
 	if _resp.Data.Code == http.StatusNoContent || _resp.Data.Code < 200 || _resp.Data.Code >= 300 {
-		if _resp.Data.Code == http.StatusNoContent {
+		if _resp.Data.Code == http.StatusNoContent || _resp.Data.Code == http.StatusNotModified {
 			return _resp
 		}
 
@@ -111,12 +127,14 @@ func Request[T any](ctx context.Context, s *TestServer, _method, _path string, _
 		}
 	}
 
-	if _, ok := any(_resp.Value).(string); ok {
-		*_resp.Value = any(_resp.Data.Body.String()).(T) //nolint:erespcheck
+	_resp.Value = new(T)
+
+	// Non-JSON responses (e.g. exports) can be requested as a string.
+	if _raw, ok := any(_resp.Value).(*string); ok {
+		*_raw = _resp.Data.Body.String()
 		return _resp
 	}
 
-	_resp.Value = new(T)
 	err := json.Unmarshal(_resp.Data.Body.Bytes(), _resp.Value)
 	if err != nil {
 		s.t.Fatalf("failed to decode response: %v", err)
@@ -19,6 +19,7 @@ var (
 		{Name: "nillable", Type: field.TypeString, Default: "test"},
 		{Name: "strings", Type: field.TypeJSON, Nullable: true},
 		{Name: "ints", Type: field.TypeJSON, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
 	}
 	// CategoriesTable holds the schema information for the "categories" table.
 	CategoriesTable = &schema.Table{
@@ -86,6 +87,47 @@ var (
 			},
 		},
 	}
+	// HistoriesColumns holds the columns for the "histories" table.
+	HistoriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "entity_type", Type: field.TypeString},
+		{Name: "entity_id", Type: field.TypeString},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "action", Type: field.TypeString},
+		{Name: "actor", Type: field.TypeString, Default: ""},
+		{Name: "request_id", Type: field.TypeString, Default: ""},
+		{Name: "entity", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// HistoriesTable holds the schema information for the "histories" table.
+	HistoriesTable = &schema.Table{
+		Name:       "histories",
+		Columns:    HistoriesColumns,
+		PrimaryKey: []*schema.Column{HistoriesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "history_entity_type_entity_id_version",
+				Unique:  true,
+				Columns: []*schema.Column{HistoriesColumns[1], HistoriesColumns[2], HistoriesColumns[3]},
+			},
+		},
+	}
+	// IdempotencyKeysColumns holds the columns for the "idempotency_keys" table.
+	IdempotencyKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "hash", Type: field.TypeString},
+		{Name: "status_code", Type: field.TypeInt, Default: 0},
+		{Name: "header", Type: field.TypeJSON, Nullable: true},
+		{Name: "body", Type: field.TypeBytes, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// IdempotencyKeysTable holds the schema information for the "idempotency_keys" table.
+	IdempotencyKeysTable = &schema.Table{
+		Name:       "idempotency_keys",
+		Columns:    IdempotencyKeysColumns,
+		PrimaryKey: []*schema.Column{IdempotencyKeysColumns[0]},
+	}
 	// PetsColumns holds the columns for the "pets" table.
 	PetsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -93,6 +135,7 @@ var (
 		{Name: "nicknames", Type: field.TypeJSON, Nullable: true},
 		{Name: "age", Type: field.TypeInt},
 		{Name: "type", Type: field.TypeEnum, Enums: []string{"DOG", "CAT", "BIRD", "FISH", "AMPHIBIAN", "REPTILE", "OTHER"}},
+		{Name: "version", Type: field.TypeInt, Default: 1},
 		{Name: "user_pets", Type: field.TypeUUID, Nullable: true},
 	}
 	// PetsTable holds the schema information for the "pets" table.
@@ -103,7 +146,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "pets_users_pets",
-				Columns:    []*schema.Column{PetsColumns[5]},
+				Columns:    []*schema.Column{PetsColumns[6]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -170,6 +213,7 @@ var (
 		{Name: "avatar", Type: field.TypeBytes, Nullable: true, Size: 1048576},
 		{Name: "password_hashed", Type: field.TypeString},
 		{Name: "github_data", Type: field.TypeJSON, Nullable: true},
+		{Name: "github_id", Type: field.TypeInt, Unique: true, Nullable: true},
 		{Name: "any_data", Type: field.TypeJSON, Nullable: true},
 		{Name: "profile_url", Type: field.TypeOther, Nullable: true, SchemaType: map[string]string{"postgres": "varchar", "sqlite3": "text"}},
 		{Name: "last_authenticated_at", Type: field.TypeTime, Nullable: true},
@@ -183,12 +227,54 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "users_settings_admins",
-				Columns:    []*schema.Column{UsersColumns[14]},
+				Columns:    []*schema.Column{UsersColumns[15]},
 				RefColumns: []*schema.Column{SettingsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 		},
 	}
+	// WebhookDeliveriesColumns holds the columns for the "webhook_deliveries" table.
+	WebhookDeliveriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "key", Type: field.TypeString, Unique: true, Size: 64},
+		{Name: "subscription_id", Type: field.TypeString},
+		{Name: "event", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeBytes},
+		{Name: "status", Type: field.TypeString, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "last_error", Type: field.TypeString, Default: ""},
+		{Name: "last_status_code", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// WebhookDeliveriesTable holds the schema information for the "webhook_deliveries" table.
+	WebhookDeliveriesTable = &schema.Table{
+		Name:       "webhook_deliveries",
+		Columns:    WebhookDeliveriesColumns,
+		PrimaryKey: []*schema.Column{WebhookDeliveriesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webhookdelivery_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[5], WebhookDeliveriesColumns[9]},
+			},
+		},
+	}
+	// WebhookSubscriptionsColumns holds the columns for the "webhook_subscriptions" table.
+	WebhookSubscriptionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "url", Type: field.TypeString},
+		{Name: "secret", Type: field.TypeString},
+		{Name: "events", Type: field.TypeJSON, Nullable: true},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+	}
+	// WebhookSubscriptionsTable holds the schema information for the "webhook_subscriptions" table.
+	WebhookSubscriptionsTable = &schema.Table{
+		Name:       "webhook_subscriptions",
+		Columns:    WebhookSubscriptionsColumns,
+		PrimaryKey: []*schema.Column{WebhookSubscriptionsColumns[0]},
+	}
 	// CategoryPetsColumns holds the columns for the "category_pets" table.
 	CategoryPetsColumns = []*schema.Column{
 		{Name: "category_id", Type: field.TypeInt},
@@ -244,11 +330,15 @@ var (
 		CategoriesTable,
 		FollowsTable,
 		FriendshipsTable,
+		HistoriesTable,
+		IdempotencyKeysTable,
 		PetsTable,
 		PostsTable,
 		SettingsTable,
 		SkippedsTable,
 		UsersTable,
+		WebhookDeliveriesTable,
+		WebhookSubscriptionsTable,
 		CategoryPetsTable,
 		PetFriendsTable,
 	}
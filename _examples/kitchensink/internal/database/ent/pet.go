@@ -27,6 +27,8 @@ type Pet struct {
 	Age int `json:"age"`
 	// Type holds the value of the "type" field.
 	Type pet.Type `json:"type"`
+	// Version of the pet, incremented on every update.
+	Version int `json:"version"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PetQuery when eager-loading is set.
 	Edges        PetEdges `json:"edges"`
@@ -105,7 +107,7 @@ func (*Pet) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case pet.FieldNicknames:
 			values[i] = new([]byte)
-		case pet.FieldID, pet.FieldAge:
+		case pet.FieldID, pet.FieldAge, pet.FieldVersion:
 			values[i] = new(sql.NullInt64)
 		case pet.FieldName, pet.FieldType:
 			values[i] = new(sql.NullString)
@@ -158,6 +160,12 @@ func (_m *Pet) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Type = pet.Type(value.String)
 			}
+		case pet.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				_m.Version = int(value.Int64)
+			}
 		case pet.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullScanner); !ok {
 				return fmt.Errorf("unexpected type %T for field user_pets", values[i])
@@ -237,6 +245,9 @@ func (_m *Pet) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("type=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Type))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Version))
 	builder.WriteByte(')')
 	return builder.String()
 }
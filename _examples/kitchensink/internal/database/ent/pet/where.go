@@ -63,6 +63,11 @@ func Age(v int) predicate.Pet {
 	return predicate.Pet(sql.FieldEQ(FieldAge, v))
 }
 
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldEQ(FieldVersion, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Pet {
 	return predicate.Pet(sql.FieldEQ(FieldName, v))
@@ -198,6 +203,46 @@ func TypeNotIn(vs ...Type) predicate.Pet {
 	return predicate.Pet(sql.FieldNotIn(FieldType, vs...))
 }
 
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.Pet {
+	return predicate.Pet(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.Pet {
+	return predicate.Pet(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.Pet {
+	return predicate.Pet(sql.FieldLTE(FieldVersion, v))
+}
+
 // HasCategories applies the HasEdge predicate on the "categories" edge.
 func HasCategories() predicate.Pet {
 	return predicate.Pet(func(s *sql.Selector) {
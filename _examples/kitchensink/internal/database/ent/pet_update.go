@@ -98,6 +98,27 @@ func (_u *PetUpdate) SetNillableType(v *pet.Type) *PetUpdate {
 	return _u
 }
 
+// SetVersion sets the "version" field.
+func (_u *PetUpdate) SetVersion(v int) *PetUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *PetUpdate) SetNillableVersion(v *int) *PetUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *PetUpdate) AddVersion(v int) *PetUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
 // AddCategoryIDs adds the "categories" edge to the Category entity by IDs.
 func (_u *PetUpdate) AddCategoryIDs(ids ...int) *PetUpdate {
 	_u.mutation.AddCategoryIDs(ids...)
@@ -313,6 +334,12 @@ func (_u *PetUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.GetType(); ok {
 		_spec.SetField(pet.FieldType, field.TypeEnum, value)
 	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(pet.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(pet.FieldVersion, field.TypeInt, value)
+	}
 	if _u.mutation.CategoriesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -576,6 +603,27 @@ func (_u *PetUpdateOne) SetNillableType(v *pet.Type) *PetUpdateOne {
 	return _u
 }
 
+// SetVersion sets the "version" field.
+func (_u *PetUpdateOne) SetVersion(v int) *PetUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *PetUpdateOne) SetNillableVersion(v *int) *PetUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *PetUpdateOne) AddVersion(v int) *PetUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
 // AddCategoryIDs adds the "categories" edge to the Category entity by IDs.
 func (_u *PetUpdateOne) AddCategoryIDs(ids ...int) *PetUpdateOne {
 	_u.mutation.AddCategoryIDs(ids...)
@@ -821,6 +869,12 @@ func (_u *PetUpdateOne) sqlSave(ctx context.Context) (_node *Pet, err error) {
 	if value, ok := _u.mutation.GetType(); ok {
 		_spec.SetField(pet.FieldType, field.TypeEnum, value)
 	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(pet.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(pet.FieldVersion, field.TypeInt, value)
+	}
 	if _u.mutation.CategoriesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -3,7 +3,11 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	github "github.com/google/go-github/v81/github"
@@ -51,7 +55,7 @@ func (c *CreateCategoryParams) Exec(ctx context.Context, _builder *ent.CategoryC
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadCategory(_query.Where(category.ID(_result.ID))).Only(ctx)
+	return eagerLoadCategory(_query.Where(category.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // CreateFollowParams defines parameters for creating a Follow via a POST request.
@@ -75,10 +79,10 @@ func (c *CreateFollowParams) Exec(ctx context.Context, _builder *ent.FollowsCrea
 		return nil, err
 	}
 	// Since Follow entities have a composite ID, we have to query by all known FK fields.
-	return EagerLoadFollow(_query.Where(
+	return eagerLoadFollow(_query.Where(
 		follows.UserIDEQ(_result.UserID),
 		follows.PetIDEQ(_result.PetID),
-	)).Only(ctx)
+	), nil, nil).Only(ctx)
 }
 
 // CreateFriendshipParams defines parameters for creating a Friendship via a POST request.
@@ -105,11 +109,12 @@ func (c *CreateFriendshipParams) Exec(ctx context.Context, _builder *ent.Friends
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadFriendship(_query.Where(friendship.ID(_result.ID))).Only(ctx)
+	return eagerLoadFriendship(_query.Where(friendship.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // CreatePetParams defines parameters for creating a Pet via a POST request.
 type CreatePetParams struct {
+	Included
 	Name      string   `json:"name"`
 	Nicknames []string `json:"nicknames,omitempty"`
 	Age       int      `json:"age"`
@@ -117,7 +122,7 @@ type CreatePetParams struct {
 	// Categories that the pet belongs to.
 	Categories []int `json:"categories,omitempty"`
 	// The user that owns the pet.
-	Owner *uuid.UUID `json:"owner,omitempty"`
+	Owner *Nested[uuid.UUID, CreateUserParams] `json:"owner,omitempty"`
 	// Pets that this pet is friends with.
 	Friends []int `json:"friends,omitempty"`
 	// Users that this pet is followed by.
@@ -132,23 +137,176 @@ func (c *CreatePetParams) ApplyInputs(_builder *ent.PetCreate) *ent.PetCreate {
 	_builder.SetAge(c.Age)
 	_builder.SetType(c.Type)
 	_builder.AddCategoryIDs(c.Categories...)
-	if c.Owner != nil {
-		_builder.SetOwnerID(*c.Owner)
+	if c.Owner != nil && c.Owner.ID != nil {
+		_builder.SetOwnerID(*c.Owner.ID)
 	}
 	_builder.AddFriendIDs(c.Friends...)
 	_builder.AddFollowedByIDs(c.FollowedBy...)
 	return _builder
 }
 
-// Exec wraps all logic (mapping all provided values to the builder), creates the entity,
-// and does another query (using provided query as base) to get the entity, with all eager
-// loaded edges.
-func (c *CreatePetParams) Exec(ctx context.Context, _builder *ent.PetCreate, _query *ent.PetQuery) (*ent.Pet, error) {
-	_result, err := c.ApplyInputs(_builder).Save(ctx)
+// create creates the entity, along with any nested entities, using the provided client
+// (which should be transactional). The provided functions are applied to the builder
+// before saving, which is used to link nested entities to their parent.
+func (c *CreatePetParams) create(ctx context.Context, _db *ent.Client, _fns ...func(*ent.PetCreate)) (*ent.Pet, error) {
+	_builder := c.ApplyInputs(_db.Pet.Create())
+	if c.Owner != nil && c.Owner.Create != nil {
+		_nested, err := c.Owner.Create.create(ctx, _db)
+		if err != nil {
+			return nil, err
+		}
+		_builder.SetOwnerID(_nested.ID)
+	}
+	for _, _fn := range _fns {
+		_fn(_builder)
+	}
+
+	_result, err := _builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return _result, nil
+}
+
+// nestedCreates invokes the provided function with the name of the entity type of each
+// nested entity which create() would create, recursively.
+func (c *CreatePetParams) nestedCreates(_fn func(_entity string) error) error {
+	if c.Owner != nil && c.Owner.Create != nil {
+		if err := _fn("User"); err != nil {
+			return err
+		}
+		if err := c.Owner.Create.nestedCreates(_fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exec wraps all logic (mapping all provided values to the builders), creates the entity
+// along with any nested entities in a single transaction, and does another query to get
+// the entity, with all eager loaded edges.
+func (c *CreatePetParams) Exec(ctx context.Context, _db *ent.Client) (_result *ent.Pet, err error) {
+	if err = c.Included.Validate(PetIncludeConfig); err != nil {
+		return nil, err
+	}
+	_tx, err := _db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = _tx.Rollback()
+		}
+	}()
+
+	_created, err := c.create(ctx, _tx.Client())
+	if err != nil {
+		return nil, err
+	}
+
+	_result, err = eagerLoadPet(
+		_tx.Pet.Query().Where(pet.ID(_created.ID)),
+		c.Included.Include,
+		nil,
+	).Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = _tx.Commit(); err != nil {
+		return nil, err
+	}
+	return _result, nil
+}
+
+// CreatePetBulkParams defines parameters for creating multiple Pet entities
+// via a single POST request. The request body is a JSON array of [CreatePetParams].
+type CreatePetBulkParams struct {
+	Included
+	Items []*CreatePetParams
+}
+
+// UnmarshalJSON decodes each item of the provided JSON array individually, so decoding
+// errors can be reported with the index of the invalid item.
+func (c *CreatePetBulkParams) UnmarshalJSON(data []byte) error {
+	var _items []json.RawMessage
+	if err := json.Unmarshal(data, &_items); err != nil {
+		return err
+	}
+
+	c.Items = make([]*CreatePetParams, len(_items))
+	for i, _item := range _items {
+		_dec := json.NewDecoder(bytes.NewReader(_item))
+		_dec.DisallowUnknownFields()
+		if err := _dec.Decode(&c.Items[i]); err != nil {
+			return &ErrBulkItem{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// Exec wraps all logic (mapping all provided values to the builders), creates all entities
+// in a single transaction, and does another query to get the entities, with all eager
+// loaded edges. Entities are returned in the same order as they were provided.
+func (c *CreatePetBulkParams) Exec(ctx context.Context, _db *ent.Client) (_results []*ent.Pet, err error) {
+	if len(c.Items) == 0 {
+		return nil, &ErrBadRequest{Err: errors.New("no items provided")}
+	}
+	if len(c.Items) > 100 {
+		return nil, &ErrBadRequest{Err: fmt.Errorf("too many items provided (max 100): %d", len(c.Items))}
+	}
+	if err = c.Included.Validate(PetIncludeConfig); err != nil {
+		return nil, err
+	}
+
+	_tx, err := _db.Tx(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadPet(_query.Where(pet.ID(_result.ID))).Only(ctx)
+	defer func() {
+		if err != nil {
+			_ = _tx.Rollback()
+		}
+	}()
+	_created := make([]*ent.Pet, len(c.Items))
+	for i, _item := range c.Items {
+		if _item == nil {
+			return nil, &ErrBulkItem{Index: i, Err: &ErrBadRequest{Err: errors.New("item cannot be null")}}
+		}
+		_created[i], err = _item.create(ctx, _tx.Client())
+		if err != nil {
+			return nil, &ErrBulkItem{Index: i, Err: err}
+		}
+	}
+
+	_ids := make([]int, len(_created))
+	for i := range _created {
+		_ids[i] = _created[i].ID
+	}
+
+	_entities, err := eagerLoadPet(
+		_tx.Pet.Query().Where(pet.IDIn(_ids...)),
+		c.Included.Include,
+		nil,
+	).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_byID := make(map[int]*ent.Pet, len(_entities))
+	for _, _entity := range _entities {
+		_byID[_entity.ID] = _entity
+	}
+
+	_results = make([]*ent.Pet, len(_ids))
+	for i, _id := range _ids {
+		_results[i] = _byID[_id]
+	}
+
+	if err = _tx.Commit(); err != nil {
+		return nil, err
+	}
+	return _results, nil
 }
 
 // CreatePostParams defines parameters for creating a Post via a POST request.
@@ -165,6 +323,28 @@ func (c *CreatePostParams) ApplyInputs(_builder *ent.PostCreate) *ent.PostCreate
 	return _builder
 }
 
+// create creates the entity, along with any nested entities, using the provided client
+// (which should be transactional). The provided functions are applied to the builder
+// before saving, which is used to link nested entities to their parent.
+func (c *CreatePostParams) create(ctx context.Context, _db *ent.Client, _fns ...func(*ent.PostCreate)) (*ent.Post, error) {
+	_builder := c.ApplyInputs(_db.Post.Create())
+	for _, _fn := range _fns {
+		_fn(_builder)
+	}
+
+	_result, err := _builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return _result, nil
+}
+
+// nestedCreates invokes the provided function with the name of the entity type of each
+// nested entity which create() would create, recursively.
+func (c *CreatePostParams) nestedCreates(_fn func(_entity string) error) error {
+	return nil
+}
+
 // Exec wraps all logic (mapping all provided values to the builder), creates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
@@ -173,7 +353,7 @@ func (c *CreatePostParams) Exec(ctx context.Context, _builder *ent.PostCreate, _
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadPost(_query.Where(post.ID(_result.ID))).Only(ctx)
+	return eagerLoadPost(_query.Where(post.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // CreateSettingParams defines parameters for creating a Setting via a POST request.
@@ -200,11 +380,12 @@ func (c *CreateSettingParams) Exec(ctx context.Context, _builder *ent.SettingsCr
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadSetting(_query.Where(settings.ID(_result.ID))).Only(ctx)
+	return eagerLoadSetting(_query.Where(settings.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // CreateUserParams defines parameters for creating a User via a POST request.
 type CreateUserParams struct {
+	Included
 	ID *uuid.UUID `json:"id"`
 	// Name of the user.
 	Name string `json:"name"`
@@ -222,18 +403,20 @@ type CreateUserParams struct {
 	PasswordHashed string `json:"password_hashed"`
 	// The github user raw JSON data.
 	GithubData *github.User `json:"github_data,omitempty"`
+	// ID of the user on GitHub, used as a natural key when syncing users.
+	GithubID *int `json:"github_id,omitempty"`
 	// Any data that is not defined in the schema.
 	AnyData             *github.User          `json:"any_data,omitempty"`
 	ProfileURL          *schema.ExampleValuer `json:"profile_url,omitempty"`
 	LastAuthenticatedAt *time.Time            `json:"last_authenticated_at,omitempty"`
 	// Pets owned by the user.
-	Pets []int `json:"pets,omitempty"`
+	Pets []Nested[int, CreatePetParams] `json:"pets,omitempty"`
 	// Pets that the user is following.
 	FollowedPets []int `json:"followed_pets,omitempty"`
 	// Friends of the user.
-	Friends     []uuid.UUID `json:"friends,omitempty"`
-	Posts       []int       `json:"posts,omitempty"`
-	Friendships []int       `json:"friendships,omitempty"`
+	Friends     []uuid.UUID                     `json:"friends,omitempty"`
+	Posts       []Nested[int, CreatePostParams] `json:"posts,omitempty"`
+	Friendships []int                           `json:"friendships,omitempty"`
 }
 
 func (c *CreateUserParams) ApplyInputs(_builder *ent.UserCreate) *ent.UserCreate {
@@ -260,6 +443,9 @@ func (c *CreateUserParams) ApplyInputs(_builder *ent.UserCreate) *ent.UserCreate
 	if c.GithubData != nil {
 		_builder.SetGithubData(c.GithubData)
 	}
+	if c.GithubID != nil {
+		_builder.SetGithubID(*c.GithubID)
+	}
 	if c.AnyData != nil {
 		_builder.SetAnyData(c.AnyData)
 	}
@@ -269,21 +455,211 @@ func (c *CreateUserParams) ApplyInputs(_builder *ent.UserCreate) *ent.UserCreate
 	if c.LastAuthenticatedAt != nil {
 		_builder.SetLastAuthenticatedAt(*c.LastAuthenticatedAt)
 	}
-	_builder.AddPetIDs(c.Pets...)
+	for _, _item := range c.Pets {
+		if _item.ID != nil {
+			_builder.AddPetIDs(*_item.ID)
+		}
+	}
 	_builder.AddFollowedPetIDs(c.FollowedPets...)
 	_builder.AddFriendIDs(c.Friends...)
-	_builder.AddPostIDs(c.Posts...)
+	for _, _item := range c.Posts {
+		if _item.ID != nil {
+			_builder.AddPostIDs(*_item.ID)
+		}
+	}
 	_builder.AddFriendshipIDs(c.Friendships...)
 	return _builder
 }
 
-// Exec wraps all logic (mapping all provided values to the builder), creates the entity,
-// and does another query (using provided query as base) to get the entity, with all eager
-// loaded edges.
-func (c *CreateUserParams) Exec(ctx context.Context, _builder *ent.UserCreate, _query *ent.UserQuery) (*ent.User, error) {
-	_result, err := c.ApplyInputs(_builder).Save(ctx)
+// create creates the entity, along with any nested entities, using the provided client
+// (which should be transactional). The provided functions are applied to the builder
+// before saving, which is used to link nested entities to their parent.
+func (c *CreateUserParams) create(ctx context.Context, _db *ent.Client, _fns ...func(*ent.UserCreate)) (*ent.User, error) {
+	_builder := c.ApplyInputs(_db.User.Create())
+	for _, _fn := range _fns {
+		_fn(_builder)
+	}
+
+	_result, err := _builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, _item := range c.Pets {
+		if _item.Create == nil {
+			continue
+		}
+		_, err = _item.Create.create(ctx, _db, func(_b *ent.PetCreate) { _b.SetOwnerID(_result.ID) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, _item := range c.Posts {
+		if _item.Create == nil {
+			continue
+		}
+		_, err = _item.Create.create(ctx, _db, func(_b *ent.PostCreate) { _b.SetAuthorID(_result.ID) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	return _result, nil
+}
+
+// nestedCreates invokes the provided function with the name of the entity type of each
+// nested entity which create() would create, recursively.
+func (c *CreateUserParams) nestedCreates(_fn func(_entity string) error) error {
+	for _, _item := range c.Pets {
+		if _item.Create == nil {
+			continue
+		}
+		if err := _fn("Pet"); err != nil {
+			return err
+		}
+		if err := _item.Create.nestedCreates(_fn); err != nil {
+			return err
+		}
+	}
+	for _, _item := range c.Posts {
+		if _item.Create == nil {
+			continue
+		}
+		if err := _fn("Post"); err != nil {
+			return err
+		}
+		if err := _item.Create.nestedCreates(_fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exec wraps all logic (mapping all provided values to the builders), creates the entity
+// along with any nested entities in a single transaction, and does another query to get
+// the entity, with all eager loaded edges.
+func (c *CreateUserParams) Exec(ctx context.Context, _db *ent.Client) (_result *ent.User, err error) {
+	if err = c.Included.Validate(UserIncludeConfig); err != nil {
+		return nil, err
+	}
+	_tx, err := _db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = _tx.Rollback()
+		}
+	}()
+
+	_created, err := c.create(ctx, _tx.Client())
+	if err != nil {
+		return nil, err
+	}
+
+	_result, err = eagerLoadUser(
+		_tx.User.Query().Where(user.ID(_created.ID)),
+		c.Included.Include,
+		nil,
+	).Only(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadUser(_query.Where(user.ID(_result.ID))).Only(ctx)
+
+	if err = _tx.Commit(); err != nil {
+		return nil, err
+	}
+	return _result, nil
+}
+
+// Upsert wraps all logic (mapping all provided values to the builder), creates the entity
+// or updates the existing entity with the same github_id, and does
+// another query to get the entity, with all eager loaded edges. Returns true if the entity
+// was created, which is best-effort with concurrent upserts, as the existence check is a
+// separate statement.
+func (c *CreateUserParams) Upsert(ctx context.Context, _db *ent.Client) (_result *ent.User, _created bool, err error) {
+	if err = c.Included.Validate(UserIncludeConfig); err != nil {
+		return nil, false, err
+	}
+	for _, _item := range c.Pets {
+		if _item.Create != nil {
+			return nil, false, &ErrBadRequest{Err: errors.New("nested creates are not supported when upserting: pets")}
+		}
+	}
+	for _, _item := range c.Posts {
+		if _item.Create != nil {
+			return nil, false, &ErrBadRequest{Err: errors.New("nested creates are not supported when upserting: posts")}
+		}
+	}
+
+	_tx, err := _db.Tx(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = _tx.Rollback()
+		}
+	}()
+
+	_exists, err := _tx.User.Query().Where(
+		user.GithubIDEQ(*c.GithubID),
+	).Exist(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_id, err := c.ApplyInputs(_tx.User.Create()).
+		OnConflictColumns(user.FieldGithubID).
+		UpdateNewValues().
+		ID(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_result, err = eagerLoadUser(
+		_tx.User.Query().Where(user.ID(_id)),
+		c.Included.Include,
+		nil,
+	).Only(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err = _tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return _result, !_exists, nil
+}
+
+// Nested holds either the ID of an existing entity, or the parameters to create a new
+// entity, for edges which support nested creates. When decoding, JSON objects are treated
+// as create parameters, and any other value as an ID.
+type Nested[I, P any] struct {
+	ID     *I
+	Create *P
+}
+
+// UnmarshalJSON decodes either an ID, or create parameters, into the nested value.
+func (n *Nested[I, P]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return errors.New("expected an ID or an object, got null")
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		_dec := json.NewDecoder(bytes.NewReader(data))
+		_dec.DisallowUnknownFields()
+		n.Create = new(P)
+		return _dec.Decode(n.Create)
+	}
+
+	n.ID = new(I)
+	return json.Unmarshal(data, n.ID)
+}
+
+// MarshalJSON encodes either the create parameters, or the ID, of the nested value.
+func (n Nested[I, P]) MarshalJSON() ([]byte, error) {
+	if n.Create != nil {
+		return json.Marshal(n.Create)
+	}
+	return json.Marshal(n.ID)
 }
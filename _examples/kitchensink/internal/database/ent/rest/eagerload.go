@@ -4,76 +4,235 @@ package rest
 
 import (
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/category"
 )
 
+// edgeLoad describes how an edge should be eager-loaded.
+type edgeLoad struct {
+	Fields   []string // Fields of the edge to select (sparse fieldsets), if any.
+	Includes []string // Nested edges of the edge to include, if any.
+	Included bool     // Whether the edge was explicitly included.
+}
+
+// loadEdge returns how the provided edge should be eager-loaded, or nil if it shouldn't
+// be loaded at all. Edges are loaded if they are always eager-loaded (and weren't excluded
+// by a field selection), or if they were explicitly included.
+func loadEdge(_edge string, _eager bool, _fields, _includes []string) *edgeLoad {
+	_selected, _nestedFields := selectEdge(_fields, _edge)
+	_included, _nestedIncludes := includeEdge(_includes, _edge)
+
+	if (!_eager || !_selected) && !_included {
+		return nil
+	}
+	return &edgeLoad{Fields: _nestedFields, Includes: _nestedIncludes, Included: _included}
+}
+
 // EagerLoadCategory eager-loads the edges of a Category entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadCategory(_query *ent.CategoryQuery) *ent.CategoryQuery {
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadCategory(_query *ent.CategoryQuery, _fields ...string) *ent.CategoryQuery {
+	return eagerLoadCategory(_query, nil, _fields)
+}
+
+// eagerLoadCategory is similar to [EagerLoadCategory], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadCategory(_query *ent.CategoryQuery, _includes, _fields []string) *ent.CategoryQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(CategorySelectConfig, _fields)...)
+	}
 	return _query
 }
 
 // EagerLoadFollow eager-loads the edges of a Follow entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadFollow(_query *ent.FollowsQuery) *ent.FollowsQuery {
-	return _query.WithUser(
-		func(e *ent.UserQuery) {
-			applySortingUser(e, "name", "asc")
-		},
-	).WithPet(
-		func(e *ent.PetQuery) {
-			applySortingPet(e, "name", "asc")
-		},
-	)
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadFollow(_query *ent.FollowsQuery, _fields ...string) *ent.FollowsQuery {
+	return eagerLoadFollow(_query, nil, _fields)
+}
+
+// eagerLoadFollow is similar to [EagerLoadFollow], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadFollow(_query *ent.FollowsQuery, _includes, _fields []string) *ent.FollowsQuery {
+	if _load := loadEdge("user", true, _fields, _includes); _load != nil {
+		_query.WithUser(
+			func(e *ent.UserQuery) {
+				applySortingUser(e, "name", "asc")
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(UserSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	if _load := loadEdge("pet", true, _fields, _includes); _load != nil {
+		_query.WithPet(
+			func(e *ent.PetQuery) {
+				applySortingPet(e, "name", "asc")
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(PetSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	return _query
 }
 
 // EagerLoadFriendship eager-loads the edges of a Friendship entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadFriendship(_query *ent.FriendshipQuery) *ent.FriendshipQuery {
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadFriendship(_query *ent.FriendshipQuery, _fields ...string) *ent.FriendshipQuery {
+	return eagerLoadFriendship(_query, nil, _fields)
+}
+
+// eagerLoadFriendship is similar to [EagerLoadFriendship], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadFriendship(_query *ent.FriendshipQuery, _includes, _fields []string) *ent.FriendshipQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(FriendshipSelectConfig, _fields)...)
+	}
 	return _query
 }
 
 // EagerLoadPet eager-loads the edges of a Pet entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadPet(_query *ent.PetQuery) *ent.PetQuery {
-	return _query.WithCategories(
-		func(e *ent.CategoryQuery) {
-			applySortingCategory(e, "id", "asc")
-			e.Limit(1000)
-		},
-	).WithOwner(
-		func(e *ent.UserQuery) {
-			applySortingUser(e, "name", "asc")
-		},
-	)
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadPet(_query *ent.PetQuery, _fields ...string) *ent.PetQuery {
+	return eagerLoadPet(_query, nil, _fields)
+}
+
+// eagerLoadPet is similar to [EagerLoadPet], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadPet(_query *ent.PetQuery, _includes, _fields []string) *ent.PetQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(PetSelectConfig, _fields)...)
+	}
+	if _load := loadEdge("categories", true, _fields, _includes); _load != nil {
+		_query.WithCategories(
+			func(e *ent.CategoryQuery) {
+				e.Where(category.DeletedAtIsNil())
+				applySortingCategory(e, "id", "asc")
+				e.Limit(1000)
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(CategorySelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	if _load := loadEdge("owner", true, _fields, _includes); _load != nil {
+		_query.WithOwner(
+			func(e *ent.UserQuery) {
+				applySortingUser(e, "name", "asc")
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(UserSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	if _load := loadEdge("friends", false, _fields, _includes); _load != nil {
+		_query.WithFriends(
+			func(e *ent.PetQuery) {
+				applySortingPet(e, "name", "asc")
+				e.Limit(1000)
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(PetSelectConfig, _load.Fields)...)
+				}
+				if _load.Included {
+					eagerLoadPet(e, _load.Includes, nil)
+				}
+			},
+		)
+	}
+	return _query
 }
 
 // EagerLoadPost eager-loads the edges of a Post entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadPost(_query *ent.PostQuery) *ent.PostQuery {
-	return _query.WithAuthor(
-		func(e *ent.UserQuery) {
-			applySortingUser(e, "name", "asc")
-		},
-	)
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadPost(_query *ent.PostQuery, _fields ...string) *ent.PostQuery {
+	return eagerLoadPost(_query, nil, _fields)
+}
+
+// eagerLoadPost is similar to [EagerLoadPost], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadPost(_query *ent.PostQuery, _includes, _fields []string) *ent.PostQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(PostSelectConfig, _fields)...)
+	}
+	if _load := loadEdge("author", true, _fields, _includes); _load != nil {
+		_query.WithAuthor(
+			func(e *ent.UserQuery) {
+				applySortingUser(e, "name", "asc")
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(UserSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	return _query
 }
 
 // EagerLoadSetting eager-loads the edges of a Setting entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadSetting(_query *ent.SettingsQuery) *ent.SettingsQuery {
-	return _query.WithAdmins(
-		func(e *ent.UserQuery) {
-			applySortingUser(e, "name", "asc")
-			e.Limit(1000)
-		},
-	)
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadSetting(_query *ent.SettingsQuery, _fields ...string) *ent.SettingsQuery {
+	return eagerLoadSetting(_query, nil, _fields)
+}
+
+// eagerLoadSetting is similar to [EagerLoadSetting], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadSetting(_query *ent.SettingsQuery, _includes, _fields []string) *ent.SettingsQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(SettingSelectConfig, _fields)...)
+	}
+	if _load := loadEdge("admins", true, _fields, _includes); _load != nil {
+		_query.WithAdmins(
+			func(e *ent.UserQuery) {
+				applySortingUser(e, "name", "asc")
+				e.Limit(1000)
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(UserSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	return _query
 }
 
 // EagerLoadUser eager-loads the edges of a User entity, if any edges
-// were requested to be eager-loaded, based off associated annotations.
-func EagerLoadUser(_query *ent.UserQuery) *ent.UserQuery {
-	return _query.WithPets(
-		func(e *ent.PetQuery) {
-			applySortingPet(e, "name", "asc")
-		},
-	)
+// were requested to be eager-loaded, based off associated annotations. If any fields
+// are provided (sparse fieldsets), only those fields and edges are queried.
+func EagerLoadUser(_query *ent.UserQuery, _fields ...string) *ent.UserQuery {
+	return eagerLoadUser(_query, nil, _fields)
+}
+
+// eagerLoadUser is similar to [EagerLoadUser], but also eager-loads any
+// includable edges which were provided.
+func eagerLoadUser(_query *ent.UserQuery, _includes, _fields []string) *ent.UserQuery {
+	if len(_fields) > 0 {
+		_query.Select(selectColumns(UserSelectConfig, _fields)...)
+	}
+	if _load := loadEdge("pets", true, _fields, _includes); _load != nil {
+		_query.WithPets(
+			func(e *ent.PetQuery) {
+				applySortingPet(e, "name", "asc")
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(PetSelectConfig, _load.Fields)...)
+				}
+			},
+		)
+	}
+	if _load := loadEdge("posts", false, _fields, _includes); _load != nil {
+		_query.WithPosts(
+			func(e *ent.PostQuery) {
+				applySortingPost(e, "id", "asc")
+				e.Limit(1000)
+				if len(_load.Fields) > 0 {
+					e.Select(selectColumns(PostSelectConfig, _load.Fields)...)
+				}
+				if _load.Included {
+					eagerLoadPost(e, _load.Includes, nil)
+				}
+			},
+		)
+	}
+	return _query
 }
@@ -4,11 +4,17 @@ package rest
 
 import (
 	"context"
+	stdsql "database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"slices"
+	"strings"
 	"time"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	uuid "github.com/google/uuid"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent"
@@ -21,10 +27,45 @@ import (
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/user"
 )
 
+// CountStrategy represents the strategy used to calculate the total number of results
+// for paged (non-cursor) list operations.
+type CountStrategy string
+
+const (
+	CountExact     CountStrategy = "exact"     // Runs a count query for every request.
+	CountEstimated CountStrategy = "estimated" // Uses a [CountEstimator] for unfiltered requests, falling back to an exact count.
+	CountNone      CountStrategy = "none"      // Skips the count query, counting the results seen so far (see [PagedResponse.IsEstimate]).
+)
+
+// CountEstimator returns an estimate of the total number of rows in the provided table,
+// which is used by unfiltered list operations using [CountEstimated]. If false is returned,
+// an exact count is used instead.
+type CountEstimator func(ctx context.Context, _table string) (int, bool, error)
+
+// NewPostgresCountEstimator returns a [CountEstimator] which uses the table statistics
+// of the provided Postgres database (pg_class.reltuples), which are updated by VACUUM,
+// ANALYZE, and autovacuum. If the estimate is below _min (or the table hasn't been
+// analyzed yet), an exact count is used instead, as estimates are less useful (and less
+// accurate) for small tables.
+func NewPostgresCountEstimator(_db *stdsql.DB, _min int) CountEstimator {
+	return func(ctx context.Context, _table string) (int, bool, error) {
+		var _estimate stdsql.NullInt64
+		err := _db.QueryRowContext(ctx, "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)", _table).Scan(&_estimate)
+		if errors.Is(err, stdsql.ErrNoRows) || (err == nil && (!_estimate.Valid || _estimate.Int64 < int64(max(_min, 0)))) {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return int(_estimate.Int64), true, nil
+	}
+}
+
 type PageConfig struct {
-	MinItemsPerPage int `json:"min_items_per_page"`
-	ItemsPerPage    int `json:"items_per_page"`
-	MaxItemsPerPage int `json:"max_items_per_page"`
+	MinItemsPerPage int           `json:"min_items_per_page"`
+	ItemsPerPage    int           `json:"items_per_page"`
+	MaxItemsPerPage int           `json:"max_items_per_page"`
+	CountStrategy   CountStrategy `json:"count_strategy"`
 }
 
 var (
@@ -36,6 +77,7 @@ var (
 		MinItemsPerPage: 1,
 		ItemsPerPage:    10,
 		MaxItemsPerPage: 100,
+		CountStrategy:   CountExact,
 	}
 	// CategoryPageConfig defines the page configuration for LIST-related endpoints
 	// for Category.
@@ -43,6 +85,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   DefaultPageConfig.CountStrategy,
 	}
 	// FollowPageConfig defines the page configuration for LIST-related endpoints
 	// for Follow.
@@ -50,6 +93,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   DefaultPageConfig.CountStrategy,
 	}
 	// FriendshipPageConfig defines the page configuration for LIST-related endpoints
 	// for Friendship.
@@ -57,6 +101,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   CountEstimated,
 	}
 	// PetPageConfig defines the page configuration for LIST-related endpoints
 	// for Pet.
@@ -64,6 +109,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   DefaultPageConfig.CountStrategy,
 	}
 	// PostPageConfig defines the page configuration for LIST-related endpoints
 	// for Post.
@@ -71,6 +117,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   DefaultPageConfig.CountStrategy,
 	}
 	// SettingPageConfig defines the page configuration for LIST-related endpoints
 	// for Setting.
@@ -78,6 +125,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   CountNone,
 	}
 	// UserPageConfig defines the page configuration for LIST-related endpoints
 	// for User.
@@ -85,6 +133,7 @@ var (
 		MinItemsPerPage: DefaultPageConfig.MinItemsPerPage,
 		ItemsPerPage:    DefaultPageConfig.ItemsPerPage,
 		MaxItemsPerPage: DefaultPageConfig.MaxItemsPerPage,
+		CountStrategy:   DefaultPageConfig.CountStrategy,
 	}
 )
 
@@ -98,11 +147,12 @@ type PagableQuery[P any, T any] interface {
 
 // PagedResponse is the JSON response structure for paged queries.
 type PagedResponse[T any] struct {
-	Page       int  `json:"page"`         // Current page number.
-	TotalCount int  `json:"total_count"`  // Total number of items.
-	LastPage   int  `json:"last_page"`    // Last page number.
-	IsLastPage bool `json:"is_last_page"` // Whether this is the last page.
-	Content    []*T `json:"content"`      // Paged data.
+	Page       int  `json:"page"`                  // Current page number.
+	TotalCount int  `json:"total_count"`           // Total number of items.
+	LastPage   int  `json:"last_page"`             // Last page number.
+	IsLastPage bool `json:"is_last_page"`          // Whether this is the last page.
+	IsEstimate bool `json:"is_estimate,omitempty"` // Whether TotalCount and LastPage are estimated (see [CountEstimated] and [CountNone]).
+	Content    []*T `json:"content"`               // Paged data.
 }
 
 // GetPage returns the current page number.
@@ -125,36 +175,177 @@ func (p *PagedResponse[T]) GetIsLastPage() bool {
 	return p.IsLastPage
 }
 
-type Paginated[P PagableQuery[P, T], T any] struct {
-	Page         *int `json:"page"     form:"page,omitempty"`
-	ItemsPerPage *int `json:"per_page" form:"per_page,omitempty"`
-	ResultCount  int  `json:"-"        form:"-"` // ResultCount is populated by the query execution inside of ApplyPagination.
-	LastPage     int  `json:"-"        form:"-"` // LastPage is populated by the query execution inside of ApplyPagination.
+// Len returns the number of results in the current page.
+func (p *PagedResponse[T]) Len() int {
+	return len(p.Content)
+}
 
-	hasApplied bool `json:"-" form:"-"`
+// CursorResponse is the JSON response structure for cursor-paginated queries.
+type CursorResponse[T any] struct {
+	NextCursor *string `json:"next_cursor"`  // Cursor for the next page, nil if this is the last page.
+	PrevCursor *string `json:"prev_cursor"`  // Cursor for the previous page, nil if this is the first page.
+	IsLastPage bool    `json:"is_last_page"` // Whether this is the last page.
+	Content    []*T    `json:"content"`      // Paged data.
 }
 
-// ApplyPagination applies offsets and limits, and also runs a count query on the
-// provided query to calculate total results and what the last page number is.
-func (p *Paginated[P, T]) ApplyPagination(ctx context.Context, _query P, pageConfig *PageConfig) (P, error) {
-	if pageConfig == nil {
-		pageConfig = DefaultPageConfig
+// GetNextCursor returns the cursor for the next page, or an empty string if this is
+// the last page.
+func (p *CursorResponse[T]) GetNextCursor() string {
+	if p.NextCursor == nil {
+		return ""
 	}
+	return *p.NextCursor
+}
 
-	if p.Page == nil {
-		p.Page = &firstPage
+// GetPrevCursor returns the cursor for the previous page, or an empty string if this
+// is the first page.
+func (p *CursorResponse[T]) GetPrevCursor() string {
+	if p.PrevCursor == nil {
+		return ""
+	}
+	return *p.PrevCursor
+}
+
+// GetIsLastPage returns whether this is the last page.
+func (p *CursorResponse[T]) GetIsLastPage() bool {
+	return p.IsLastPage
+}
+
+// Len returns the number of results in the current page.
+func (p *CursorResponse[T]) Len() int {
+	return len(p.Content)
+}
+
+// Cursor is the decoded form of the opaque cursor used for cursor-based (keyset)
+// pagination. It holds the position of a single result, by its sort field value and ID.
+type Cursor struct {
+	Field    string          `json:"f"`           // Field the results are sorted by.
+	Value    json.RawMessage `json:"v,omitempty"` // Value of the sort field for the result.
+	ID       json.RawMessage `json:"i"`           // ID of the result, used as a tie-breaker.
+	Backward bool            `json:"b,omitempty"` // Whether to paginate backwards from the result.
+}
+
+// Encode encodes the cursor into an opaque, URL-safe string.
+func (c *Cursor) Encode() (string, error) {
+	_buf, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(_buf), nil
+}
+
+// DecodeCursor decodes an opaque cursor string, as returned by [Cursor.Encode].
+func DecodeCursor(v string) (*Cursor, error) {
+	_buf, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, &ErrBadRequest{Err: errors.New("invalid cursor provided")}
+	}
+	_cursor := &Cursor{}
+	if err = json.Unmarshal(_buf, _cursor); err != nil || _cursor.Field == "" || len(_cursor.ID) == 0 {
+		return nil, &ErrBadRequest{Err: errors.New("invalid cursor provided")}
 	}
+	return _cursor, nil
+}
+
+// newCursor returns an encoded cursor for the provided sort field value and ID.
+func newCursor(_field string, _value, _id any, _backward bool) (*string, error) {
+	var err error
+	_cursor := &Cursor{Field: _field, Backward: _backward}
+
+	if _value != nil {
+		if _cursor.Value, err = json.Marshal(_value); err != nil {
+			return nil, err
+		}
+	}
+
+	if _cursor.ID, err = json.Marshal(_id); err != nil {
+		return nil, err
+	}
+
+	_encoded, err := _cursor.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return &_encoded, nil
+}
+
+// decodeCursorValue decodes a sort field value or ID from a cursor.
+func decodeCursorValue[V any](_raw json.RawMessage) (V, error) {
+	var v V
+	if err := json.Unmarshal(_raw, &v); err != nil {
+		return v, &ErrBadRequest{Err: errors.New("invalid cursor provided")}
+	}
+	return v, nil
+}
 
+// keysetPredicate returns a predicate which only matches rows positioned after the
+// provided sort field value and ID (or before, if _asc is false). If the sort field
+// is the ID field, _value is ignored.
+func keysetPredicate(_field, _idField string, _value, _id any, _asc bool) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		_cmp := sql.GT
+		if !_asc {
+			_cmp = sql.LT
+		}
+		if _field == _idField {
+			s.Where(_cmp(s.C(_idField), _id))
+			return
+		}
+		s.Where(sql.Or(
+			_cmp(s.C(_field), _value),
+			sql.And(sql.EQ(s.C(_field), _value), _cmp(s.C(_idField), _id)),
+		))
+	}
+}
+
+type Paginated[P PagableQuery[P, T], T any] struct {
+	Page         *int    `json:"page"     form:"page,omitempty"`
+	ItemsPerPage *int    `json:"per_page" form:"per_page,omitempty"`
+	Cursor       *string `json:"cursor"   form:"cursor,omitempty"`
+	ResultCount  int     `json:"-"        form:"-"` // ResultCount is populated by the query execution inside of ApplyPagination.
+	LastPage     int     `json:"-"        form:"-"` // LastPage is populated by the query execution inside of ApplyPagination.
+
+	hasApplied    bool                                     `json:"-" form:"-"`
+	countStrategy CountStrategy                            `json:"-" form:"-"`
+	isEstimate    bool                                     `json:"-" form:"-"`
+	estimate      func(context.Context) (int, bool, error) `json:"-" form:"-"` // Only provided for unfiltered queries.
+}
+
+// validateItemsPerPage applies the default number of items per page (if not provided),
+// and ensures it is within the bounds of the provided page configuration.
+func (p *Paginated[P, T]) validateItemsPerPage(pageConfig *PageConfig) error {
 	if p.ItemsPerPage == nil {
 		p.ItemsPerPage = &pageConfig.ItemsPerPage
 	}
 
 	if *p.ItemsPerPage < pageConfig.MinItemsPerPage {
-		return _query, &ErrBadRequest{Err: fmt.Errorf("per_page %d is out of bounds, must be >= %d", *p.ItemsPerPage, pageConfig.MinItemsPerPage)}
+		return &ErrBadRequest{Err: fmt.Errorf("per_page %d is out of bounds, must be >= %d", *p.ItemsPerPage, pageConfig.MinItemsPerPage)}
 	}
 
 	if *p.ItemsPerPage > pageConfig.MaxItemsPerPage {
-		return _query, &ErrBadRequest{Err: fmt.Errorf("per_page %d is out of bounds, must be <= %d", *p.ItemsPerPage, pageConfig.MaxItemsPerPage)}
+		return &ErrBadRequest{Err: fmt.Errorf("per_page %d is out of bounds, must be <= %d", *p.ItemsPerPage, pageConfig.MaxItemsPerPage)}
+	}
+	return nil
+}
+
+// ApplyPagination applies offsets and limits, and also runs a count query on the
+// provided query to calculate total results and what the last page number is (depending
+// on the count strategy of the provided page configuration).
+func (p *Paginated[P, T]) ApplyPagination(ctx context.Context, _query P, pageConfig *PageConfig) (P, error) {
+	if pageConfig == nil {
+		pageConfig = DefaultPageConfig
+	}
+
+	if p.Cursor != nil {
+		return _query, &ErrBadRequest{Err: errors.New("cursor is not supported on this endpoint, use page instead")}
+	}
+
+	if p.Page == nil {
+		p.Page = &firstPage
+	}
+
+	if err := p.validateItemsPerPage(pageConfig); err != nil {
+		return _query, err
 	}
 
 	if *p.Page < 1 {
@@ -163,24 +354,48 @@ func (p *Paginated[P, T]) ApplyPagination(ctx context.Context, _query P, pageCon
 
 	var err error
 
-	p.ResultCount, err = _query.Count(ctx)
-	if err != nil {
-		return _query, err
+	p.countStrategy = pageConfig.CountStrategy
+	_offset := (*p.Page - 1) * *p.ItemsPerPage
+
+	if p.countStrategy == CountNone {
+		// Fetch one extra result, to determine if this is the last page.
+		p.hasApplied = true
+		return _query.Limit(*p.ItemsPerPage + 1).Offset(_offset), nil
+	}
+
+	if p.countStrategy == CountEstimated && p.estimate != nil {
+		p.ResultCount, p.isEstimate, err = p.estimate(ctx)
+		if err != nil {
+			return _query, err
+		}
+	}
+
+	if !p.isEstimate {
+		p.ResultCount, err = _query.Count(ctx)
+		if err != nil {
+			return _query, err
+		}
 	}
 
-	// TODO: how to calculate this without knowing the total count?
 	p.LastPage = int(math.Ceil(float64(p.ResultCount) / float64(*p.ItemsPerPage)))
 
 	if p.LastPage < 1 {
 		p.LastPage = 1
 	}
 
+	p.hasApplied = true
+
+	if p.isEstimate {
+		// Estimates may be too low, so don't enforce the last page, and fetch one extra
+		// result to determine if this is the last page.
+		return _query.Limit(*p.ItemsPerPage + 1).Offset(_offset), nil
+	}
+
 	if *p.Page > p.LastPage {
 		return _query, &ErrBadRequest{Err: fmt.Errorf("page %d is out of bounds, last page is %d", *p.Page, p.LastPage)}
 	}
 
-	p.hasApplied = true
-	return _query.Limit(*p.ItemsPerPage).Offset((*p.Page - 1) * *p.ItemsPerPage), nil
+	return _query.Limit(*p.ItemsPerPage).Offset(_offset), nil
 }
 
 // ExecutePaginated executes the query and returns a paged response. If ApplyPagination
@@ -199,13 +414,123 @@ func (p *Paginated[P, T]) ExecutePaginated(ctx context.Context, _query P, pageCo
 		return nil, err
 	}
 
-	return &PagedResponse[T]{
-		Page:       *p.Page,
-		TotalCount: p.ResultCount,
-		LastPage:   p.LastPage,
-		IsLastPage: *p.Page == p.LastPage,
-		Content:    _data,
-	}, nil
+	_resp := &PagedResponse[T]{Page: *p.Page, Content: _data}
+
+	if p.countStrategy != CountNone && !p.isEstimate {
+		_resp.TotalCount = p.ResultCount
+		_resp.LastPage = p.LastPage
+		_resp.IsLastPage = *p.Page == p.LastPage
+		return _resp, nil
+	}
+
+	_hasMore := len(_data) > *p.ItemsPerPage
+	if _hasMore {
+		_resp.Content = _data[:*p.ItemsPerPage]
+	}
+	_resp.IsLastPage = !_hasMore
+
+	// Without an exact count (see [CountNone]), the count is based on the results seen so
+	// far, and is only exact once the last page is reached.
+	_seen := (*p.Page-1)**p.ItemsPerPage + len(_resp.Content)
+	switch {
+	case _hasMore:
+		// Ensure the estimate is consistent with the results seen so far.
+		p.ResultCount = max(p.ResultCount, _seen+1)
+		p.LastPage = max(p.LastPage, *p.Page+1)
+		_resp.IsEstimate = true
+	case len(_resp.Content) > 0 || *p.Page == 1:
+		// On the last page, the exact count is known.
+		p.ResultCount = _seen
+		p.LastPage = *p.Page
+	default:
+		// Past the last page, so the exact count is unknown.
+		p.LastPage = max(p.LastPage, 1)
+		_resp.IsEstimate = true
+	}
+	_resp.TotalCount = p.ResultCount
+	_resp.LastPage = p.LastPage
+	return _resp, nil
+}
+
+// ValidateCursor validates the cursor-based pagination parameters, returning the
+// decoded cursor (if one was provided). The cursor must have been created with the
+// same sort field as the one provided.
+func (p *Paginated[P, T]) ValidateCursor(pageConfig *PageConfig, _field string) (*Cursor, error) {
+	if pageConfig == nil {
+		pageConfig = DefaultPageConfig
+	}
+
+	if p.Page != nil {
+		return nil, &ErrBadRequest{Err: errors.New("page is not supported on this endpoint, use cursor instead")}
+	}
+
+	if err := p.validateItemsPerPage(pageConfig); err != nil {
+		return nil, err
+	}
+
+	if p.Cursor == nil || *p.Cursor == "" {
+		return nil, nil
+	}
+
+	_cursor, err := DecodeCursor(*p.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _cursor.Field != _field {
+		return nil, &ErrBadRequest{Err: fmt.Errorf("cursor was created with sort field %q, but sort field is %q", _cursor.Field, _field)}
+	}
+	return _cursor, nil
+}
+
+// ExecuteCursor executes the query and returns a cursor-paginated response. The query
+// must already have the keyset predicate (see [Paginated.ValidateCursor]) and ordering
+// applied, with the ordering reversed if the cursor is paginating backwards. _position
+// must return the sort field value and ID of the provided result.
+func (p *Paginated[P, T]) ExecuteCursor(
+	ctx context.Context,
+	_query P,
+	_field string,
+	_cursor *Cursor,
+	_position func(*T) (_value, _id any),
+) (*CursorResponse[T], error) {
+	// Fetch one extra result, to determine if there are more results in the
+	// direction we're paginating.
+	_data, err := _query.Limit(*p.ItemsPerPage + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_hasMore := len(_data) > *p.ItemsPerPage
+	if _hasMore {
+		_data = _data[:*p.ItemsPerPage]
+	}
+
+	_backward := _cursor != nil && _cursor.Backward
+	if _backward {
+		slices.Reverse(_data)
+	}
+
+	_resp := &CursorResponse[T]{Content: _data}
+
+	if len(_data) > 0 {
+		if (_backward && _hasMore) || (!_backward && _cursor != nil) {
+			_value, _id := _position(_data[0])
+			if _resp.PrevCursor, err = newCursor(_field, _value, _id, true); err != nil {
+				return nil, err
+			}
+		}
+
+		if _backward || _hasMore {
+			_value, _id := _position(_data[len(_data)-1])
+			if _resp.NextCursor, err = newCursor(_field, _value, _id, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_resp.IsLastPage = _resp.NextCursor == nil
+	return _resp, nil
 }
 
 // FilterOperation represents if all or any (one or more) filters should be applied.
@@ -239,12 +564,151 @@ func (f *Filtered[P]) ApplyFilterOperation(_predicates ...P) (P, error) {
 	return sql.OrPredicates(_predicates...), nil
 }
 
+// SearchConfig defines how the "q" parameter of list operations is matched against an
+// entity, using the full-text search capabilities of the dialect where possible. If the
+// dialect (or configuration) doesn't support full-text search, the search falls back to
+// matching any of the columns containing the search query (case insensitive).
+type SearchConfig struct {
+	// Columns are the columns which are searched.
+	Columns []string
+	// IDColumn is the ID column of the entity, used to match rows in Table.
+	IDColumn string
+	// PostgresConfig is the text search configuration used with Postgres (e.g. "english"
+	// or "simple"). Expression indexes must use the same configuration, e.g.:
+	//  to_tsvector('english'::regconfig, coalesce(col1, '') || ' ' || coalesce(col2, ''))
+	PostgresConfig string
+	// FullText enables MATCH ... AGAINST with MySQL, which requires a FULLTEXT index
+	// covering all of the columns.
+	FullText bool
+	// Table is the FTS5 virtual table used with SQLite, where the rowid matches the ID
+	// of the entity.
+	Table string
+}
+
+// Match returns a predicate which matches entities against the search query, or false
+// if the dialect (or configuration) doesn't support full-text search.
+func (c *SearchConfig) Match(s *sql.Selector, _query string) (*sql.Predicate, bool) {
+	switch {
+	case s.Dialect() == dialect.Postgres:
+		return sql.P(func(b *sql.Builder) {
+			c.tsvector(b, s)
+			b.WriteString(" @@ ")
+			c.tsquery(b, _query)
+		}), true
+	case s.Dialect() == dialect.MySQL && c.FullText:
+		return sql.P(func(b *sql.Builder) {
+			c.match(b, s, _query)
+		}), true
+	case s.Dialect() == dialect.SQLite && c.Table != "":
+		return sql.P(func(b *sql.Builder) {
+			b.Ident(s.C(c.IDColumn)).WriteString(" IN (SELECT rowid FROM ").Ident(c.Table)
+			b.WriteString(" WHERE ").Ident(c.Table).WriteString(" MATCH ").Arg(fts5Query(_query)).WriteByte(')')
+		}), true
+	}
+	return nil, false
+}
+
+// Rank sorts the selector by relevance to the search query (most relevant first when
+// using descending order), or returns false if the dialect (or configuration) doesn't
+// support full-text search.
+func (c *SearchConfig) Rank(s *sql.Selector, _query string, _order orderDirection) bool {
+	_desc := _order == orderDesc
+	switch {
+	case s.Dialect() == dialect.Postgres:
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("ts_rank(")
+			c.tsvector(b, s)
+			b.Comma()
+			c.tsquery(b, _query)
+			b.WriteByte(')')
+			if _desc {
+				b.WriteString(" DESC")
+			}
+		}))
+	case s.Dialect() == dialect.MySQL && c.FullText:
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			c.match(b, s, _query)
+			if _desc {
+				b.WriteString(" DESC")
+			}
+		}))
+	case s.Dialect() == dialect.SQLite && c.Table != "":
+		// FTS5 ranks are lower for better matches, so the order is reversed.
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("(SELECT rank FROM ").Ident(c.Table).WriteString(" WHERE ").Ident(c.Table)
+			b.WriteString(" MATCH ").Arg(fts5Query(_query)).WriteString(" AND rowid = ").Ident(s.C(c.IDColumn)).WriteByte(')')
+			if !_desc {
+				b.WriteString(" DESC")
+			}
+		}))
+	default:
+		return false
+	}
+	return true
+}
+
+// tsvector writes the Postgres text search document, which combines all columns.
+func (c *SearchConfig) tsvector(b *sql.Builder, s *sql.Selector) {
+	b.WriteString("to_tsvector(").WriteString(c.regconfig()).WriteString(", ")
+	for i, _column := range c.Columns {
+		if i > 0 {
+			b.WriteString(" || ' ' || ")
+		}
+		b.WriteString("coalesce(").Ident(s.C(_column)).WriteString(", '')")
+	}
+	b.WriteByte(')')
+}
+
+// tsquery writes the Postgres text search query, which supports the same syntax as
+// typical web search engines (e.g. quoted phrases, "or", and "-" for negation).
+func (c *SearchConfig) tsquery(b *sql.Builder, _query string) {
+	b.WriteString("websearch_to_tsquery(").WriteString(c.regconfig()).WriteString(", ").Arg(_query).WriteByte(')')
+}
+
+// regconfig returns the Postgres text search configuration as a literal, so expression
+// indexes can be used.
+func (c *SearchConfig) regconfig() string {
+	_config := c.PostgresConfig
+	if _config == "" {
+		_config = "english"
+	}
+	return "'" + strings.ReplaceAll(_config, "'", "''") + "'::regconfig"
+}
+
+// match writes the MySQL MATCH ... AGAINST expression.
+func (c *SearchConfig) match(b *sql.Builder, s *sql.Selector, _query string) {
+	b.WriteString("MATCH(")
+	for i, _column := range c.Columns {
+		if i > 0 {
+			b.Comma()
+		}
+		b.Ident(s.C(_column))
+	}
+	b.WriteString(") AGAINST(").Arg(_query).WriteString(" IN NATURAL LANGUAGE MODE)")
+}
+
+// fts5Query quotes each term of the search query, so FTS5 query syntax (which would
+// otherwise result in errors for malformed queries) is matched literally.
+func fts5Query(_query string) string {
+	_terms := strings.Fields(_query)
+	for i := range _terms {
+		_terms[i] = `"` + strings.ReplaceAll(_terms[i], `"`, `""`) + `"`
+	}
+	return strings.Join(_terms, " ")
+}
+
 // ListCategoryParams defines parameters for listing Categories via a GET request.
 type ListCategoryParams struct {
 	Sorted
+	Selected
 	Paginated[*ent.CategoryQuery, ent.Category]
 	Filtered[predicate.Category]
 
+	// IncludeDeleted includes soft-deleted entities in the results.
+	IncludeDeleted *bool `json:"include_deleted,omitempty" form:"include_deleted,omitempty"`
+	// OnlyDeleted only includes soft-deleted entities in the results.
+	OnlyDeleted *bool `json:"only_deleted,omitempty" form:"only_deleted,omitempty"`
+
 	// Filters field "id" to be equal to the provided value.
 	CategoryIDEQ *int `form:"id.eq,omitempty" json:"category_ideq,omitempty"`
 	// Filters field "id" to be not equal to the provided value.
@@ -265,8 +729,17 @@ type ListCategoryParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in Category.
 func (l *ListCategoryParams) FilterPredicates() (predicate.Category, error) {
-	var _predicates []predicate.Category
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
 
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListCategoryParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
+
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListCategoryParams) filterPredicates() (_predicates []predicate.Category) {
 	if l.CategoryIDEQ != nil {
 		_predicates = append(_predicates, category.IDEQ(*l.CategoryIDEQ))
 	}
@@ -292,7 +765,21 @@ func (l *ListCategoryParams) FilterPredicates() (predicate.Category, error) {
 		_predicates = append(_predicates, category.UpdatedAtLT(*l.CategoryUpdatedAtLT))
 	}
 
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
+}
+
+// DeletedPredicate returns the predicate used to exclude (or only include) soft-deleted
+// entities, based on the provided parameters. If soft-deleted entities should be included,
+// nil is returned.
+func (l *ListCategoryParams) DeletedPredicate() predicate.Category {
+	switch {
+	case l.OnlyDeleted != nil && *l.OnlyDeleted:
+		return category.DeletedAtNotNil()
+	case l.IncludeDeleted != nil && *l.IncludeDeleted:
+		return nil
+	default:
+		return category.DeletedAtIsNil()
+	}
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -315,13 +802,43 @@ func (l *ListCategoryParams) Exec(ctx context.Context, _query *ent.CategoryQuery
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadCategory(_query))
+	if l.HasFilters() {
+		l.estimate = nil // Estimates are only used for unfiltered queries.
+	}
+	if _deleted := l.DeletedPredicate(); _deleted != nil {
+		_query.Where(_deleted)
+	}
+	if l.OnlyDeleted != nil && *l.OnlyDeleted {
+		l.estimate = nil
+	}
+	if err = l.Selected.Validate(CategorySelectConfig); err != nil {
+		return nil, err
+	}
+	// Pagination must be applied before selecting fields, as the count query
+	// doesn't support multiple selected columns.
+	_query, err = l.ApplyPagination(ctx, _query, CategoryPageConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = l.ApplySorting(eagerLoadCategory(_query, nil, l.Selected.Fields))
 	if err != nil {
 		return nil, err
 	}
 	return l.ExecutePaginated(ctx, _query, CategoryPageConfig)
 }
 
+// cursorValueCategory returns the value of the provided sort field on the Category,
+// for use in cursors (and keyset iteration of exports).
+func cursorValueCategory(e *ent.Category, _field string) any {
+	switch _field {
+	case category.FieldCreatedAt:
+		return e.CreatedAt
+	case category.FieldUpdatedAt:
+		return e.UpdatedAt
+	}
+	return nil
+}
+
 // ListFollowParams defines parameters for listing Follows via a GET request.
 type ListFollowParams struct {
 	Sorted
@@ -343,7 +860,7 @@ func (l *ListFollowParams) ApplySorting(_query *ent.FollowsQuery) error {
 // Exec wraps all logic (filtering, sorting, pagination, eager loading) and
 // executes all necessary queries, returning the results.
 func (l *ListFollowParams) Exec(ctx context.Context, _query *ent.FollowsQuery) (_results *PagedResponse[ent.Follows], err error) {
-	err = l.ApplySorting(EagerLoadFollow(_query))
+	err = l.ApplySorting(eagerLoadFollow(_query, nil, nil))
 	if err != nil {
 		return nil, err
 	}
@@ -353,6 +870,7 @@ func (l *ListFollowParams) Exec(ctx context.Context, _query *ent.FollowsQuery) (
 // ListFriendshipParams defines parameters for listing Friendships via a GET request.
 type ListFriendshipParams struct {
 	Sorted
+	Selected
 	Paginated[*ent.FriendshipQuery, ent.Friendship]
 	Filtered[predicate.Friendship]
 
@@ -524,8 +1042,17 @@ type ListFriendshipParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in Friendship.
 func (l *ListFriendshipParams) FilterPredicates() (predicate.Friendship, error) {
-	var _predicates []predicate.Friendship
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
+
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListFriendshipParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
 
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListFriendshipParams) filterPredicates() (_predicates []predicate.Friendship) {
 	if l.FriendshipIDEQ != nil {
 		_predicates = append(_predicates, friendship.IDEQ(*l.FriendshipIDEQ))
 	}
@@ -805,7 +1332,7 @@ func (l *ListFriendshipParams) FilterPredicates() (predicate.Friendship, error)
 		}
 	}
 
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -828,19 +1355,49 @@ func (l *ListFriendshipParams) Exec(ctx context.Context, _query *ent.FriendshipQ
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadFriendship(_query))
+	if l.HasFilters() {
+		l.estimate = nil // Estimates are only used for unfiltered queries.
+	}
+	if err = l.Selected.Validate(FriendshipSelectConfig); err != nil {
+		return nil, err
+	}
+	// Pagination must be applied before selecting fields, as the count query
+	// doesn't support multiple selected columns.
+	_query, err = l.ApplyPagination(ctx, _query, FriendshipPageConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = l.ApplySorting(eagerLoadFriendship(_query, nil, l.Selected.Fields))
 	if err != nil {
 		return nil, err
 	}
 	return l.ExecutePaginated(ctx, _query, FriendshipPageConfig)
 }
 
+// cursorValueFriendship returns the value of the provided sort field on the Friendship,
+// for use in cursors (and keyset iteration of exports).
+func cursorValueFriendship(e *ent.Friendship, _field string) any {
+	switch _field {
+	case friendship.FieldUserID:
+		return e.UserID
+	case friendship.FieldFriendID:
+		return e.FriendID
+	}
+	return nil
+}
+
 // ListPetParams defines parameters for listing Pets via a GET request.
 type ListPetParams struct {
 	Sorted
+	Selected
+	Included
 	Paginated[*ent.PetQuery, ent.Pet]
 	Filtered[predicate.Pet]
 
+	// Search is the search query, which is matched across multiple fields (see
+	// PetSearchConfig).
+	Search *string `json:"q,omitempty" form:"q,omitempty"`
+
 	// Filters field "id" to be equal to the provided value.
 	PetIDEQ *int `form:"id.eq,omitempty" json:"pet_ideq,omitempty"`
 	// Filters field "id" to be not equal to the provided value.
@@ -1119,8 +1676,17 @@ type ListPetParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in Pet.
 func (l *ListPetParams) FilterPredicates() (predicate.Pet, error) {
-	var _predicates []predicate.Pet
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
+
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListPetParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
 
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListPetParams) filterPredicates() (_predicates []predicate.Pet) {
 	if l.PetIDEQ != nil {
 		_predicates = append(_predicates, pet.IDEQ(*l.PetIDEQ))
 	}
@@ -1585,7 +2151,34 @@ func (l *ListPetParams) FilterPredicates() (predicate.Pet, error) {
 		}
 	}
 
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
+}
+
+// PetSearchConfig defines how the "q" parameter is matched against Pets.
+var PetSearchConfig = &SearchConfig{
+	Columns: []string{
+		pet.FieldName,
+	},
+	IDColumn:       pet.FieldID,
+	PostgresConfig: "english",
+}
+
+// SearchPredicate returns the predicate for the search query, or nil if no search
+// query was provided.
+func (l *ListPetParams) SearchPredicate() predicate.Pet {
+	if l.Search == nil || strings.TrimSpace(*l.Search) == "" {
+		return nil
+	}
+	_fallback := sql.OrPredicates(
+		pet.NameContainsFold(*l.Search),
+	)
+	return func(s *sql.Selector) {
+		if _match, ok := PetSearchConfig.Match(s, *l.Search); ok {
+			s.Where(_match)
+			return
+		}
+		_fallback(s)
+	}
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -1596,6 +2189,18 @@ func (l *ListPetParams) ApplySorting(_query *ent.PetQuery) error {
 	if l.Field == nil { // No custom sort field provided and no defaults, so don't do anything.
 		return nil
 	}
+	if *l.Field == "relevance" {
+		if l.SearchPredicate() == nil {
+			return &ErrBadRequest{Err: fmt.Errorf("sort field %q requires a search query", *l.Field)}
+		}
+		_query.Order(func(s *sql.Selector) {
+			if !PetSearchConfig.Rank(s, *l.Search, *l.Order) {
+				// Full-text search isn't supported, so there is no relevance to sort by.
+				withFieldSelector(pet.FieldID, *l.Order)(s)
+			}
+		})
+		return nil
+	}
 	applySortingPet(_query, *l.Field, *l.Order)
 	return nil
 }
@@ -1608,19 +2213,55 @@ func (l *ListPetParams) Exec(ctx context.Context, _query *ent.PetQuery) (_result
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadPet(_query))
+	if l.HasFilters() {
+		l.estimate = nil // Estimates are only used for unfiltered queries.
+	}
+	if _search := l.SearchPredicate(); _search != nil {
+		_query.Where(_search)
+		l.estimate = nil
+	}
+	if err = l.Selected.Validate(PetSelectConfig); err != nil {
+		return nil, err
+	}
+	if err = l.Included.Validate(PetIncludeConfig); err != nil {
+		return nil, err
+	}
+	// Pagination must be applied before selecting fields, as the count query
+	// doesn't support multiple selected columns.
+	_query, err = l.ApplyPagination(ctx, _query, PetPageConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = l.ApplySorting(eagerLoadPet(_query, l.Included.Include, l.Selected.Fields))
 	if err != nil {
 		return nil, err
 	}
 	return l.ExecutePaginated(ctx, _query, PetPageConfig)
 }
 
+// cursorValuePet returns the value of the provided sort field on the Pet,
+// for use in cursors (and keyset iteration of exports).
+func cursorValuePet(e *ent.Pet, _field string) any {
+	switch _field {
+	case pet.FieldName:
+		return e.Name
+	case pet.FieldAge:
+		return e.Age
+	}
+	return nil
+}
+
 // ListPostParams defines parameters for listing Posts via a GET request.
 type ListPostParams struct {
 	Sorted
+	Selected
 	Paginated[*ent.PostQuery, ent.Post]
 	Filtered[predicate.Post]
 
+	// Search is the search query, which is matched across multiple fields (see
+	// PostSearchConfig).
+	Search *string `json:"q,omitempty" form:"q,omitempty"`
+
 	// Filters field "id" to be equal to the provided value.
 	PostIDEQ *int `form:"id.eq,omitempty" json:"post_ideq,omitempty"`
 	// Filters field "id" to be not equal to the provided value.
@@ -1719,8 +2360,17 @@ type ListPostParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in Post.
 func (l *ListPostParams) FilterPredicates() (predicate.Post, error) {
-	var _predicates []predicate.Post
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
+
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListPostParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
 
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListPostParams) filterPredicates() (_predicates []predicate.Post) {
 	if l.PostIDEQ != nil {
 		_predicates = append(_predicates, post.IDEQ(*l.PostIDEQ))
 	}
@@ -1879,7 +2529,39 @@ func (l *ListPostParams) FilterPredicates() (predicate.Post, error) {
 		}
 	}
 
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
+}
+
+// PostSearchConfig defines how the "q" parameter is matched against Posts.
+var PostSearchConfig = &SearchConfig{
+	Columns: []string{
+		post.FieldTitle,
+		post.FieldSlug,
+		post.FieldBody,
+	},
+	IDColumn:       post.FieldID,
+	PostgresConfig: "english",
+	Table:          "post_search",
+}
+
+// SearchPredicate returns the predicate for the search query, or nil if no search
+// query was provided.
+func (l *ListPostParams) SearchPredicate() predicate.Post {
+	if l.Search == nil || strings.TrimSpace(*l.Search) == "" {
+		return nil
+	}
+	_fallback := sql.OrPredicates(
+		post.TitleContainsFold(*l.Search),
+		post.SlugContainsFold(*l.Search),
+		post.BodyContainsFold(*l.Search),
+	)
+	return func(s *sql.Selector) {
+		if _match, ok := PostSearchConfig.Match(s, *l.Search); ok {
+			s.Where(_match)
+			return
+		}
+		_fallback(s)
+	}
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -1890,28 +2572,122 @@ func (l *ListPostParams) ApplySorting(_query *ent.PostQuery) error {
 	if l.Field == nil { // No custom sort field provided and no defaults, so don't do anything.
 		return nil
 	}
+	if *l.Field == "relevance" {
+		if l.SearchPredicate() == nil {
+			return &ErrBadRequest{Err: fmt.Errorf("sort field %q requires a search query", *l.Field)}
+		}
+		_query.Order(func(s *sql.Selector) {
+			if !PostSearchConfig.Rank(s, *l.Search, *l.Order) {
+				// Full-text search isn't supported, so there is no relevance to sort by.
+				withFieldSelector(post.FieldID, *l.Order)(s)
+			}
+		})
+		return nil
+	}
 	applySortingPost(_query, *l.Field, *l.Order)
 	return nil
 }
 
-// Exec wraps all logic (filtering, sorting, pagination, eager loading) and
-// executes all necessary queries, returning the results.
-func (l *ListPostParams) Exec(ctx context.Context, _query *ent.PostQuery) (_results *PagedResponse[ent.Post], err error) {
+// Exec wraps all logic (filtering, sorting, cursor-based pagination, eager
+// loading) and executes all necessary queries, returning the results.
+func (l *ListPostParams) Exec(ctx context.Context, _query *ent.PostQuery) (_results *CursorResponse[ent.Post], err error) {
 	_predicates, err := l.FilterPredicates()
 	if err != nil {
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadPost(_query))
+	if _search := l.SearchPredicate(); _search != nil {
+		_query.Where(_search)
+	}
+	if err = l.Selected.Validate(PostSelectConfig); err != nil {
+		return nil, err
+	}
+	if err = l.Sorted.Validate(PostSortConfig); err != nil {
+		return nil, err
+	}
+	_cursor, err := l.ValidateCursor(PostPageConfig, *l.Field)
 	if err != nil {
 		return nil, err
 	}
-	return l.ExecutePaginated(ctx, _query, PostPageConfig)
+	_keyset, err := keysetPost(*l.Field, *l.Order, _cursor)
+	if err != nil {
+		return nil, err
+	}
+	_order := *l.Order
+	if _keyset != nil {
+		_query.Where(_keyset)
+		if _cursor.Backward {
+			_order = reverseOrder(_order)
+		}
+	}
+	_query.Order(withFieldSelector(*l.Field, _order))
+	if *l.Field != post.FieldID {
+		_query.Order(withFieldSelector(post.FieldID, _order))
+	}
+	_fields := l.Selected.Fields
+	if len(_fields) > 0 {
+		// The sort field is required to build cursors, even if it wasn't selected.
+		_fields = append(slices.Clip(_fields), *l.Field)
+	}
+	return l.ExecuteCursor(
+		ctx,
+		eagerLoadPost(_query, nil, _fields),
+		*l.Field,
+		_cursor,
+		func(e *ent.Post) (any, any) {
+			return cursorValuePost(e, *l.Field), e.ID
+		},
+	)
+}
+
+// keysetPost ensures the sort field can be used for cursor-based pagination,
+// and returns a predicate which only matches results after the provided cursor. If
+// no cursor is provided, the returned predicate is nil.
+func keysetPost(_field string, _order orderDirection, _cursor *Cursor) (_predicate predicate.Post, err error) {
+	var _value any
+
+	switch _field {
+	case post.FieldID:
+	case post.FieldCreatedAt:
+		if _cursor != nil {
+			_value, err = decodeCursorValue[time.Time](_cursor.Value)
+		}
+	case post.FieldUpdatedAt:
+		if _cursor != nil {
+			_value, err = decodeCursorValue[time.Time](_cursor.Value)
+		}
+	default:
+		return nil, &ErrBadRequest{Err: fmt.Errorf("sort field %q cannot be used with cursor-based pagination", _field)}
+	}
+
+	if _cursor == nil || err != nil {
+		return nil, err
+	}
+
+	_id, err := decodeCursorValue[int](_cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return keysetPredicate(_field, post.FieldID, _value, _id, (_order == orderAsc) != _cursor.Backward), nil
+}
+
+// cursorValuePost returns the value of the provided sort field on the Post,
+// for use in cursors (and keyset iteration of exports).
+func cursorValuePost(e *ent.Post, _field string) any {
+	switch _field {
+	case post.FieldCreatedAt:
+		return e.CreatedAt
+	case post.FieldUpdatedAt:
+		return e.UpdatedAt
+	}
+	return nil
 }
 
 // ListSettingParams defines parameters for listing Settings via a GET request.
 type ListSettingParams struct {
 	Sorted
+	Selected
 	Paginated[*ent.SettingsQuery, ent.Settings]
 	Filtered[predicate.Settings]
 
@@ -1935,8 +2711,17 @@ type ListSettingParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in Setting.
 func (l *ListSettingParams) FilterPredicates() (predicate.Settings, error) {
-	var _predicates []predicate.Settings
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
+
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListSettingParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
 
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListSettingParams) filterPredicates() (_predicates []predicate.Settings) {
 	if l.SettingsIDEQ != nil {
 		_predicates = append(_predicates, settings.IDEQ(*l.SettingsIDEQ))
 	}
@@ -1962,7 +2747,7 @@ func (l *ListSettingParams) FilterPredicates() (predicate.Settings, error) {
 		_predicates = append(_predicates, settings.UpdatedAtLT(*l.SettingsUpdatedAtLT))
 	}
 
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -1985,16 +2770,42 @@ func (l *ListSettingParams) Exec(ctx context.Context, _query *ent.SettingsQuery)
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadSetting(_query))
+	if l.HasFilters() {
+		l.estimate = nil // Estimates are only used for unfiltered queries.
+	}
+	if err = l.Selected.Validate(SettingSelectConfig); err != nil {
+		return nil, err
+	}
+	// Pagination must be applied before selecting fields, as the count query
+	// doesn't support multiple selected columns.
+	_query, err = l.ApplyPagination(ctx, _query, SettingPageConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = l.ApplySorting(eagerLoadSetting(_query, nil, l.Selected.Fields))
 	if err != nil {
 		return nil, err
 	}
 	return l.ExecutePaginated(ctx, _query, SettingPageConfig)
 }
 
+// cursorValueSetting returns the value of the provided sort field on the Setting,
+// for use in cursors (and keyset iteration of exports).
+func cursorValueSetting(e *ent.Settings, _field string) any {
+	switch _field {
+	case settings.FieldCreatedAt:
+		return e.CreatedAt
+	case settings.FieldUpdatedAt:
+		return e.UpdatedAt
+	}
+	return nil
+}
+
 // ListUserParams defines parameters for listing Users via a GET request.
 type ListUserParams struct {
 	Sorted
+	Selected
+	Included
 	Paginated[*ent.UserQuery, ent.User]
 	Filtered[predicate.User]
 
@@ -2303,8 +3114,17 @@ type ListUserParams struct {
 
 // FilterPredicates returns the predicates for filter-related parameters in User.
 func (l *ListUserParams) FilterPredicates() (predicate.User, error) {
-	var _predicates []predicate.User
+	return l.ApplyFilterOperation(l.filterPredicates()...)
+}
+
+// HasFilters returns true if any filter-related parameters were provided.
+func (l *ListUserParams) HasFilters() bool {
+	return len(l.filterPredicates()) > 0
+}
 
+// filterPredicates returns the individual predicates for all provided filter-related
+// parameters.
+func (l *ListUserParams) filterPredicates() (_predicates []predicate.User) {
 	if l.UserIDEQ != nil {
 		_predicates = append(_predicates, user.IDEQ(*l.UserIDEQ))
 	}
@@ -2844,7 +3664,7 @@ func (l *ListUserParams) FilterPredicates() (predicate.User, error) {
 			user.EmailHasSuffix(*l.UserFilterGroupSearchHasSuffix),
 		))
 	}
-	return l.ApplyFilterOperation(_predicates...)
+	return _predicates
 }
 
 // ApplySorting applies sorting to the query based on the provided sort and order fields.
@@ -2867,9 +3687,38 @@ func (l *ListUserParams) Exec(ctx context.Context, _query *ent.UserQuery) (_resu
 		return nil, err
 	}
 	_query.Where(_predicates)
-	err = l.ApplySorting(EagerLoadUser(_query))
+	if l.HasFilters() {
+		l.estimate = nil // Estimates are only used for unfiltered queries.
+	}
+	if err = l.Selected.Validate(UserSelectConfig); err != nil {
+		return nil, err
+	}
+	if err = l.Included.Validate(UserIncludeConfig); err != nil {
+		return nil, err
+	}
+	// Pagination must be applied before selecting fields, as the count query
+	// doesn't support multiple selected columns.
+	_query, err = l.ApplyPagination(ctx, _query, UserPageConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = l.ApplySorting(eagerLoadUser(_query, l.Included.Include, l.Selected.Fields))
 	if err != nil {
 		return nil, err
 	}
 	return l.ExecutePaginated(ctx, _query, UserPageConfig)
 }
+
+// cursorValueUser returns the value of the provided sort field on the User,
+// for use in cursors (and keyset iteration of exports).
+func cursorValueUser(e *ent.User, _field string) any {
+	switch _field {
+	case user.FieldCreatedAt:
+		return e.CreatedAt
+	case user.FieldUpdatedAt:
+		return e.UpdatedAt
+	case user.FieldName:
+		return e.Name
+	}
+	return nil
+}
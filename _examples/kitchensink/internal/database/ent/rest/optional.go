@@ -28,6 +28,12 @@ func (o Option[T]) Get() (T, bool) {
 	return o.value, true
 }
 
+// Set sets the value, and marks it as present.
+func (o *Option[T]) Set(_value T) {
+	o.present = true
+	o.value = _value
+}
+
 // OrElse returns value if present, or the provided default value.
 func (o Option[T]) OrElse(_fallback T) T {
 	if !o.present {
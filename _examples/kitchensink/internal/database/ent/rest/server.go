@@ -4,18 +4,26 @@ package rest
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
 	_ "embed"
 	"encoding"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"html/template"
 	"io"
+	"maps"
+	"mime"
 	"net/http"
 	"net/url"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-playground/form/v4"
@@ -23,6 +31,7 @@ import (
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/category"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/friendship"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/idempotencykey"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/pet"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/post"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/privacy"
@@ -47,6 +56,30 @@ const (
 	OperationDelete Operation = "delete"
 	// OperationList represents the list operation (method: GET).
 	OperationList Operation = "list"
+	// OperationReplace represents the replace operation (method: PUT).
+	OperationReplace Operation = "replace"
+	// OperationCreateBulk represents the bulk create operation (method: POST).
+	OperationCreateBulk Operation = "create-bulk"
+	// OperationUpdateBulk represents the bulk update operation (method: PATCH).
+	OperationUpdateBulk Operation = "update-bulk"
+	// OperationDeleteBulk represents the bulk delete operation (method: DELETE).
+	OperationDeleteBulk Operation = "delete-bulk"
+	// OperationRestore represents the restore operation (method: POST).
+	OperationRestore Operation = "restore"
+	// OperationUpsert represents the upsert operation (method: PUT).
+	OperationUpsert Operation = "upsert"
+	// OperationAggregate represents the aggregate operation (method: GET).
+	OperationAggregate Operation = "aggregate"
+	// OperationImport represents the import operation (method: POST).
+	OperationImport Operation = "import"
+	// OperationEvents represents the events operation (method: GET).
+	OperationEvents Operation = "events"
+	// OperationHistory represents the history operation (method: GET).
+	OperationHistory Operation = "history"
+	// OperationHistoryRead represents the history read operation (method: GET).
+	OperationHistoryRead Operation = "history-read"
+	// OperationHistoryRestore represents the history restore operation (method: POST).
+	OperationHistoryRestore Operation = "history-restore"
 )
 
 // ErrorResponse is the response structure for errors.
@@ -109,6 +142,121 @@ func IsInvalidID(err error) bool {
 	return errors.As(err, &_target)
 }
 
+// ErrPreconditionFailed is returned when a conditional request (e.g. using the "If-Match"
+// header) doesn't match the current version of the entity.
+type ErrPreconditionFailed struct {
+	Err error
+}
+
+func (e ErrPreconditionFailed) Error() string {
+	return fmt.Sprintf("precondition failed: %s", e.Err)
+}
+
+func (e ErrPreconditionFailed) Unwrap() error {
+	return e.Err
+}
+
+// IsPreconditionFailed returns true if the unwrapped/underlying error is of type ErrPreconditionFailed.
+func IsPreconditionFailed(err error) bool {
+	var _target *ErrPreconditionFailed
+	return errors.As(err, &_target)
+}
+
+// ErrBulkItem is returned when a specific item of a bulk request is invalid, or fails
+// to be created. Index is the index of the item in the request.
+type ErrBulkItem struct {
+	Index int
+	Err   error
+}
+
+func (e ErrBulkItem) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e ErrBulkItem) Unwrap() error {
+	return e.Err
+}
+
+// IsBulkItem returns true if the unwrapped/underlying error is of type ErrBulkItem.
+func IsBulkItem(err error) bool {
+	var _target *ErrBulkItem
+	return errors.As(err, &_target)
+}
+
+// ErrUnauthorized is returned when a request isn't authenticated (see
+// [ServerConfig.Authorizer]).
+var ErrUnauthorized = errors.New("unauthorized")
+
+// IsUnauthorized returns true if the unwrapped/underlying error is of type ErrUnauthorized.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// ErrForbidden is returned when a request isn't allowed to perform an operation (see
+// [ServerConfig.Authorizer]).
+type ErrForbidden struct {
+	Err error
+}
+
+func (e ErrForbidden) Error() string {
+	return fmt.Sprintf("forbidden: %s", e.Err)
+}
+
+func (e ErrForbidden) Unwrap() error {
+	return e.Err
+}
+
+// IsForbidden returns true if the unwrapped/underlying error is of type ErrForbidden.
+func IsForbidden(err error) bool {
+	var _target *ErrForbidden
+	return errors.As(err, &_target)
+}
+
+// withAuthorizer wraps the handler of the provided operation, invoking the authorizer
+// (see [ServerConfig.Authorizer]) before the handler.
+func (s *Server) withAuthorizer(_op Operation, _entity string, _scopes []string, _next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.Authorizer != nil {
+			if err := s.config.Authorizer(r, _op, _entity, _scopes); err != nil {
+				if !IsUnauthorized(err) && !IsForbidden(err) {
+					err = &ErrForbidden{Err: err}
+				}
+				handleResponse[struct{}](s, w, r, _op, nil, err)
+				return
+			}
+		}
+		_next(w, r)
+	}
+}
+
+// nestedCreateScopes holds the scopes required to create each entity type which can be
+// created through nested edges (see entrest.WithScopes).
+var nestedCreateScopes = map[string][]string{
+	"Pet": []string{"pets:write"},
+}
+
+// authorizeNestedCreates invokes the authorizer (see [ServerConfig.Authorizer]) with
+// [OperationCreate] once for each entity type which the provided walk function reports
+// as created through nested edges, as the authorizer of the operation itself only
+// covers the top-level entity.
+func (s *Server) authorizeNestedCreates(r *http.Request, _walk func(func(_entity string) error) error) error {
+	if s.config.Authorizer == nil {
+		return nil
+	}
+	_seen := make(map[string]bool)
+	return _walk(func(_entity string) error {
+		if _seen[_entity] {
+			return nil
+		}
+		_seen[_entity] = true
+		err := s.config.Authorizer(r, OperationCreate, _entity, nestedCreateScopes[_entity])
+		if err != nil && !IsUnauthorized(err) && !IsForbidden(err) {
+			err = &ErrForbidden{Err: err}
+		}
+		return err
+	})
+}
+
 // JSON marshals 'v' to JSON, and setting the Content-Type as application/json.
 // Note that this does NOT auto-escape HTML. If 'v' cannot be marshalled to JSON,
 // this will panic.
@@ -132,6 +280,152 @@ func JSON(w http.ResponseWriter, r *http.Request, _status int, v any) {
 // M is an alias for map[string]any, which makes it easier to respond with generic JSON data structures.
 type M map[string]any
 
+// Encoder encodes response bodies of a specific media type (see [ServerConfig.Encoders]).
+type Encoder interface {
+	Encode(w io.Writer, v any) error
+}
+
+// EncoderFunc is an adapter to allow the use of ordinary functions as an [Encoder].
+type EncoderFunc func(w io.Writer, v any) error
+
+// Encode calls f(w, v).
+func (f EncoderFunc) Encode(w io.Writer, v any) error {
+	return f(w, v)
+}
+
+// Decoder decodes request bodies of a specific media type (see [ServerConfig.Decoders]).
+type Decoder interface {
+	Decode(r io.Reader, v any) error
+}
+
+// DecoderFunc is an adapter to allow the use of ordinary functions as a [Decoder].
+type DecoderFunc func(r io.Reader, v any) error
+
+// Decode calls f(r, v).
+func (f DecoderFunc) Decode(r io.Reader, v any) error {
+	return f(r, v)
+}
+
+// ErrNotAcceptable is returned when none of the media types accepted by the client
+// (through the "Accept" header) are supported.
+type ErrNotAcceptable struct {
+	Accept string
+}
+
+func (e ErrNotAcceptable) Error() string {
+	return fmt.Sprintf("none of the accepted media types are supported: %s", e.Accept)
+}
+
+// IsNotAcceptable returns true if the unwrapped/underlying error is of type ErrNotAcceptable.
+func IsNotAcceptable(err error) bool {
+	var _target *ErrNotAcceptable
+	return errors.As(err, &_target)
+}
+
+// ErrUnsupportedMediaType is returned when the "Content-Type" of the request body isn't
+// supported.
+type ErrUnsupportedMediaType struct {
+	MediaType string
+}
+
+func (e ErrUnsupportedMediaType) Error() string {
+	return fmt.Sprintf("unsupported content type %s", e.MediaType)
+}
+
+// IsUnsupportedMediaType returns true if the unwrapped/underlying error is of type ErrUnsupportedMediaType.
+func IsUnsupportedMediaType(err error) bool {
+	var _target *ErrUnsupportedMediaType
+	return errors.As(err, &_target)
+}
+
+// negotiateEncoder returns the media type and encoder of the response, based on the
+// "Accept" header of the request, and the registered encoders. A nil encoder is returned
+// if JSON should be used.
+func (s *Server) negotiateEncoder(r *http.Request) (string, Encoder, error) {
+	_accept := r.Header.Get("Accept")
+	if _accept == "" {
+		return "application/json", nil, nil
+	}
+
+	type _candidate struct {
+		media string
+		q     float64
+	}
+
+	var _candidates []_candidate
+	for _, _value := range strings.Split(_accept, ",") {
+		_media, _params, err := mime.ParseMediaType(strings.TrimSpace(_value))
+		if err != nil {
+			continue
+		}
+		_q := 1.0
+		if _raw, ok := _params["q"]; ok {
+			if _q, err = strconv.ParseFloat(_raw, 64); err != nil {
+				continue
+			}
+		}
+		if _q > 0 {
+			_candidates = append(_candidates, _candidate{media: _media, q: _q})
+		}
+	}
+	slices.SortStableFunc(_candidates, func(a, b _candidate) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	for _, _c := range _candidates {
+		switch _c.media {
+		case "application/json", "application/*", "*/*":
+			return "application/json", nil, nil
+		}
+		if _enc, ok := s.config.Encoders[_c.media]; ok {
+			return _c.media, _enc, nil
+		}
+		if _prefix, ok := strings.CutSuffix(_c.media, "/*"); ok {
+			for _, _media := range slices.Sorted(maps.Keys(s.config.Encoders)) {
+				if strings.HasPrefix(_media, _prefix+"/") {
+					return _media, s.config.Encoders[_media], nil
+				}
+			}
+		}
+	}
+	return "", nil, &ErrNotAcceptable{Accept: _accept}
+}
+
+// encode writes 'v' to the response with the provided status code, using the media type
+// negotiated from the "Accept" header (see [ServerConfig.Encoders]). If no supported media
+// type was accepted, JSON is used. If 'v' cannot be encoded, this will panic.
+func (s *Server) encode(w http.ResponseWriter, r *http.Request, _status int, v any) {
+	if len(s.config.Encoders) > 0 {
+		w.Header().Add("Vary", "Accept")
+	}
+
+	_media, _enc, err := s.negotiateEncoder(r)
+	if err != nil || _enc == nil {
+		JSON(w, r, _status, v)
+		return
+	}
+
+	if _sparse, ok := v.(*sparseResponse); ok {
+		// Sparse responses can only be marshalled to JSON, so they're converted to their
+		// generic representation first.
+		_buf, err := json.Marshal(_sparse)
+		if err != nil {
+			panic(fmt.Sprintf("failed to marshal response: %v", err))
+		}
+		var _generic any
+		if err = json.Unmarshal(_buf, &_generic); err != nil {
+			panic(fmt.Sprintf("failed to marshal response: %v", err))
+		}
+		v = _generic
+	}
+
+	w.Header().Set("Content-Type", _media)
+	w.WriteHeader(_status)
+	if err = _enc.Encode(w, v); err != nil && err != io.EOF {
+		panic(fmt.Sprintf("failed to marshal response: %v", err))
+	}
+}
+
 var (
 	// DefaultDecoder is the default decoder used by Bind. You can either override
 	// this, or provide your own. Make sure it is set before Bind is called.
@@ -140,34 +434,76 @@ var (
 	// DefaultDecodeMaxMemory is the maximum amount of memory in bytes that will be
 	// used for decoding multipart/form-data requests.
 	DefaultDecodeMaxMemory int64 = 8 << 20
+
+	// DefaultDecodeMaxBytes is the maximum size in bytes of request bodies which are
+	// decoded by Bind (multipart forms are instead limited by [DefaultDecodeMaxMemory]),
+	// or which are read into memory for requests with an "Idempotency-Key" header (except
+	// for imports, which are limited by [DefaultImportMaxBytes]).
+	DefaultDecodeMaxBytes int64 = 10 << 20
 )
 
 // Bind decodes the request body to the given struct. At this time the only supported
-// content-types are application/json, application/x-www-form-urlencoded, as well as
-// GET parameters.
+// content-types are application/json, application/merge-patch+json and
+// application/json-patch+json (for params which support patching),
+// application/x-www-form-urlencoded, multipart/form-data, as well as GET (and DELETE)
+// parameters. Any other content-type results in an [ErrUnsupportedMediaType].
 func Bind(r *http.Request, v any) error {
+	return bind(r, v, nil)
+}
+
+// bind is the same as [Bind], but also supports the provided decoders, keyed by media
+// type (see [ServerConfig.Decoders]).
+func bind(r *http.Request, v any, _decoders map[string]Decoder) error {
 	err := r.ParseForm()
 	if err != nil {
 		return &ErrBadRequest{Err: fmt.Errorf("parsing form parameters: %w", err)}
 	}
 
 	switch r.Method {
-	case http.MethodGet, http.MethodHead:
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
 		err = DefaultDecoder.Decode(v, r.Form)
 	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		// The content type has already been validated by ParseForm.
+		_media, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		defer r.Body.Close()
+		_body := http.MaxBytesReader(nil, r.Body, DefaultDecodeMaxBytes)
+
 		switch {
+		case strings.HasPrefix(r.Header.Get("Content-Type"), MediaTypeMergePatch):
+			_patcher, ok := v.(MergePatcher)
+			if !ok {
+				return &ErrBadRequest{Err: fmt.Errorf("unsupported content type %s", MediaTypeMergePatch)}
+			}
+			var _data []byte
+			_data, err = io.ReadAll(_body)
+			if err == nil {
+				err = _patcher.DecodeMergePatch(_data)
+			}
+		case strings.HasPrefix(r.Header.Get("Content-Type"), MediaTypeJSONPatch):
+			_patcher, ok := v.(JSONPatcher)
+			if !ok {
+				return &ErrBadRequest{Err: fmt.Errorf("unsupported content type %s", MediaTypeJSONPatch)}
+			}
+			var _ops []JSONPatchOperation
+			err = json.NewDecoder(_body).Decode(&_ops)
+			if err == nil {
+				err = _patcher.DecodeJSONPatch(_ops)
+			}
+		case _decoders[_media] != nil:
+			err = _decoders[_media].Decode(_body, v)
 		case strings.HasPrefix(r.Header.Get("Content-Type"), "application/json"):
-			_dec := json.NewDecoder(r.Body)
+			_dec := json.NewDecoder(_body)
 			_dec.DisallowUnknownFields()
-			defer r.Body.Close()
 			err = _dec.Decode(v)
 		case strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"):
 			err = r.ParseMultipartForm(DefaultDecodeMaxMemory)
 			if err == nil {
 				err = DefaultDecoder.Decode(v, r.MultipartForm.Value)
 			}
-		default:
+		case _media == "" || _media == "application/x-www-form-urlencoded":
 			err = DefaultDecoder.Decode(v, r.PostForm)
+		default:
+			return &ErrUnsupportedMediaType{MediaType: _media}
 		}
 	default:
 		return &ErrBadRequest{Err: fmt.Errorf("unsupported method %s", r.Method)}
@@ -180,26 +516,32 @@ func Bind(r *http.Request, v any) error {
 }
 
 // Req simplifies making an HTTP handler that returns a single result, and an error.
-// The result, if not nil, must be JSON-marshalable. If result is nil, [http.StatusNoContent]
-// will be returned.
+// The result, if not nil, must be JSON-marshalable (or supported by the negotiated encoder,
+// see [ServerConfig.Encoders]). If result is nil, [http.StatusNoContent] will be returned.
 func Req[Resp any](s *Server, _op Operation, _fn func(*http.Request) (*Resp, error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
 		_results, err := _fn(r)
 		handleResponse(s, w, r, _op, _results, err)
 	}
 }
 
-// resolveID resolves the ID from the request path, and unmarshals it into the provided type.
-// Only supports string, int, and types that support UnmarshalText, UnmarshalJSON, or UnmarshalBinary
-// (in that order).
-func resolveID[T any](r *http.Request) (_id T, err error) {
-	_value := r.PathValue("id")
+// resolveID resolves the provided ID path parameter from the request path, and unmarshals
+// it into the provided type. Only supports string, int, and types that support UnmarshalText,
+// UnmarshalJSON, or UnmarshalBinary (in that order).
+func resolveID[T any](r *http.Request, _param string) (_id T, err error) {
+	_value := r.PathValue(_param)
 
 	switch any(_id).(type) {
 	case string:
 		_id = any(_value).(T)
 	case int:
-		_rid, err := strconv.Atoi(_value)
+		var _rid int
+		_rid, err = strconv.Atoi(_value)
 		if err == nil {
 			_id = any(_rid).(T)
 		}
@@ -233,7 +575,12 @@ func resolveID[T any](r *http.Request) (_id T, err error) {
 // handler function.
 func ReqID[Resp, I any](s *Server, _op Operation, _fn func(*http.Request, I) (*Resp, error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		_id, err := resolveID[I](r)
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
+		_id, err := resolveID[I](r, "id")
 		if err != nil {
 			handleResponse[Resp](s, w, r, _op, nil, err)
 			return
@@ -243,12 +590,41 @@ func ReqID[Resp, I any](s *Server, _op Operation, _fn func(*http.Request, I) (*R
 	}
 }
 
+// ReqEdgeID is similar to ReqID, but also processes an "edgeID" path parameter (the ID of
+// the related entity), and provides both to the handler function.
+func ReqEdgeID[Resp, I, E any](s *Server, _op Operation, _fn func(*http.Request, I, E) (*Resp, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
+		_id, err := resolveID[I](r, "id")
+		if err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		_edgeID, err := resolveID[E](r, "edgeID")
+		if err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		_results, err := _fn(r, _id, _edgeID)
+		handleResponse(s, w, r, _op, _results, err)
+	}
+}
+
 // ReqParam is similar to Req, but also processes a request body/query params and provides it
 // to the handler function.
 func ReqParam[Params, Resp any](s *Server, _op Operation, _fn func(*http.Request, *Params) (*Resp, error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
 		_params := new(Params)
-		if err := Bind(r, _params); err != nil {
+		if err := bind(r, _params, s.config.Decoders); err != nil {
 			handleResponse[Resp](s, w, r, _op, nil, err)
 			return
 		}
@@ -257,17 +633,48 @@ func ReqParam[Params, Resp any](s *Server, _op Operation, _fn func(*http.Request
 	}
 }
 
+// createdKey is the context key used to signal that the entity in the response was
+// created, and [http.StatusCreated] should be returned.
+type createdKey struct{}
+
+// ReqUpsert is similar to ReqParam, but the handler function also returns if the entity was
+// created, in which case [http.StatusCreated] is returned rather than [http.StatusOK].
+func ReqUpsert[Params, Resp any](s *Server, _op Operation, _fn func(*http.Request, *Params) (*Resp, bool, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
+		_params := new(Params)
+		if err := bind(r, _params, s.config.Decoders); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		_results, _created, err := _fn(r, _params)
+		if _created {
+			r = r.WithContext(context.WithValue(r.Context(), createdKey{}, true))
+		}
+		handleResponse(s, w, r, _op, _results, err)
+	}
+}
+
 // ReqIDParam is similar to ReqParam, but also processes an "id" path parameter and request
 // body/query params, and provides it to the handler function.
 func ReqIDParam[Params, Resp, I any](s *Server, _op Operation, _fn func(*http.Request, I, *Params) (*Resp, error)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		_id, err := resolveID[I](r)
+		if _, _, err := s.negotiateEncoder(r); err != nil {
+			handleResponse[Resp](s, w, r, _op, nil, err)
+			return
+		}
+		r = s.withAudit(r, _op)
+		_id, err := resolveID[I](r, "id")
 		if err != nil {
 			handleResponse[Resp](s, w, r, _op, nil, err)
 			return
 		}
 		_params := new(Params)
-		err = Bind(r, _params)
+		err = bind(r, _params, s.config.Decoders)
 		if err != nil {
 			handleResponse[Resp](s, w, r, _op, nil, err)
 			return
@@ -299,6 +706,97 @@ type linkablePagedResource interface {
 	GetIsLastPage() bool
 }
 
+type linkableCursorResource interface {
+	GetNextCursor() string
+	GetPrevCursor() string
+}
+
+// entityETag returns the entity tag of the provided response, if the response is a
+// versioned entity, or a page of versioned entities.
+func entityETag(_resp any) (string, bool) {
+	switch v := _resp.(type) {
+	case *ent.Pet:
+		return formatETag(v.Version), true
+	case *PagedResponse[ent.Pet]:
+		return listETag(v.Content, func(e *ent.Pet) any { return [2]any{e.ID, e.Version} }, v.Page, v.TotalCount), true
+	case *CursorResponse[ent.Pet]:
+		return listETag(v.Content, func(e *ent.Pet) any { return [2]any{e.ID, e.Version} }, v.NextCursor, v.PrevCursor), true
+	}
+	return "", false
+}
+
+// formatETag formats the provided entity version as a strong entity tag.
+func formatETag[T any](_version T) string {
+	return strconv.Quote(fmt.Sprint(_version))
+}
+
+// listETag returns a weak entity tag for a page of versioned entities, which changes
+// when any of the entities (or the page itself) change.
+func listETag[T any](_items []*T, _version func(*T) any, _page ...any) string {
+	_hash := fnv.New64a()
+	for _, _item := range _items {
+		fmt.Fprintf(_hash, "%v;", _version(_item))
+	}
+	for _, v := range _page {
+		if _ptr, ok := v.(*string); ok && _ptr != nil {
+			v = *_ptr
+		}
+		fmt.Fprintf(_hash, "%v;", v)
+	}
+	return fmt.Sprintf(`W/"%x"`, _hash.Sum64())
+}
+
+// matchETag returns true if the provided "If-None-Match" header value matches the
+// provided entity tag, using weak comparison.
+func matchETag(_header, _etag string) bool {
+	if _header == "" {
+		return false
+	}
+	for _, _tag := range strings.Split(_header, ",") {
+		_tag = strings.TrimSpace(_tag)
+		if _tag == "*" || strings.TrimPrefix(_tag, "W/") == strings.TrimPrefix(_etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch parses the versions from the "If-Match" header of the provided request.
+// If false is returned, the header wasn't provided (or was "*"), and the request isn't
+// conditional. Weak and invalid entity tags never match, as If-Match requires strong
+// comparison.
+func parseIfMatch[T any](r *http.Request) (_versions []T, ok bool) {
+	_header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if _header == "" || _header == "*" {
+		return nil, false
+	}
+	_versions = []T{}
+	for _, _tag := range strings.Split(_header, ",") {
+		_value, err := strconv.Unquote(strings.TrimSpace(_tag))
+		if err != nil {
+			continue
+		}
+		var _version T
+		if err = json.Unmarshal([]byte(_value), &_version); err == nil {
+			_versions = append(_versions, _version)
+		}
+	}
+	return _versions, true
+}
+
+// preconditionFailed converts the not found error of a conditional update or delete
+// into [ErrPreconditionFailed], if the entity still exists (i.e. the provided version
+// didn't match).
+func preconditionFailed(ctx context.Context, err error, _exists func(context.Context) (bool, error)) error {
+	if !ent.IsNotFound(err) {
+		return err
+	}
+	if ok, _ := _exists(ctx); ok {
+		return &ErrPreconditionFailed{Err: errors.New("entity has been modified since the provided If-Match version")}
+	}
+	return err
+}
+
 // Spec returns the OpenAPI spec for the server implementation.
 func (s *Server) Spec(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -391,6 +889,367 @@ func (s *Server) Docs(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(_buf.Bytes())
 }
 
+// ErrIdempotencyKeyMismatch is returned when an "Idempotency-Key" header is re-used
+// with a different request.
+var ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used with a different request")
+
+// IsIdempotencyKeyMismatch returns true if the unwrapped/underlying error is of type ErrIdempotencyKeyMismatch.
+func IsIdempotencyKeyMismatch(err error) bool {
+	return errors.Is(err, ErrIdempotencyKeyMismatch)
+}
+
+// ErrIdempotencyKeyInProgress is returned when an "Idempotency-Key" header is re-used
+// while the original request is still in progress.
+var ErrIdempotencyKeyInProgress = errors.New("a request with the same idempotency key is still in progress")
+
+// IsIdempotencyKeyInProgress returns true if the unwrapped/underlying error is of type ErrIdempotencyKeyInProgress.
+func IsIdempotencyKeyInProgress(err error) bool {
+	return errors.Is(err, ErrIdempotencyKeyInProgress)
+}
+
+// IdempotencyByAuthorization returns the "Authorization" header of the request, which is
+// the default key used to identify the client of a request (see
+// [ServerConfig.IdempotencyClientKey]).
+func IdempotencyByAuthorization(r *http.Request) string {
+	return r.Header.Get("Authorization")
+}
+
+// DefaultIdempotencyTTL is the default duration in which idempotency keys are stored.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyRecord is the stored response of a request which included an
+// "Idempotency-Key" header.
+type IdempotencyRecord struct {
+	Hash       string      // Hash of the request, used to detect re-use of the key with a different request.
+	StatusCode int         // Status code of the response, or zero if the request is still in progress.
+	Header     http.Header // Headers of the response.
+	Body       []byte      // Body of the response.
+	CreatedAt  time.Time   // Time in which the key was initially used.
+}
+
+// IdempotencyStore stores the responses of requests which include an "Idempotency-Key"
+// header. Keys provided to the store are already scoped to the method and path of the
+// request.
+type IdempotencyStore interface {
+	// Reserve reserves the provided key for a request with the provided hash. If the
+	// key has already been reserved (and hasn't expired), the existing record is
+	// returned, and nothing is reserved.
+	Reserve(ctx context.Context, _key, _hash string) (*IdempotencyRecord, error)
+
+	// Complete stores the response of the request which reserved the provided key.
+	Complete(ctx context.Context, _key string, _record *IdempotencyRecord) error
+
+	// Release removes the reservation of the provided key, e.g. when the request
+	// resulted in a server error, so that it can be retried.
+	Release(ctx context.Context, _key string) error
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+// MemoryIdempotencyStore is an in-memory [IdempotencyStore]. Note that keys aren't
+// shared between multiple instances of the server, and are lost on restart.
+type MemoryIdempotencyStore struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+	swept   time.Time
+}
+
+// NewMemoryIdempotencyStore returns a new in-memory [IdempotencyStore], which stores
+// keys for the provided duration (or [DefaultIdempotencyTTL] if zero).
+func NewMemoryIdempotencyStore(_ttl time.Duration) *MemoryIdempotencyStore {
+	if _ttl <= 0 {
+		_ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:     _ttl,
+		records: make(map[string]*IdempotencyRecord),
+	}
+}
+
+func (m *MemoryIdempotencyStore) Reserve(_ context.Context, _key, _hash string) (*IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_now := time.Now()
+	if _now.Sub(m.swept) >= time.Minute {
+		for k, v := range m.records {
+			if _now.Sub(v.CreatedAt) >= m.ttl {
+				delete(m.records, k)
+			}
+		}
+		m.swept = _now
+	}
+
+	if _record, ok := m.records[_key]; ok && _now.Sub(_record.CreatedAt) < m.ttl {
+		_copy := *_record
+		return &_copy, nil
+	}
+
+	m.records[_key] = &IdempotencyRecord{Hash: _hash, CreatedAt: _now}
+	return nil, nil
+}
+
+func (m *MemoryIdempotencyStore) Complete(_ context.Context, _key string, _record *IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_copy := *_record
+	if _existing, ok := m.records[_key]; ok {
+		_copy.CreatedAt = _existing.CreatedAt
+	}
+	m.records[_key] = &_copy
+	return nil
+}
+
+func (m *MemoryIdempotencyStore) Release(_ context.Context, _key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, _key)
+	return nil
+}
+
+var _ IdempotencyStore = (*EntIdempotencyStore)(nil)
+
+// EntIdempotencyStore is an [IdempotencyStore] which stores keys in the database,
+// using the IdempotencyKey schema, so that keys are shared between multiple instances
+// of the server.
+type EntIdempotencyStore struct {
+	db  *ent.Client
+	ttl time.Duration
+}
+
+// NewEntIdempotencyStore returns a new [IdempotencyStore] backed by the IdempotencyKey
+// schema, which stores keys for the provided duration (or [DefaultIdempotencyTTL]
+// if zero). Expired keys are replaced when re-used, and can be removed using
+// [EntIdempotencyStore.Purge].
+func NewEntIdempotencyStore(_db *ent.Client, _ttl time.Duration) *EntIdempotencyStore {
+	if _ttl <= 0 {
+		_ttl = DefaultIdempotencyTTL
+	}
+	return &EntIdempotencyStore{db: _db, ttl: _ttl}
+}
+
+func (e *EntIdempotencyStore) Reserve(ctx context.Context, _key, _hash string) (*IdempotencyRecord, error) {
+	// If an expired key is removed, or the key is released between the create and
+	// query below, try again.
+	for range 3 {
+		err := e.db.IdempotencyKey.Create().SetKey(_key).SetHash(_hash).Exec(ctx)
+		if err == nil {
+			return nil, nil
+		}
+		if !ent.IsConstraintError(err) {
+			return nil, err
+		}
+
+		_existing, err := e.db.IdempotencyKey.Query().Where(idempotencykey.Key(_key)).Only(ctx)
+		if ent.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if time.Since(_existing.CreatedAt) < e.ttl {
+			return &IdempotencyRecord{
+				Hash:       _existing.Hash,
+				StatusCode: _existing.StatusCode,
+				Header:     _existing.Header,
+				Body:       _existing.Body,
+				CreatedAt:  _existing.CreatedAt,
+			}, nil
+		}
+
+		err = e.db.IdempotencyKey.DeleteOneID(_existing.ID).Exec(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+func (e *EntIdempotencyStore) Complete(ctx context.Context, _key string, _record *IdempotencyRecord) error {
+	return e.db.IdempotencyKey.Update().
+		Where(idempotencykey.Key(_key)).
+		SetStatusCode(_record.StatusCode).
+		SetHeader(_record.Header).
+		SetBody(_record.Body).
+		Exec(ctx)
+}
+
+func (e *EntIdempotencyStore) Release(ctx context.Context, _key string) error {
+	_, err := e.db.IdempotencyKey.Delete().Where(idempotencykey.Key(_key)).Exec(ctx)
+	return err
+}
+
+// Purge removes all expired keys, returning the number of keys removed. This can
+// be called periodically to prevent the table from growing indefinitely.
+func (e *EntIdempotencyStore) Purge(ctx context.Context) (int, error) {
+	return e.db.IdempotencyKey.Delete().Where(idempotencykey.CreatedAtLT(time.Now().Add(-e.ttl))).Exec(ctx)
+}
+
+// idempotencyWriter records the response written by the next handler, so that it
+// can be stored.
+type idempotencyWriter struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *idempotencyWriter) WriteHeader(_code int) {
+	if w.status == 0 {
+		w.status = _code
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(_code)
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// withIdempotency wraps the handler of the provided POST or PATCH operation, replaying
+// the stored response of requests which include an "Idempotency-Key" header, when
+// they're retried by the same client (see [ServerConfig.IdempotencyClientKey]) with the
+// same key and request. Re-using a key with a different request results in a 422, and
+// re-using a key while the original request is still in progress results in a 409.
+// Server errors aren't stored, so those requests can be retried.
+func (s *Server) withIdempotency(_op Operation, _next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_key := r.Header.Get("Idempotency-Key")
+		if _key == "" {
+			_next(w, r)
+			return
+		}
+
+		if len(_key) > 255 {
+			handleResponse[struct{}](s, w, r, _op, nil, &ErrBadRequest{Err: errors.New("idempotency key must be at most 255 characters")})
+			return
+		}
+
+		_limit := DefaultDecodeMaxBytes
+		if _op == OperationImport {
+			_limit = DefaultImportMaxBytes
+		}
+		_body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, _limit))
+		if err != nil {
+			var _maxErr *http.MaxBytesError
+			if errors.As(err, &_maxErr) {
+				err = fmt.Errorf("request body exceeds the maximum size of %d bytes", _maxErr.Limit)
+			}
+			handleResponse[struct{}](s, w, r, _op, nil, &ErrBadRequest{Err: err})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(_body))
+
+		_scope := sha256.New()
+		for _, v := range []string{r.Method + " " + r.URL.Path, s.config.IdempotencyClientKey(r), _key} {
+			_scope.Write([]byte(strconv.Itoa(len(v)) + ":" + v + "\n"))
+		}
+		_hash := sha256.New()
+		_hash.Write([]byte(r.URL.RawQuery + "\n"))
+		_hash.Write(_body)
+		_id, _sum := hex.EncodeToString(_scope.Sum(nil)), hex.EncodeToString(_hash.Sum(nil))
+
+		_record, err := s.config.IdempotencyStore.Reserve(r.Context(), _id, _sum)
+		if err != nil {
+			handleResponse[struct{}](s, w, r, _op, nil, err)
+			return
+		}
+
+		if _record != nil {
+			switch {
+			case _record.Hash != _sum:
+				handleResponse[struct{}](s, w, r, _op, nil, ErrIdempotencyKeyMismatch)
+			case _record.StatusCode == 0:
+				handleResponse[struct{}](s, w, r, _op, nil, ErrIdempotencyKeyInProgress)
+			default:
+				for k, v := range _record.Header {
+					if _, ok := w.Header()[k]; !ok {
+						w.Header()[k] = v
+					}
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(_record.StatusCode)
+				_, _ = w.Write(_record.Body)
+			}
+			return
+		}
+
+		// The response has already been written by the time the store is updated, so
+		// the request context may already be canceled.
+		_ctx := context.WithoutCancel(r.Context())
+		_rw := &idempotencyWriter{ResponseWriter: w}
+
+		defer func() {
+			if _rw.status == 0 || _rw.status >= http.StatusInternalServerError {
+				_ = s.config.IdempotencyStore.Release(_ctx, _id)
+				return
+			}
+			_ = s.config.IdempotencyStore.Complete(_ctx, _id, &IdempotencyRecord{
+				Hash:       _sum,
+				StatusCode: _rw.status,
+				Header:     _rw.header,
+				Body:       _rw.body.Bytes(),
+				CreatedAt:  time.Now(),
+			})
+		}()
+
+		_next(_rw, r)
+	}
+}
+
+// hookedClients holds the clients which servers have registered hooks on.
+var hookedClients sync.Map
+
+// registerHooks ensures hooks are only registered once per client. Hooks can't be removed
+// from a client, so registering them again (i.e. creating another server with the same
+// client) would run them more than once for every mutation.
+func registerHooks(_db *ent.Client) error {
+	if _, loaded := hookedClients.LoadOrStore(_db, struct{}{}); loaded {
+		return errors.New("hooks are already registered on the provided client, NewServer may only be called once per client")
+	}
+	return nil
+}
+
+// entityMutation is implemented by the generated mutations of entities with an ID.
+type entityMutation[I any] interface {
+	ent.Mutation
+	ID() (I, bool)
+	IDs(context.Context) ([]I, error)
+	Tx() (*ent.Tx, error)
+	Client() *ent.Client
+}
+
+// afterCommit runs _fn once the transaction in the context (if any) is committed, or
+// immediately if the context doesn't include a transaction.
+func afterCommit(ctx context.Context, _fn func(ctx context.Context)) {
+	_tx := ent.TxFromContext(ctx)
+	if _tx == nil {
+		_fn(ctx)
+		return
+	}
+	_tx.OnCommit(func(_next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			if err := _next.Commit(ctx, tx); err != nil {
+				return err
+			}
+			_fn(ctx)
+			return nil
+		})
+	})
+}
+
 type ServerConfig struct {
 	// BaseURL is similar to [ServerConfig.BasePath], however, only the path of the URL is used
 	// to prefill BasePath. This is not required if BasePath is provided.
@@ -409,6 +1268,17 @@ type ServerConfig struct {
 	// server URL into the spec. This only applies if [ServerConfig.BaseURL] is provided.
 	DisableSpecInjectServer bool
 
+	// Authorizer is invoked before the handler of every operation, with the operation, the
+	// name of the entity (e.g. "Pet", including for edge operations like "/pets/{id}/owner")
+	// and the scopes required by the operation (see entrest.WithScopes), if any. Returning
+	// an error aborts the request. Return [ErrUnauthorized] (or an error which wraps it) if
+	// the request isn't authenticated, resulting in a 401 "Unauthorized" response. All
+	// other errors result in a 403 "Forbidden" response (see [ErrForbidden]). Entities
+	// created through nested edges (see entrest.WithNestedCreate) are also authorized, with
+	// [OperationCreate], the name of their entity and the create scopes of their entity,
+	// before anything is created. If not provided, all requests are allowed.
+	Authorizer func(r *http.Request, _op Operation, _entity string, _scopes []string) error
+
 	// DisableDocsHandler if set to true, will disable the embedded API reference documentation
 	// endpoint at /docs. Use this if you want to provide your own documentation functionality.
 	// This is disabled by default if [ServerConfig.DisableSpecHandler] is true.
@@ -419,6 +1289,92 @@ type ServerConfig struct {
 	// through results, and more.
 	EnableLinks bool
 
+	// IdempotencyStore is used to store the responses of POST and PATCH requests which
+	// include an "Idempotency-Key" header, so that retries of those requests can be
+	// replayed. Defaults to an in-memory store (see [NewMemoryIdempotencyStore]), which
+	// isn't shared between multiple instances of the server.
+	IdempotencyStore IdempotencyStore
+
+	// IdempotencyClientKey returns the key which identifies the client of a request (e.g.
+	// an API key, or the ID of the authenticated user), as idempotency keys are scoped to
+	// each client, so that clients can't replay the responses of other clients. Defaults
+	// to the "Authorization" header of the request (see [IdempotencyByAuthorization]),
+	// which should be replaced when clients are authenticated in other ways (e.g. using
+	// cookies).
+	IdempotencyClientKey func(r *http.Request) string
+
+	// Encoders are additional response encoders keyed by media type (e.g. "application/cbor"),
+	// which are used when requested through the "Accept" header. JSON is always supported,
+	// and is used by default. Requests which don't accept JSON or any of the registered
+	// media types return a 406 "Not Acceptable".
+	Encoders map[string]Encoder
+
+	// Decoders are additional request body decoders keyed by media type (e.g.
+	// "application/cbor"), which are used based on the "Content-Type" header. Requests
+	// with an unsupported "Content-Type" return a 415 "Unsupported Media Type".
+	Decoders map[string]Decoder
+
+	// EventBuffer stores recent events of the event streams (see [OperationEvents]), so
+	// clients can resume streams using the "Last-Event-ID" header without missing events.
+	// Defaults to an in-memory buffer (see [NewMemoryEventBuffer]), which isn't shared
+	// between multiple instances of the server.
+	EventBuffer EventBuffer
+
+	// Webhooks are the subscriptions which webhook events are delivered to, in addition
+	// to the subscriptions provided by WebhookStore.
+	Webhooks []*WebhookSubscription
+
+	// WebhookStore provides additional webhook subscriptions, e.g. stored in the database
+	// (see [NewEntWebhookSubscriptionStore]). If neither Webhooks nor
+	// WebhookStore are provided, webhook deliveries aren't recorded.
+	WebhookStore WebhookSubscriptionStore
+
+	// WebhookOutbox stores webhook deliveries until they're delivered by [Server.RunWebhooks]
+	// (or [Server.DeliverWebhooks]). Defaults to an in-memory outbox (see
+	// [NewMemoryWebhookOutbox]), which isn't shared between multiple instances of the
+	// server (see [NewEntWebhookOutbox]). If storing
+	// deliveries fails, the mutation which caused them returns an error, so use
+	// transactions to ensure changes aren't made without their deliveries.
+	WebhookOutbox WebhookOutbox
+
+	// WebhookClient is the HTTP client used to deliver webhooks. Defaults to a client
+	// with a timeout of [WebhookTimeout].
+	WebhookClient *http.Client
+
+	// AuditSink, if provided, receives an audit record for each entity changed through
+	// the API (see [AuditRecord]), including a field-level diff of the changes, with
+	// sensitive fields redacted. See [NewSlogAuditSink] and [NewMemoryAuditSink].
+	AuditSink AuditSink
+
+	// AuditErrorHandler is invoked when AuditSink fails to write audit records. Records are
+	// written once the changes have been made, so errors don't fail the request. Defaults
+	// to logging the error using [slog.Default].
+	AuditErrorHandler func(ctx context.Context, _records []*AuditRecord, err error)
+
+	// ActorFromRequest returns the actor (e.g. the ID of the authenticated user) which is
+	// recorded in audit records and history entries (see [HistoryEntry]).
+	ActorFromRequest func(r *http.Request) string
+	// HistoryStore stores the history of entities with history enabled (see
+	// [HistoryEntry]). Defaults to storing history in the database (see
+	// [NewEntHistoryStore]). If storing an entry fails, the mutation which caused it
+	// returns an error.
+	HistoryStore HistoryStore
+
+	// RateLimiter limits requests to rate limited endpoints (see [RateLimit]). Defaults to
+	// an in-memory token bucket limiter (see [NewMemoryRateLimiter]), which isn't shared
+	// between multiple instances of the server.
+	RateLimiter RateLimiter
+
+	// RateLimitKey returns the key which identifies the client of a request (e.g. an API
+	// key, or the ID of the authenticated user), as each client is rate limited separately.
+	// Defaults to the IP address of the client (see [RateLimitByIP]).
+	RateLimitKey func(r *http.Request) string
+
+	// CountEstimator is used by unfiltered list operations using the "estimated" count
+	// strategy (see [CountEstimated]), e.g. [NewPostgresCountEstimator]. If not provided,
+	// an exact count is used instead.
+	CountEstimator CountEstimator
+
 	// MaskErrors if set to true, will mask the error message returned to the client,
 	// returning a generic error message based on the HTTP status code.
 	MaskErrors bool
@@ -436,13 +1392,19 @@ type ServerConfig struct {
 }
 
 type Server struct {
-	db     *ent.Client
-	config *ServerConfig
+	db            *ent.Client
+	config        *ServerConfig
+	events        *eventBroker
+	webhooksReady chan struct{}
 }
 
 // NewServer returns a new auto-generated server implementation for your ent schema.
 // [Server.Handler] returns a ready-to-use http.Handler that mounts all of the
 // necessary endpoints.
+//
+// Depending on the configuration, hooks are registered on the provided client (e.g. to
+// record audit records), in which case NewServer returns an error if called again with
+// the same client.
 func NewServer(_db *ent.Client, _config *ServerConfig) (*Server, error) {
 	s := &Server{
 		db:     _db,
@@ -467,6 +1429,98 @@ func NewServer(_db *ent.Client, _config *ServerConfig) (*Server, error) {
 		}
 		s.config.BasePath = strings.TrimRight(s.config.BasePath, "/")
 	}
+	if s.config.IdempotencyStore == nil {
+		s.config.IdempotencyStore = NewMemoryIdempotencyStore(0)
+	}
+	if s.config.IdempotencyClientKey == nil {
+		s.config.IdempotencyClientKey = IdempotencyByAuthorization
+	}
+	if err := registerHooks(_db); err != nil {
+		return nil, err
+	}
+	if s.config.EventBuffer == nil {
+		s.config.EventBuffer = NewMemoryEventBuffer(0)
+	}
+	s.events = newEventBroker(s.config.EventBuffer)
+
+	// Hooks are registered on the provided client, so changes made through it (or any
+	// transactions started from it) are broadcast to the event streams.
+	_db.Pet.Use(eventHook[int]("", s.events.publishAll))
+	if s.config.WebhookOutbox == nil {
+		s.config.WebhookOutbox = NewMemoryWebhookOutbox()
+	}
+	if s.config.WebhookClient == nil {
+		s.config.WebhookClient = &http.Client{Timeout: WebhookTimeout}
+	}
+	s.webhooksReady = make(chan struct{}, 1)
+
+	// Deliveries are stored within the transaction of the mutation (if any), once the
+	// mutation succeeds. Without any subscriptions, there is nothing to deliver.
+	if len(s.config.Webhooks) > 0 || s.config.WebhookStore != nil {
+		_db.Pet.Use(eventHook[int]("",
+			webhookCapture(s, "pet", loadPetWebhook),
+		))
+		_db.User.Use(eventHook[uuid.UUID]("",
+			webhookCapture(s, "user", loadUserWebhook),
+		))
+	}
+	if s.config.AuditSink != nil {
+		if s.config.AuditErrorHandler == nil {
+			s.config.AuditErrorHandler = defaultAuditErrorHandler
+		}
+
+		// Hooks are registered on the provided client, but only record changes made by
+		// the server's handlers (see [Server.withAudit]).
+		_db.Category.Use(auditHook(
+			s, "deleted_at",
+			[]string{},
+			auditCategoryValues,
+		))
+		_db.Friendship.Use(auditHook(
+			s, "",
+			[]string{},
+			auditFriendshipValues,
+		))
+		_db.Pet.Use(auditHook(
+			s, "",
+			[]string{},
+			auditPetValues,
+		))
+		_db.Post.Use(auditHook(
+			s, "",
+			[]string{},
+			auditPostValues,
+		))
+		_db.Settings.Use(auditHook(
+			s, "",
+			[]string{},
+			auditSettingsValues,
+		))
+		_db.User.Use(auditHook(
+			s, "",
+			[]string{"password_hashed"},
+			auditUserValues,
+		))
+	}
+	if s.config.HistoryStore == nil {
+		s.config.HistoryStore = NewEntHistoryStore(_db)
+	}
+
+	// History is recorded for all changes, including those not made through the API.
+	_db.Category.Use(historyHook(
+		s, "deleted_at",
+		historyCategorySnapshots,
+	))
+	_db.Pet.Use(historyHook(
+		s, "",
+		historyPetSnapshots,
+	))
+	if s.config.RateLimiter == nil {
+		s.config.RateLimiter = NewMemoryRateLimiter()
+	}
+	if s.config.RateLimitKey == nil {
+		s.config.RateLimitKey = RateLimitByIP
+	}
 	return s, nil
 }
 
@@ -486,12 +1540,30 @@ func (s *Server) DefaultErrorHandler(w http.ResponseWriter, r *http.Request, _op
 		_resp.Code = http.StatusNotFound
 	case IsMethodNotAllowed(err):
 		_resp.Code = http.StatusMethodNotAllowed
+	case IsUnauthorized(err):
+		_resp.Code = http.StatusUnauthorized
+	case IsForbidden(err):
+		_resp.Code = http.StatusForbidden
 	case IsBadRequest(err):
 		_resp.Code = http.StatusBadRequest
 	case IsInvalidID(err):
 		_resp.Code = http.StatusBadRequest
+	case IsPreconditionFailed(err):
+		_resp.Code = http.StatusPreconditionFailed
+	case IsNotAcceptable(err):
+		_resp.Code = http.StatusNotAcceptable
+	case IsUnsupportedMediaType(err):
+		_resp.Code = http.StatusUnsupportedMediaType
+	case IsIdempotencyKeyMismatch(err):
+		_resp.Code = http.StatusUnprocessableEntity
+	case IsIdempotencyKeyInProgress(err):
+		_resp.Code = http.StatusConflict
 	case errors.Is(err, privacy.Deny):
 		_resp.Code = http.StatusForbidden
+	case IsRateLimited(err):
+		_resp.Code = http.StatusTooManyRequests
+	case IsHistoryNotFound(err):
+		_resp.Code = http.StatusNotFound
 	case ent.IsNotFound(err):
 		_resp.Code = http.StatusNotFound
 	case ent.IsConstraintError(err), ent.IsNotSingular(err):
@@ -511,12 +1583,16 @@ func (s *Server) DefaultErrorHandler(w http.ResponseWriter, r *http.Request, _op
 	if s.config.MaskErrors {
 		_resp.Error = http.StatusText(_resp.Code)
 	}
+	_resp.RequestID = s.reqID(r)
+	s.encode(w, r, _resp.Code, _resp)
+}
+
+// reqID returns the request ID of the request (see [ServerConfig.GetReqID]).
+func (s *Server) reqID(r *http.Request) string {
 	if s.config.GetReqID != nil {
-		_resp.RequestID = s.config.GetReqID(r)
-	} else {
-		_resp.RequestID = r.Header.Get("X-Request-Id")
+		return s.config.GetReqID(r)
 	}
-	JSON(w, r, _resp.Code, _resp)
+	return r.Header.Get("X-Request-Id")
 }
 
 func handleResponse[Resp any](s *Server, w http.ResponseWriter, r *http.Request, _op Operation, _resp *Resp, err error) {
@@ -547,6 +1623,25 @@ func handleResponse[Resp any](s *Server, w http.ResponseWriter, r *http.Request,
 					}
 				}
 			}
+			if _lr, ok := any(_resp).(linkableCursorResource); ok {
+				_query := r.URL.Query()
+				if _cursor := _lr.GetPrevCursor(); _cursor != "" {
+					_query.Set("cursor", _cursor)
+					r.URL.RawQuery = _query.Encode()
+					_links["prev"] = r.URL.String()
+					if !strings.HasPrefix(_links["prev"], s.config.BasePath) {
+						_links["prev"] = s.config.BasePath + _links["prev"]
+					}
+				}
+				if _cursor := _lr.GetNextCursor(); _cursor != "" {
+					_query.Set("cursor", _cursor)
+					r.URL.RawQuery = _query.Encode()
+					_links["next"] = r.URL.String()
+					if !strings.HasPrefix(_links["next"], s.config.BasePath) {
+						_links["next"] = s.config.BasePath + _links["next"]
+					}
+				}
+			}
 		}
 
 		if v := _links.String(); v != "" {
@@ -562,18 +1657,34 @@ func handleResponse[Resp any](s *Server, w http.ResponseWriter, r *http.Request,
 		return
 	}
 	if _resp != nil {
-		type pagedResp interface {
-			GetTotalCount() int
+		if _etag, ok := entityETag(_resp); ok {
+			w.Header().Set("ETag", _etag)
+			if r.Method == http.MethodGet && matchETag(r.Header.Get("If-None-Match"), _etag) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		var _out any = _resp
+		if _op == OperationRead || _op == OperationList {
+			// Fields have already been validated by the operation at this point.
+			if _fields := parseFields(r.URL.Query()["fields"]); len(_fields) > 0 {
+				_out = &sparseResponse{value: _resp, fields: _fields}
+			}
+		}
+
+		type listResp interface {
+			Len() int
 		}
-		if v, ok := any(_resp).(pagedResp); ok && v.GetTotalCount() == 0 && r.Method == http.MethodGet {
-			JSON(w, r, http.StatusNotFound, _resp)
+		if v, ok := any(_resp).(listResp); ok && v.Len() == 0 && r.Method == http.MethodGet {
+			s.encode(w, r, http.StatusNotFound, _out)
 			return
 		}
-		if r.Method == http.MethodPost {
-			JSON(w, r, http.StatusCreated, _resp)
+		if (r.Method == http.MethodPost && _op != OperationRestore && _op != OperationImport && _op != OperationHistoryRestore) || r.Context().Value(createdKey{}) != nil {
+			s.encode(w, r, http.StatusCreated, _out)
 			return
 		}
-		JSON(w, r, http.StatusOK, _resp)
+		s.encode(w, r, http.StatusOK, _out)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -594,50 +1705,73 @@ func UseEntContext(_db *ent.Client) func(_next http.Handler) http.Handler {
 // Handler returns a ready-to-use http.Handler that mounts all of the necessary endpoints.
 func (s *Server) Handler() http.Handler {
 	_mux := http.NewServeMux()
-	_mux.HandleFunc("GET /categories", ReqParam(s, OperationList, s.ListCategories))
-	_mux.HandleFunc("GET /categories/{id}", ReqID(s, OperationRead, s.GetCategory))
-	_mux.HandleFunc("GET /categories/{id}/pets", ReqIDParam(s, OperationList, s.ListCategoryPets))
-	_mux.HandleFunc("POST /categories", ReqParam(s, OperationCreate, s.CreateCategory))
-	_mux.HandleFunc("PATCH /categories/{id}", ReqIDParam(s, OperationUpdate, s.UpdateCategory))
-	_mux.HandleFunc("DELETE /categories/{id}", ReqID(s, OperationDelete, s.DeleteCategory))
-	_mux.HandleFunc("GET /follows", ReqParam(s, OperationList, s.ListFollows))
-	_mux.HandleFunc("POST /follows", ReqParam(s, OperationCreate, s.CreateFollow))
-	_mux.HandleFunc("GET /friendships", ReqParam(s, OperationList, s.ListFriendships))
-	_mux.HandleFunc("GET /friendships/{id}", ReqID(s, OperationRead, s.GetFriendship))
-	_mux.HandleFunc("GET /friendships/{id}/user", ReqID(s, OperationRead, s.GetFriendshipUser))
-	_mux.HandleFunc("GET /friendships/{id}/friend", ReqID(s, OperationRead, s.GetFriendshipFriend))
-	_mux.HandleFunc("POST /friendships", ReqParam(s, OperationCreate, s.CreateFriendship))
-	_mux.HandleFunc("PATCH /friendships/{id}", ReqIDParam(s, OperationUpdate, s.UpdateFriendship))
-	_mux.HandleFunc("DELETE /friendships/{id}", ReqID(s, OperationDelete, s.DeleteFriendship))
-	_mux.HandleFunc("GET /pets", ReqParam(s, OperationList, s.ListPets))
-	_mux.HandleFunc("GET /pets/{id}", ReqID(s, OperationRead, s.GetPet))
-	_mux.HandleFunc("GET /pets/{id}/categories", ReqIDParam(s, OperationList, s.ListPetCategories))
-	_mux.HandleFunc("GET /pets/{id}/owner", ReqID(s, OperationRead, s.GetPetOwner))
-	_mux.HandleFunc("GET /pets/{id}/friends", ReqIDParam(s, OperationList, s.ListPetFriends))
-	_mux.HandleFunc("GET /pets/{id}/followed-by", ReqIDParam(s, OperationList, s.ListPetFollowedBys))
-	_mux.HandleFunc("POST /pets", ReqParam(s, OperationCreate, s.CreatePet))
-	_mux.HandleFunc("PATCH /pets/{id}", ReqIDParam(s, OperationUpdate, s.UpdatePet))
-	_mux.HandleFunc("DELETE /pets/{id}", ReqID(s, OperationDelete, s.DeletePet))
-	_mux.HandleFunc("GET /posts", ReqParam(s, OperationList, s.ListPosts))
-	_mux.HandleFunc("GET /posts/{id}", ReqID(s, OperationRead, s.GetPost))
-	_mux.HandleFunc("GET /posts/{id}/author", ReqID(s, OperationRead, s.GetPostAuthor))
-	_mux.HandleFunc("POST /posts", ReqParam(s, OperationCreate, s.CreatePost))
-	_mux.HandleFunc("PATCH /posts/{id}", ReqIDParam(s, OperationUpdate, s.UpdatePost))
-	_mux.HandleFunc("DELETE /posts/{id}", ReqID(s, OperationDelete, s.DeletePost))
-	_mux.HandleFunc("GET /settings", ReqParam(s, OperationList, s.ListSettings))
-	_mux.HandleFunc("GET /settings/{id}", ReqID(s, OperationRead, s.GetSetting))
-	_mux.HandleFunc("GET /settings/{id}/admins", ReqIDParam(s, OperationList, s.ListSettingAdmins))
-	_mux.HandleFunc("PATCH /settings/{id}", ReqIDParam(s, OperationUpdate, s.UpdateSetting))
-	_mux.HandleFunc("GET /users", ReqParam(s, OperationList, s.ListUsers))
-	_mux.HandleFunc("GET /users/{id}", ReqID(s, OperationRead, s.GetUser))
-	_mux.HandleFunc("GET /users/{id}/pets", ReqIDParam(s, OperationList, s.ListUserPets))
-	_mux.HandleFunc("GET /users/{id}/followed-pets", ReqIDParam(s, OperationList, s.ListUserFollowedPets))
-	_mux.HandleFunc("GET /users/{id}/friends", ReqIDParam(s, OperationList, s.ListUserFriends))
-	_mux.HandleFunc("GET /users/{id}/posts", ReqIDParam(s, OperationList, s.ListUserPosts))
-	_mux.HandleFunc("GET /users/{id}/friendships", ReqIDParam(s, OperationList, s.ListUserFriendships))
-	_mux.HandleFunc("POST /users", ReqParam(s, OperationCreate, s.CreateUser))
-	_mux.HandleFunc("PATCH /users/{id}", ReqIDParam(s, OperationUpdate, s.UpdateUser))
-	_mux.HandleFunc("DELETE /users/{id}", ReqID(s, OperationDelete, s.DeleteUser))
+	_mux.HandleFunc("GET /categories", s.withAuthorizer(OperationList, "Category", nil, ReqExport(s, OperationList, s.ListCategories, s.ExportCategories, CategoryExportColumns)))
+	_mux.HandleFunc("GET /categories/{id}", s.withAuthorizer(OperationRead, "Category", nil, ReqIDParam(s, OperationRead, s.GetCategory)))
+	_mux.HandleFunc("GET /categories/{id}/pets", s.withAuthorizer(OperationList, "Category", nil, ReqIDParam(s, OperationList, s.ListCategoryPets)))
+	_mux.HandleFunc("POST /categories", s.withAuthorizer(OperationCreate, "Category", nil, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreateCategory))))
+	_mux.HandleFunc("PATCH /categories/{id}", s.withAuthorizer(OperationUpdate, "Category", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdateCategory))))
+	_mux.HandleFunc("DELETE /categories/{id}", s.withAuthorizer(OperationDelete, "Category", nil, ReqID(s, OperationDelete, s.DeleteCategory)))
+	_mux.HandleFunc("POST /categories/{id}/restore", s.withAuthorizer(OperationRestore, "Category", nil, s.withIdempotency(OperationRestore, ReqID(s, OperationRestore, s.RestoreCategory))))
+	_mux.HandleFunc("GET /categories/{id}/history", s.withAuthorizer(OperationHistory, "Category", nil, ReqIDParam(s, OperationHistory, s.ListCategoryHistory)))
+	_mux.HandleFunc("GET /categories/{id}/history/{version}", s.withAuthorizer(OperationHistoryRead, "Category", nil, ReqID(s, OperationHistoryRead, s.GetCategoryHistoryVersion)))
+	_mux.HandleFunc("POST /categories/{id}/history/{version}/restore", s.withAuthorizer(OperationHistoryRestore, "Category", nil, s.withIdempotency(OperationHistoryRestore, ReqID(s, OperationHistoryRestore, s.RestoreCategoryHistoryVersion))))
+	_mux.HandleFunc("GET /follows", s.withAuthorizer(OperationList, "Follows", nil, ReqParam(s, OperationList, s.ListFollows)))
+	_mux.HandleFunc("POST /follows", s.withAuthorizer(OperationCreate, "Follows", nil, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreateFollow))))
+	_mux.HandleFunc("GET /friendships", s.withAuthorizer(OperationList, "Friendship", nil, ReqExport(s, OperationList, s.ListFriendships, s.ExportFriendships, FriendshipExportColumns)))
+	_mux.HandleFunc("GET /friendships/{id}", s.withAuthorizer(OperationRead, "Friendship", nil, ReqIDParam(s, OperationRead, s.GetFriendship)))
+	_mux.HandleFunc("GET /friendships/{id}/user", s.withAuthorizer(OperationRead, "Friendship", nil, ReqIDParam(s, OperationRead, s.GetFriendshipUser)))
+	_mux.HandleFunc("GET /friendships/{id}/friend", s.withAuthorizer(OperationRead, "Friendship", nil, ReqIDParam(s, OperationRead, s.GetFriendshipFriend)))
+	_mux.HandleFunc("POST /friendships", s.withAuthorizer(OperationCreate, "Friendship", nil, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreateFriendship))))
+	_mux.HandleFunc("PATCH /friendships/{id}", s.withAuthorizer(OperationUpdate, "Friendship", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdateFriendship))))
+	_mux.HandleFunc("DELETE /friendships/{id}", s.withAuthorizer(OperationDelete, "Friendship", nil, ReqID(s, OperationDelete, s.DeleteFriendship)))
+	_mux.HandleFunc("GET /pets", s.withAuthorizer(OperationList, "Pet", nil, ReqExport(s, OperationList, s.ListPets, s.ExportPets, PetExportColumns)))
+	_mux.HandleFunc("GET /pets/aggregate", s.withAuthorizer(OperationAggregate, "Pet", nil, ReqParam(s, OperationAggregate, s.AggregatePets)))
+	_mux.HandleFunc("GET /pets/events", s.withAuthorizer(OperationEvents, "Pet", nil, ReqEvents(s, OperationEvents, "Pet", s.StreamPetEvents)))
+	_mux.HandleFunc("GET /pets/{id}", s.withAuthorizer(OperationRead, "Pet", nil, ReqIDParam(s, OperationRead, s.GetPet)))
+	_mux.HandleFunc("GET /pets/{id}/categories", s.withAuthorizer(OperationList, "Pet", nil, ReqIDParam(s, OperationList, s.ListPetCategories)))
+	_mux.HandleFunc("GET /pets/{id}/owner", s.withAuthorizer(OperationRead, "Pet", []string{"users:read"}, ReqIDParam(s, OperationRead, s.GetPetOwner)))
+	_mux.HandleFunc("GET /pets/{id}/friends", s.withAuthorizer(OperationList, "Pet", nil, ReqIDParam(s, OperationList, s.ListPetFriends)))
+	_mux.HandleFunc("GET /pets/{id}/followed-by", s.withAuthorizer(OperationList, "Pet", nil, ReqIDParam(s, OperationList, s.ListPetFollowedBys)))
+	_mux.HandleFunc("PUT /pets/{id}/categories/{edgeID}", s.withAuthorizer(OperationUpdate, "Pet", nil, ReqEdgeID(s, OperationUpdate, s.AddPetCategory)))
+	_mux.HandleFunc("DELETE /pets/{id}/categories/{edgeID}", s.withAuthorizer(OperationUpdate, "Pet", nil, ReqEdgeID(s, OperationUpdate, s.RemovePetCategory)))
+	_mux.HandleFunc("PUT /pets/{id}/owner/{edgeID}", s.withAuthorizer(OperationUpdate, "Pet", nil, ReqEdgeID(s, OperationUpdate, s.SetPetOwner)))
+	_mux.HandleFunc("DELETE /pets/{id}/owner", s.withAuthorizer(OperationUpdate, "Pet", nil, ReqID(s, OperationUpdate, s.ClearPetOwner)))
+	_mux.HandleFunc("POST /pets", s.withAuthorizer(OperationCreate, "Pet", []string{"pets:write"}, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreatePet))))
+	_mux.HandleFunc("POST /pets/bulk", s.withAuthorizer(OperationCreateBulk, "Pet", nil, s.withIdempotency(OperationCreateBulk, ReqParam(s, OperationCreateBulk, s.CreateBulkPets))))
+	_mux.HandleFunc("PATCH /pets/{id}", s.withAuthorizer(OperationUpdate, "Pet", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdatePet))))
+	_mux.HandleFunc("PUT /pets/{id}", s.withAuthorizer(OperationReplace, "Pet", nil, ReqIDParam(s, OperationReplace, s.ReplacePet)))
+	_mux.HandleFunc("PATCH /pets", s.withAuthorizer(OperationUpdateBulk, "Pet", nil, s.withIdempotency(OperationUpdateBulk, ReqParam(s, OperationUpdateBulk, s.UpdateBulkPets))))
+	_mux.HandleFunc("DELETE /pets", s.withAuthorizer(OperationDeleteBulk, "Pet", nil, ReqParam(s, OperationDeleteBulk, s.DeleteBulkPets)))
+	_mux.HandleFunc("DELETE /pets/{id}", s.withAuthorizer(OperationDelete, "Pet", nil, ReqID(s, OperationDelete, s.DeletePet)))
+	_mux.HandleFunc("GET /pets/{id}/history", s.withAuthorizer(OperationHistory, "Pet", nil, ReqIDParam(s, OperationHistory, s.ListPetHistory)))
+	_mux.HandleFunc("GET /pets/{id}/history/{version}", s.withAuthorizer(OperationHistoryRead, "Pet", nil, ReqID(s, OperationHistoryRead, s.GetPetHistoryVersion)))
+	_mux.HandleFunc("POST /pets/{id}/history/{version}/restore", s.withAuthorizer(OperationHistoryRestore, "Pet", nil, s.withIdempotency(OperationHistoryRestore, ReqID(s, OperationHistoryRestore, s.RestorePetHistoryVersion))))
+	_mux.HandleFunc("GET /posts", s.withRateLimit(OperationList, "GET /posts", RateLimit{Limit: 100, Period: time.Minute}, s.withAuthorizer(OperationList, "Post", nil, ReqExport(s, OperationList, s.ListPosts, s.ExportPosts, PostExportColumns))))
+	_mux.HandleFunc("GET /posts/{id}", s.withRateLimit(OperationRead, "GET /posts/{id}", RateLimit{Limit: 3, Period: time.Minute}, s.withAuthorizer(OperationRead, "Post", nil, ReqIDParam(s, OperationRead, s.GetPost))))
+	_mux.HandleFunc("GET /posts/{id}/author", s.withRateLimit(OperationRead, "GET /posts/{id}/author", RateLimit{Limit: 3, Period: time.Minute}, s.withAuthorizer(OperationRead, "Post", nil, ReqIDParam(s, OperationRead, s.GetPostAuthor))))
+	_mux.HandleFunc("POST /posts", s.withRateLimit(OperationCreate, "POST /posts", RateLimit{Limit: 100, Period: time.Minute}, s.withAuthorizer(OperationCreate, "Post", nil, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreatePost)))))
+	_mux.HandleFunc("PATCH /posts/{id}", s.withRateLimit(OperationUpdate, "PATCH /posts/{id}", RateLimit{Limit: 100, Period: time.Minute}, s.withAuthorizer(OperationUpdate, "Post", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdatePost)))))
+	_mux.HandleFunc("DELETE /posts/{id}", s.withRateLimit(OperationDelete, "DELETE /posts/{id}", RateLimit{Limit: 100, Period: time.Minute}, s.withAuthorizer(OperationDelete, "Post", nil, ReqID(s, OperationDelete, s.DeletePost))))
+	_mux.HandleFunc("GET /settings", s.withAuthorizer(OperationList, "Settings", nil, ReqExport(s, OperationList, s.ListSettings, s.ExportSettings, SettingExportColumns)))
+	_mux.HandleFunc("GET /settings/{id}", s.withAuthorizer(OperationRead, "Settings", nil, ReqIDParam(s, OperationRead, s.GetSetting)))
+	_mux.HandleFunc("GET /settings/{id}/admins", s.withAuthorizer(OperationList, "Settings", nil, ReqIDParam(s, OperationList, s.ListSettingAdmins)))
+	_mux.HandleFunc("PATCH /settings/{id}", s.withAuthorizer(OperationUpdate, "Settings", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdateSetting))))
+	_mux.HandleFunc("GET /users", s.withAuthorizer(OperationList, "User", nil, ReqExport(s, OperationList, s.ListUsers, s.ExportUsers, UserExportColumns)))
+	_mux.HandleFunc("GET /users/aggregate", s.withAuthorizer(OperationAggregate, "User", nil, ReqParam(s, OperationAggregate, s.AggregateUsers)))
+	_mux.HandleFunc("GET /users/{id}", s.withAuthorizer(OperationRead, "User", nil, ReqIDParam(s, OperationRead, s.GetUser)))
+	_mux.HandleFunc("GET /users/{id}/pets", s.withAuthorizer(OperationList, "User", nil, ReqIDParam(s, OperationList, s.ListUserPets)))
+	_mux.HandleFunc("GET /users/{id}/followed-pets", s.withAuthorizer(OperationList, "User", nil, ReqIDParam(s, OperationList, s.ListUserFollowedPets)))
+	_mux.HandleFunc("GET /users/{id}/friends", s.withAuthorizer(OperationList, "User", nil, ReqIDParam(s, OperationList, s.ListUserFriends)))
+	_mux.HandleFunc("GET /users/{id}/posts", s.withAuthorizer(OperationList, "User", nil, ReqIDParam(s, OperationList, s.ListUserPosts)))
+	_mux.HandleFunc("GET /users/{id}/friendships", s.withAuthorizer(OperationList, "User", nil, ReqIDParam(s, OperationList, s.ListUserFriendships)))
+	_mux.HandleFunc("PUT /users/{id}/pets/{edgeID}", s.withAuthorizer(OperationUpdate, "User", nil, ReqEdgeID(s, OperationUpdate, s.AddUserPet)))
+	_mux.HandleFunc("DELETE /users/{id}/pets/{edgeID}", s.withAuthorizer(OperationUpdate, "User", nil, ReqEdgeID(s, OperationUpdate, s.RemoveUserPet)))
+	_mux.HandleFunc("POST /users", s.withAuthorizer(OperationCreate, "User", nil, s.withIdempotency(OperationCreate, ReqParam(s, OperationCreate, s.CreateUser))))
+	_mux.HandleFunc("PUT /users/by-github-id/{githubID}", s.withAuthorizer(OperationUpsert, "User", nil, ReqUpsert(s, OperationUpsert, s.UpsertUser)))
+	_mux.HandleFunc("POST /users/import", s.withAuthorizer(OperationImport, "User", nil, s.withIdempotency(OperationImport, ReqImport(s, OperationImport, s.ImportUsers))))
+	_mux.HandleFunc("PATCH /users/{id}", s.withAuthorizer(OperationUpdate, "User", nil, s.withIdempotency(OperationUpdate, ReqIDParam(s, OperationUpdate, s.UpdateUser))))
+	_mux.HandleFunc("PUT /users/{id}", s.withAuthorizer(OperationReplace, "User", nil, ReqIDParam(s, OperationReplace, s.ReplaceUser)))
+	_mux.HandleFunc("DELETE /users/{id}", s.withAuthorizer(OperationDelete, "User", nil, ReqID(s, OperationDelete, s.DeleteUser)))
 
 	if !s.config.DisableSpecHandler {
 		_mux.HandleFunc("GET /openapi.json", s.Spec)
@@ -668,14 +1802,22 @@ func (s *Server) ListCategories(r *http.Request, p *ListCategoryParams) (*PagedR
 	return p.Exec(r.Context(), s.db.Category.Query())
 }
 
+// ExportCategories streams all Categories matching the parameters of "GET /categories"
+// when an export is requested.
+func (s *Server) ExportCategories(r *http.Request, p *ListCategoryParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.Category.Query(), func(e *ent.Category) error {
+		return w.Write(e)
+	})
+}
+
 // GetCategory maps to "GET /categories/{id}".
-func (s *Server) GetCategory(r *http.Request, categoryID int) (*ent.Category, error) {
-	return EagerLoadCategory(s.db.Category.Query().Where(category.ID(categoryID))).Only(r.Context())
+func (s *Server) GetCategory(r *http.Request, categoryID int, p *ReadCategoryParams) (*ent.Category, error) {
+	return p.Exec(r.Context(), s.db.Category.Query().Where(category.ID(categoryID)))
 }
 
 // ListCategoryPets maps to "GET /categories/{id}/pets".
 func (s *Server) ListCategoryPets(r *http.Request, categoryID int, p *ListPetParams) (*PagedResponse[ent.Pet], error) {
-	return p.Exec(r.Context(), s.db.Category.Query().Where(category.ID(categoryID)).QueryPets())
+	return p.Exec(r.Context(), s.db.Category.Query().Where(category.ID(categoryID), category.DeletedAtIsNil()).QueryPets())
 }
 
 // CreateCategory maps to "POST /categories".
@@ -685,12 +1827,78 @@ func (s *Server) CreateCategory(r *http.Request, p *CreateCategoryParams) (*ent.
 
 // UpdateCategory maps to "PATCH /categories/{id}".
 func (s *Server) UpdateCategory(r *http.Request, categoryID int, p *UpdateCategoryParams) (*ent.Category, error) {
-	return p.Exec(r.Context(), s.db.Category.UpdateOneID(categoryID), s.db.Category.Query())
+	if len(p.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value, which is read
+		// and updated within the same transaction.
+		return patchTx(r.Context(), s.db, func(_db *ent.Client) (*ent.Category, error) {
+			return s.updateCategory(r, _db, categoryID, p)
+		})
+	}
+	return s.updateCategory(r, s.db, categoryID, p)
+}
+
+// updateCategory updates a Category using the provided client (see [Server.UpdateCategory]).
+func (s *Server) updateCategory(r *http.Request, _db *ent.Client, categoryID int, p *UpdateCategoryParams) (*ent.Category, error) {
+	_builder := _db.Category.UpdateOneID(categoryID).Where(category.DeletedAtIsNil())
+	return p.Exec(r.Context(), _builder, _db.Category.Query())
 }
 
 // DeleteCategory maps to "DELETE /categories/{id}".
 func (s *Server) DeleteCategory(r *http.Request, categoryID int) (*struct{}, error) {
-	return nil, s.db.Category.DeleteOneID(categoryID).Exec(r.Context())
+	// Soft-delete the entity, which can be restored later.
+	_builder := s.db.Category.UpdateOneID(categoryID).
+		Where(category.DeletedAtIsNil()).
+		SetDeletedAt(time.Now())
+	return nil, _builder.Exec(r.Context())
+}
+
+// RestoreCategory maps to "POST /categories/{id}/restore". Restoring an
+// entity which isn't soft-deleted is a no-op.
+func (s *Server) RestoreCategory(r *http.Request, categoryID int) (*ent.Category, error) {
+	_builder := s.db.Category.UpdateOneID(categoryID).
+		Where(category.DeletedAtNotNil()).
+		ClearDeletedAt()
+	if err := _builder.Exec(r.Context()); err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	return eagerLoadCategory(s.db.Category.Query().Where(category.ID(categoryID)), nil, nil).Only(r.Context())
+}
+
+// ListCategoryHistory maps to "GET /categories/{id}/history". Versions are
+// returned newest first.
+func (s *Server) ListCategoryHistory(r *http.Request, categoryID int, p *HistoryParams) (*PagedResponse[HistoryEntry], error) {
+	return p.Exec(r.Context(), s.config.HistoryStore, "Category", fmt.Sprint(categoryID), CategoryPageConfig)
+}
+
+// GetCategoryHistoryVersion maps to "GET /categories/{id}/history/{version}".
+func (s *Server) GetCategoryHistoryVersion(r *http.Request, categoryID int) (*HistoryEntry, error) {
+	_version, err := resolveID[int](r, "version")
+	if err != nil {
+		return nil, err
+	}
+	return s.config.HistoryStore.Get(r.Context(), "Category", fmt.Sprint(categoryID), _version)
+}
+
+// RestoreCategoryHistoryVersion maps to "POST /categories/{id}/history/{version}/restore".
+// Restoring a version records a new version, and doesn't restore edges.
+func (s *Server) RestoreCategoryHistoryVersion(r *http.Request, categoryID int) (*ent.Category, error) {
+	_version, err := resolveID[int](r, "version")
+	if err != nil {
+		return nil, err
+	}
+	_entry, err := s.config.HistoryStore.Get(r.Context(), "Category", fmt.Sprint(categoryID), _version)
+	if err != nil {
+		return nil, err
+	}
+
+	_builder := s.db.Category.UpdateOneID(categoryID).Where(category.DeletedAtIsNil())
+	if err = restoreCategoryHistory(_builder, _entry.Entity); err != nil {
+		return nil, err
+	}
+	if err = _builder.Exec(r.Context()); err != nil {
+		return nil, err
+	}
+	return eagerLoadCategory(s.db.Category.Query().Where(category.ID(categoryID)), nil, nil).Only(r.Context())
 }
 
 // ListFollows maps to "GET /follows".
@@ -705,22 +1913,35 @@ func (s *Server) CreateFollow(r *http.Request, p *CreateFollowParams) (*ent.Foll
 
 // ListFriendships maps to "GET /friendships".
 func (s *Server) ListFriendships(r *http.Request, p *ListFriendshipParams) (*PagedResponse[ent.Friendship], error) {
+	if s.config.CountEstimator != nil {
+		p.estimate = func(ctx context.Context) (int, bool, error) {
+			return s.config.CountEstimator(ctx, friendship.Table)
+		}
+	}
 	return p.Exec(r.Context(), s.db.Friendship.Query())
 }
 
+// ExportFriendships streams all Friendships matching the parameters of "GET /friendships"
+// when an export is requested.
+func (s *Server) ExportFriendships(r *http.Request, p *ListFriendshipParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.Friendship.Query(), func(e *ent.Friendship) error {
+		return w.Write(e)
+	})
+}
+
 // GetFriendship maps to "GET /friendships/{id}".
-func (s *Server) GetFriendship(r *http.Request, friendshipID int) (*ent.Friendship, error) {
-	return EagerLoadFriendship(s.db.Friendship.Query().Where(friendship.ID(friendshipID))).Only(r.Context())
+func (s *Server) GetFriendship(r *http.Request, friendshipID int, p *ReadFriendshipParams) (*ent.Friendship, error) {
+	return p.Exec(r.Context(), s.db.Friendship.Query().Where(friendship.ID(friendshipID)))
 }
 
 // GetFriendshipUser maps to "GET /friendships/{id}/user".
-func (s *Server) GetFriendshipUser(r *http.Request, friendshipID int) (*ent.User, error) {
-	return EagerLoadUser(s.db.Friendship.Query().Where(friendship.ID(friendshipID)).QueryUser()).Only(r.Context())
+func (s *Server) GetFriendshipUser(r *http.Request, friendshipID int, p *ReadUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), s.db.Friendship.Query().Where(friendship.ID(friendshipID)).QueryUser())
 }
 
 // GetFriendshipFriend maps to "GET /friendships/{id}/friend".
-func (s *Server) GetFriendshipFriend(r *http.Request, friendshipID int) (*ent.User, error) {
-	return EagerLoadUser(s.db.Friendship.Query().Where(friendship.ID(friendshipID)).QueryFriend()).Only(r.Context())
+func (s *Server) GetFriendshipFriend(r *http.Request, friendshipID int, p *ReadUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), s.db.Friendship.Query().Where(friendship.ID(friendshipID)).QueryFriend())
 }
 
 // CreateFriendship maps to "POST /friendships".
@@ -743,9 +1964,29 @@ func (s *Server) ListPets(r *http.Request, p *ListPetParams) (*PagedResponse[ent
 	return p.Exec(r.Context(), s.db.Pet.Query())
 }
 
+// ExportPets streams all Pets matching the parameters of "GET /pets"
+// when an export is requested.
+func (s *Server) ExportPets(r *http.Request, p *ListPetParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.Pet.Query(), func(e *ent.Pet) error {
+		return w.Write(e)
+	})
+}
+
+// AggregatePets maps to "GET /pets/aggregate".
+func (s *Server) AggregatePets(r *http.Request, p *AggregatePetParams) (*[]*PetAggregate, error) {
+	_results, err := p.Exec(r.Context(), s.db.Pet.Query())
+	return &_results, err
+}
+
+// StreamPetEvents maps to "GET /pets/events", and is invoked for each
+// Pet event, returning the event to send, or nil if it should be skipped.
+func (s *Server) StreamPetEvents(r *http.Request, p *StreamPetEventsParams, _event *Event) (*Event, error) {
+	return p.Match(r.Context(), s.db.Pet.Query(), _event)
+}
+
 // GetPet maps to "GET /pets/{id}".
-func (s *Server) GetPet(r *http.Request, petID int) (*ent.Pet, error) {
-	return EagerLoadPet(s.db.Pet.Query().Where(pet.ID(petID))).Only(r.Context())
+func (s *Server) GetPet(r *http.Request, petID int, p *ReadPetParams) (*ent.Pet, error) {
+	return p.Exec(r.Context(), s.db.Pet.Query().Where(pet.ID(petID)))
 }
 
 // ListPetCategories maps to "GET /pets/{id}/categories".
@@ -754,8 +1995,8 @@ func (s *Server) ListPetCategories(r *http.Request, petID int, p *ListCategoryPa
 }
 
 // GetPetOwner maps to "GET /pets/{id}/owner".
-func (s *Server) GetPetOwner(r *http.Request, petID int) (*ent.User, error) {
-	return EagerLoadUser(s.db.Pet.Query().Where(pet.ID(petID)).QueryOwner()).Only(r.Context())
+func (s *Server) GetPetOwner(r *http.Request, petID int, p *ReadUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), s.db.Pet.Query().Where(pet.ID(petID)).QueryOwner())
 }
 
 // ListPetFriends maps to "GET /pets/{id}/friends".
@@ -768,34 +2009,259 @@ func (s *Server) ListPetFollowedBys(r *http.Request, petID int, p *ListUserParam
 	return p.Exec(r.Context(), s.db.Pet.Query().Where(pet.ID(petID)).QueryFollowedBy())
 }
 
+// AddPetCategory maps to "PUT /pets/{id}/categories/{edgeID}".
+func (s *Server) AddPetCategory(r *http.Request, petID int, categoryID int) (*struct{}, error) {
+	// Ensure the related entity exists, so a missing entity results in a not found error.
+	_, err := s.db.Category.Query().Where(category.ID(categoryID), category.DeletedAtIsNil()).OnlyID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	// Attaching an entity which is already attached is a no-op.
+	_exists, err := s.db.Pet.Query().Where(pet.ID(petID)).QueryCategories().Where(category.ID(categoryID)).Exist(r.Context())
+	if err == nil && _exists {
+		if _versions, _conditional := parseIfMatch[int](r); _conditional {
+			_exists, err = s.db.Pet.Query().Where(pet.ID(petID), pet.VersionIn(_versions...)).Exist(r.Context())
+			if err == nil && !_exists {
+				return nil, &ErrPreconditionFailed{Err: errors.New("entity has been modified since the provided If-Match version")}
+			}
+		}
+	}
+	if err != nil || _exists {
+		return nil, err
+	}
+	_builder := s.db.Pet.UpdateOneID(petID).AddVersion(1)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	if err := _builder.AddCategoryIDs(categoryID).Exec(r.Context()); err != nil {
+		if _conditional {
+			return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// RemovePetCategory maps to "DELETE /pets/{id}/categories/{edgeID}".
+func (s *Server) RemovePetCategory(r *http.Request, petID int, categoryID int) (*struct{}, error) {
+	_builder := s.db.Pet.UpdateOneID(petID).AddVersion(1)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	if err := _builder.RemoveCategoryIDs(categoryID).Exec(r.Context()); err != nil {
+		if _conditional {
+			return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// SetPetOwner maps to "PUT /pets/{id}/owner/{edgeID}".
+func (s *Server) SetPetOwner(r *http.Request, petID int, ownerID uuid.UUID) (*struct{}, error) {
+	// Ensure the related entity exists, so a missing entity results in a not found error.
+	_, err := s.db.User.Query().Where(user.ID(ownerID)).OnlyID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	_builder := s.db.Pet.UpdateOneID(petID).AddVersion(1)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	if err := _builder.SetOwnerID(ownerID).Exec(r.Context()); err != nil {
+		if _conditional {
+			return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ClearPetOwner maps to "DELETE /pets/{id}/owner".
+func (s *Server) ClearPetOwner(r *http.Request, petID int) (*struct{}, error) {
+	_builder := s.db.Pet.UpdateOneID(petID).AddVersion(1)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	if err := _builder.ClearOwner().Exec(r.Context()); err != nil {
+		if _conditional {
+			return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
 // CreatePet maps to "POST /pets".
 func (s *Server) CreatePet(r *http.Request, p *CreatePetParams) (*ent.Pet, error) {
-	return p.Exec(r.Context(), s.db.Pet.Create(), s.db.Pet.Query())
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	if err := s.authorizeNestedCreates(r, p.nestedCreates); err != nil {
+		return nil, err
+	}
+	return p.Exec(r.Context(), s.db)
+}
+
+// CreateBulkPets maps to "POST /pets/bulk".
+func (s *Server) CreateBulkPets(r *http.Request, p *CreatePetBulkParams) (*[]*ent.Pet, error) {
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	err := s.authorizeNestedCreates(r, func(_fn func(_entity string) error) error {
+		for _, _item := range p.Items {
+			if _item == nil {
+				continue
+			}
+			if err := _item.nestedCreates(_fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	_results, err := p.Exec(r.Context(), s.db)
+	return &_results, err
 }
 
 // UpdatePet maps to "PATCH /pets/{id}".
 func (s *Server) UpdatePet(r *http.Request, petID int, p *UpdatePetParams) (*ent.Pet, error) {
-	return p.Exec(r.Context(), s.db.Pet.UpdateOneID(petID), s.db.Pet.Query())
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	if len(p.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value, which is read
+		// and updated within the same transaction.
+		return patchTx(r.Context(), s.db, func(_db *ent.Client) (*ent.Pet, error) {
+			return s.updatePet(r, _db, petID, p)
+		})
+	}
+	return s.updatePet(r, s.db, petID, p)
+}
+
+// updatePet updates a Pet using the provided client (see [Server.UpdatePet]).
+func (s *Server) updatePet(r *http.Request, _db *ent.Client, petID int, p *UpdatePetParams) (*ent.Pet, error) {
+	_builder := _db.Pet.UpdateOneID(petID)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	_result, err := p.Exec(r.Context(), _builder, _db.Pet.Query())
+	if err != nil && _conditional {
+		return nil, preconditionFailed(r.Context(), err, _db.Pet.Query().Where(pet.ID(petID)).Exist)
+	}
+	return _result, err
+}
+
+// ReplacePet maps to "PUT /pets/{id}".
+func (s *Server) ReplacePet(r *http.Request, petID int, p *ReplacePetParams) (*ent.Pet, error) {
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	_builder := s.db.Pet.UpdateOneID(petID)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	_result, err := p.Exec(r.Context(), _builder, s.db.Pet.Query())
+	if err != nil && _conditional {
+		return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+	}
+	return _result, err
+}
+
+// UpdateBulkPets maps to "PATCH /pets".
+func (s *Server) UpdateBulkPets(r *http.Request, p *UpdatePetBulkParams) (*BulkResponse, error) {
+	// Request body is used for the update values, so filters are provided via query params.
+	if err := DefaultDecoder.Decode(&p.Filter, r.URL.Query()); err != nil {
+		return nil, &ErrBadRequest{Err: fmt.Errorf("error decoding query parameters: %w", err)}
+	}
+	return p.Exec(r.Context(), s.db.Pet.Update())
+}
+
+// DeleteBulkPets maps to "DELETE /pets".
+func (s *Server) DeleteBulkPets(r *http.Request, p *DeletePetBulkParams) (*BulkResponse, error) {
+	return p.Exec(r.Context(), s.db.Pet.Delete())
 }
 
 // DeletePet maps to "DELETE /pets/{id}".
 func (s *Server) DeletePet(r *http.Request, petID int) (*struct{}, error) {
-	return nil, s.db.Pet.DeleteOneID(petID).Exec(r.Context())
+	_builder := s.db.Pet.DeleteOneID(petID)
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	err := _builder.Exec(r.Context())
+	if err != nil && _conditional {
+		return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+	}
+	return nil, err
+}
+
+// ListPetHistory maps to "GET /pets/{id}/history". Versions are
+// returned newest first.
+func (s *Server) ListPetHistory(r *http.Request, petID int, p *HistoryParams) (*PagedResponse[HistoryEntry], error) {
+	return p.Exec(r.Context(), s.config.HistoryStore, "Pet", fmt.Sprint(petID), PetPageConfig)
+}
+
+// GetPetHistoryVersion maps to "GET /pets/{id}/history/{version}".
+func (s *Server) GetPetHistoryVersion(r *http.Request, petID int) (*HistoryEntry, error) {
+	_version, err := resolveID[int](r, "version")
+	if err != nil {
+		return nil, err
+	}
+	return s.config.HistoryStore.Get(r.Context(), "Pet", fmt.Sprint(petID), _version)
+}
+
+// RestorePetHistoryVersion maps to "POST /pets/{id}/history/{version}/restore".
+// Restoring a version records a new version, and doesn't restore edges.
+func (s *Server) RestorePetHistoryVersion(r *http.Request, petID int) (*ent.Pet, error) {
+	_version, err := resolveID[int](r, "version")
+	if err != nil {
+		return nil, err
+	}
+	_entry, err := s.config.HistoryStore.Get(r.Context(), "Pet", fmt.Sprint(petID), _version)
+	if err != nil {
+		return nil, err
+	}
+
+	_builder := s.db.Pet.UpdateOneID(petID)
+	if err = restorePetHistory(_builder, _entry.Entity); err != nil {
+		return nil, err
+	}
+	_versions, _conditional := parseIfMatch[int](r)
+	if _conditional {
+		_builder.Where(pet.VersionIn(_versions...))
+	}
+	_builder.AddVersion(1)
+	if err = _builder.Exec(r.Context()); err != nil {
+		if _conditional {
+			return nil, preconditionFailed(r.Context(), err, s.db.Pet.Query().Where(pet.ID(petID)).Exist)
+		}
+		return nil, err
+	}
+	return eagerLoadPet(s.db.Pet.Query().Where(pet.ID(petID)), nil, nil).Only(r.Context())
 }
 
 // ListPosts maps to "GET /posts".
-func (s *Server) ListPosts(r *http.Request, p *ListPostParams) (*PagedResponse[ent.Post], error) {
+func (s *Server) ListPosts(r *http.Request, p *ListPostParams) (*CursorResponse[ent.Post], error) {
 	return p.Exec(r.Context(), s.db.Post.Query())
 }
 
+// ExportPosts streams all Posts matching the parameters of "GET /posts"
+// when an export is requested.
+func (s *Server) ExportPosts(r *http.Request, p *ListPostParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.Post.Query(), func(e *ent.Post) error {
+		return w.Write(e)
+	})
+}
+
 // GetPost maps to "GET /posts/{id}".
-func (s *Server) GetPost(r *http.Request, postID int) (*ent.Post, error) {
-	return EagerLoadPost(s.db.Post.Query().Where(post.ID(postID))).Only(r.Context())
+func (s *Server) GetPost(r *http.Request, postID int, p *ReadPostParams) (*ent.Post, error) {
+	return p.Exec(r.Context(), s.db.Post.Query().Where(post.ID(postID)))
 }
 
 // GetPostAuthor maps to "GET /posts/{id}/author".
-func (s *Server) GetPostAuthor(r *http.Request, postID int) (*ent.User, error) {
-	return EagerLoadUser(s.db.Post.Query().Where(post.ID(postID)).QueryAuthor()).Only(r.Context())
+func (s *Server) GetPostAuthor(r *http.Request, postID int, p *ReadUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), s.db.Post.Query().Where(post.ID(postID)).QueryAuthor())
 }
 
 // CreatePost maps to "POST /posts".
@@ -818,9 +2284,17 @@ func (s *Server) ListSettings(r *http.Request, p *ListSettingParams) (*PagedResp
 	return p.Exec(r.Context(), s.db.Settings.Query())
 }
 
+// ExportSettings streams all Settings matching the parameters of "GET /settings"
+// when an export is requested.
+func (s *Server) ExportSettings(r *http.Request, p *ListSettingParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.Settings.Query(), func(e *ent.Settings) error {
+		return w.Write(e)
+	})
+}
+
 // GetSetting maps to "GET /settings/{id}".
-func (s *Server) GetSetting(r *http.Request, settingID int) (*ent.Settings, error) {
-	return EagerLoadSetting(s.db.Settings.Query().Where(settings.ID(settingID))).Only(r.Context())
+func (s *Server) GetSetting(r *http.Request, settingID int, p *ReadSettingParams) (*ent.Settings, error) {
+	return p.Exec(r.Context(), s.db.Settings.Query().Where(settings.ID(settingID)))
 }
 
 // ListSettingAdmins maps to "GET /settings/{id}/admins".
@@ -838,9 +2312,23 @@ func (s *Server) ListUsers(r *http.Request, p *ListUserParams) (*PagedResponse[e
 	return p.Exec(r.Context(), s.db.User.Query())
 }
 
+// ExportUsers streams all Users matching the parameters of "GET /users"
+// when an export is requested.
+func (s *Server) ExportUsers(r *http.Request, p *ListUserParams, w *ExportWriter) error {
+	return p.Export(r.Context(), s.db.User.Query(), func(e *ent.User) error {
+		return w.Write(e)
+	})
+}
+
+// AggregateUsers maps to "GET /users/aggregate".
+func (s *Server) AggregateUsers(r *http.Request, p *AggregateUserParams) (*[]*UserAggregate, error) {
+	_results, err := p.Exec(r.Context(), s.db.User.Query())
+	return &_results, err
+}
+
 // GetUser maps to "GET /users/{id}".
-func (s *Server) GetUser(r *http.Request, userID uuid.UUID) (*ent.User, error) {
-	return EagerLoadUser(s.db.User.Query().Where(user.ID(userID))).Only(r.Context())
+func (s *Server) GetUser(r *http.Request, userID uuid.UUID, p *ReadUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), s.db.User.Query().Where(user.ID(userID)))
 }
 
 // ListUserPets maps to "GET /users/{id}/pets".
@@ -859,7 +2347,7 @@ func (s *Server) ListUserFriends(r *http.Request, userID uuid.UUID, p *ListUserP
 }
 
 // ListUserPosts maps to "GET /users/{id}/posts".
-func (s *Server) ListUserPosts(r *http.Request, userID uuid.UUID, p *ListPostParams) (*PagedResponse[ent.Post], error) {
+func (s *Server) ListUserPosts(r *http.Request, userID uuid.UUID, p *ListPostParams) (*CursorResponse[ent.Post], error) {
 	return p.Exec(r.Context(), s.db.User.Query().Where(user.ID(userID)).QueryPosts())
 }
 
@@ -868,13 +2356,85 @@ func (s *Server) ListUserFriendships(r *http.Request, userID uuid.UUID, p *ListF
 	return p.Exec(r.Context(), s.db.User.Query().Where(user.ID(userID)).QueryFriendships())
 }
 
+// AddUserPet maps to "PUT /users/{id}/pets/{edgeID}".
+func (s *Server) AddUserPet(r *http.Request, userID uuid.UUID, petID int) (*struct{}, error) {
+	// Ensure the related entity exists, so a missing entity results in a not found error.
+	_, err := s.db.Pet.Query().Where(pet.ID(petID)).OnlyID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	// Attaching an entity which is already attached is a no-op.
+	_exists, err := s.db.User.Query().Where(user.ID(userID)).QueryPets().Where(pet.ID(petID)).Exist(r.Context())
+	if err != nil || _exists {
+		return nil, err
+	}
+	return nil, s.db.User.UpdateOneID(userID).AddPetIDs(petID).Exec(r.Context())
+}
+
+// RemoveUserPet maps to "DELETE /users/{id}/pets/{edgeID}".
+func (s *Server) RemoveUserPet(r *http.Request, userID uuid.UUID, petID int) (*struct{}, error) {
+	return nil, s.db.User.UpdateOneID(userID).RemovePetIDs(petID).Exec(r.Context())
+}
+
 // CreateUser maps to "POST /users".
 func (s *Server) CreateUser(r *http.Request, p *CreateUserParams) (*ent.User, error) {
-	return p.Exec(r.Context(), s.db.User.Create(), s.db.User.Query())
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	if err := s.authorizeNestedCreates(r, p.nestedCreates); err != nil {
+		return nil, err
+	}
+	return p.Exec(r.Context(), s.db)
+}
+
+// UpsertUser maps to "PUT /users/by-github-id/{githubID}".
+func (s *Server) UpsertUser(r *http.Request, p *CreateUserParams) (*ent.User, bool, error) {
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	_githubID, err := resolveID[int](r, "githubID")
+	if err != nil {
+		return nil, false, err
+	}
+	p.GithubID = &_githubID
+	return p.Upsert(r.Context(), s.db)
+}
+
+// ImportUsers maps to "POST /users/import".
+func (s *Server) ImportUsers(r *http.Request, p *ImportUserParams) (*ImportResponse[uuid.UUID], error) {
+	_rows, err := importRows(r, UserImportConfig)
+	if err != nil {
+		return nil, err
+	}
+	return p.exec(r.Context(), s.db, _rows, func(_items []*CreateUserParams) error {
+		return s.authorizeNestedCreates(r, func(_fn func(_entity string) error) error {
+			for _, _item := range _items {
+				if err := _item.nestedCreates(_fn); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
 }
 
 // UpdateUser maps to "PATCH /users/{id}".
 func (s *Server) UpdateUser(r *http.Request, userID uuid.UUID, p *UpdateUserParams) (*ent.User, error) {
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
+	if len(p.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value, which is read
+		// and updated within the same transaction.
+		return patchTx(r.Context(), s.db, func(_db *ent.Client) (*ent.User, error) {
+			return s.updateUser(r, _db, userID, p)
+		})
+	}
+	return s.updateUser(r, s.db, userID, p)
+}
+
+// updateUser updates a User using the provided client (see [Server.UpdateUser]).
+func (s *Server) updateUser(r *http.Request, _db *ent.Client, userID uuid.UUID, p *UpdateUserParams) (*ent.User, error) {
+	return p.Exec(r.Context(), _db.User.UpdateOneID(userID), _db.User.Query())
+}
+
+// ReplaceUser maps to "PUT /users/{id}".
+func (s *Server) ReplaceUser(r *http.Request, userID uuid.UUID, p *ReplaceUserParams) (*ent.User, error) {
+	p.Include = r.URL.Query()["include"] // Request body is used for all other params.
 	return p.Exec(r.Context(), s.db.User.UpdateOneID(userID), s.db.User.Query())
 }
 
@@ -66,6 +66,14 @@ func withFieldSelector(_field string, _order orderDirection) func(*sql.Selector)
 	return ent.Desc(_field)
 }
 
+// reverseOrder returns the opposite of the provided order direction.
+func reverseOrder(_order orderDirection) orderDirection {
+	if _order == orderAsc {
+		return orderDesc
+	}
+	return orderAsc
+}
+
 type SortConfig struct {
 	Fields       []string
 	DefaultField string
@@ -143,6 +151,7 @@ var (
 			"owner.name",
 			"owner.updated_at",
 			"random",
+			"relevance",
 		},
 		DefaultField: "name",
 		DefaultOrder: "asc",
@@ -158,6 +167,7 @@ var (
 			"created_at",
 			"id",
 			"random",
+			"relevance",
 			"updated_at",
 		},
 		DefaultField: "id",
@@ -4,6 +4,9 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	github "github.com/google/go-github/v81/github"
@@ -26,6 +29,8 @@ type UpdateCategoryParams struct {
 	Ints       Option[[]int]    `json:"ints,omitempty"`
 	AddPets    Option[[]int]    `json:"add_pets,omitempty"`
 	RemovePets Option[[]int]    `json:"remove_pets,omitempty"`
+
+	jsonPatches jsonFieldPatches
 }
 
 func (u *UpdateCategoryParams) ApplyInputs(_builder *ent.CategoryUpdateOne) *ent.CategoryUpdateOne {
@@ -53,15 +58,146 @@ func (u *UpdateCategoryParams) ApplyInputs(_builder *ent.CategoryUpdateOne) *ent
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdateCategoryParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	if v, ok := _values["strings"]; ok {
+		_patch, err := decodeJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if _, ok := _patch.(map[string]any); ok {
+			u.jsonPatches.add("strings", func(_doc any) (any, error) {
+				return mergePatch(_doc, _patch), nil
+			})
+			delete(_values, "strings")
+		}
+	}
+	if v, ok := _values["ints"]; ok {
+		_patch, err := decodeJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if _, ok := _patch.(map[string]any); ok {
+			u.jsonPatches.add("ints", func(_doc any) (any, error) {
+				return mergePatch(_doc, _patch), nil
+			})
+			delete(_values, "ints")
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdateCategoryParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		case "strings":
+			_patch, err := rebaseJSONPatch(_op, "strings")
+			if err != nil {
+				return err
+			}
+			u.jsonPatches.add("strings", _patch)
+			continue
+		case "ints":
+			_patch, err := rebaseJSONPatch(_op, "ints")
+			if err != nil {
+				return err
+			}
+			u.jsonPatches.add("ints", _patch)
+			continue
+		case "pets":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/pets/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddPets.Get()
+				u.AddPets.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemovePets.Get()
+				u.RemovePets.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
+//
+// If any JSON-typed fields are patched, the current entity is read (and locked) first, so
+// the builder and query should belong to a transaction (see [Server.UpdateCategory]).
 func (c *UpdateCategoryParams) Exec(ctx context.Context, _builder *ent.CategoryUpdateOne, _query *ent.CategoryQuery) (*ent.Category, error) {
+	if len(c.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value.
+		_id, _ := _builder.Mutation().ID()
+		_current, err := _query.Clone().Where(category.ID(_id), lockForUpdate).Only(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _patches, ok := c.jsonPatches["strings"]; ok {
+			_value, err := patchJSON(_current.Strings, _patches)
+			if err != nil {
+				return nil, &ErrBadRequest{Err: fmt.Errorf("patching field %q: %w", "strings", err)}
+			}
+			c.Strings.Set(_value)
+		}
+		if _patches, ok := c.jsonPatches["ints"]; ok {
+			_value, err := patchJSON(_current.Ints, _patches)
+			if err != nil {
+				return nil, &ErrBadRequest{Err: fmt.Errorf("patching field %q: %w", "ints", err)}
+			}
+			c.Ints.Set(_value)
+		}
+	}
 	_result, err := c.ApplyInputs(_builder).Save(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadCategory(_query.Where(category.ID(_result.ID))).Only(ctx)
+	return eagerLoadCategory(_query.Where(category.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // UpdateFriendshipParams defines parameters for updating a Friendship via a PATCH request.
@@ -85,6 +221,48 @@ func (u *UpdateFriendshipParams) ApplyInputs(_builder *ent.FriendshipUpdateOne)
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdateFriendshipParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdateFriendshipParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
@@ -93,11 +271,12 @@ func (c *UpdateFriendshipParams) Exec(ctx context.Context, _builder *ent.Friends
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadFriendship(_query.Where(friendship.ID(_result.ID))).Only(ctx)
+	return eagerLoadFriendship(_query.Where(friendship.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // UpdatePetParams defines parameters for updating a Pet via a PATCH request.
 type UpdatePetParams struct {
+	Included
 	Name      Option[string]   `json:"name"`
 	Nicknames Option[[]string] `json:"nicknames,omitempty"`
 	Age       Option[int]      `json:"age"`
@@ -118,6 +297,8 @@ type UpdatePetParams struct {
 	AddFollowedBy Option[[]uuid.UUID] `json:"add_followed_by,omitempty"`
 	// Users that this pet is followed by.
 	RemoveFollowedBy Option[[]uuid.UUID] `json:"remove_followed_by,omitempty"`
+
+	jsonPatches jsonFieldPatches
 }
 
 func (u *UpdatePetParams) ApplyInputs(_builder *ent.PetUpdateOne) *ent.PetUpdateOne {
@@ -166,18 +347,343 @@ func (u *UpdatePetParams) ApplyInputs(_builder *ent.PetUpdateOne) *ent.PetUpdate
 	if v, ok := u.RemoveFollowedBy.Get(); ok && v != nil {
 		_builder.RemoveFollowedByIDs(v...)
 	}
+	_builder.AddVersion(1)
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdatePetParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	if v, ok := _values["nicknames"]; ok {
+		_patch, err := decodeJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if _, ok := _patch.(map[string]any); ok {
+			u.jsonPatches.add("nicknames", func(_doc any) (any, error) {
+				return mergePatch(_doc, _patch), nil
+			})
+			delete(_values, "nicknames")
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdatePetParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		case "nicknames":
+			_patch, err := rebaseJSONPatch(_op, "nicknames")
+			if err != nil {
+				return err
+			}
+			u.jsonPatches.add("nicknames", _patch)
+			continue
+		case "categories":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/categories/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddCategories.Get()
+				u.AddCategories.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveCategories.Get()
+				u.RemoveCategories.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "friends":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/friends/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddFriends.Get()
+				u.AddFriends.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveFriends.Get()
+				u.RemoveFriends.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "followed_by":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/followed_by/-")
+				}
+				var _id uuid.UUID
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddFollowedBy.Get()
+				u.AddFollowedBy.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[uuid.UUID](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveFollowedBy.Get()
+				u.RemoveFollowedBy.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
+//
+// If any JSON-typed fields are patched, the current entity is read (and locked) first, so
+// the builder and query should belong to a transaction (see [Server.UpdatePet]).
 func (c *UpdatePetParams) Exec(ctx context.Context, _builder *ent.PetUpdateOne, _query *ent.PetQuery) (*ent.Pet, error) {
+	if err := c.Included.Validate(PetIncludeConfig); err != nil {
+		return nil, err
+	}
+	if len(c.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value.
+		_id, _ := _builder.Mutation().ID()
+		_current, err := _query.Clone().Where(pet.ID(_id), lockForUpdate).Only(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _patches, ok := c.jsonPatches["nicknames"]; ok {
+			_value, err := patchJSON(_current.Nicknames, _patches)
+			if err != nil {
+				return nil, &ErrBadRequest{Err: fmt.Errorf("patching field %q: %w", "nicknames", err)}
+			}
+			c.Nicknames.Set(_value)
+		}
+	}
 	_result, err := c.ApplyInputs(_builder).Save(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadPet(_query.Where(pet.ID(_result.ID))).Only(ctx)
+	return eagerLoadPet(_query.Where(pet.ID(_result.ID)), c.Included.Include, nil).Only(ctx)
+}
+
+// ReplacePetParams defines parameters for replacing a Pet via a PUT request.
+// Unlike [UpdatePetParams], optional fields which aren't provided are cleared (or reset
+// to their default), and non-unique edges are replaced entirely.
+type ReplacePetParams struct {
+	Included
+	Name      Option[string]   `json:"name"`
+	Nicknames Option[[]string] `json:"nicknames,omitempty"`
+	Age       Option[int]      `json:"age"`
+	Type      Option[pet.Type] `json:"type"`
+	// Categories that the pet belongs to.
+	Categories Option[[]int] `json:"categories,omitempty"`
+	// The user that owns the pet.
+	Owner Option[*uuid.UUID] `json:"owner,omitempty"`
+	// Pets that this pet is friends with.
+	Friends Option[[]int] `json:"friends,omitempty"`
+	// Users that this pet is followed by.
+	FollowedBy Option[[]uuid.UUID] `json:"followed_by,omitempty"`
+}
+
+// Validate ensures all required fields and edges have been provided.
+func (u *ReplacePetParams) Validate() error {
+	if !u.Name.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: name")}
+	}
+	if !u.Age.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: age")}
+	}
+	if !u.Type.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: type")}
+	}
+	return nil
+}
+
+func (u *ReplacePetParams) ApplyInputs(_builder *ent.PetUpdateOne) *ent.PetUpdateOne {
+	if v, ok := u.Name.Get(); ok {
+		_builder.SetName(v)
+	}
+	if v, ok := u.Nicknames.Get(); ok {
+		_builder.SetNicknames(v)
+	} else {
+		_builder.ClearNicknames()
+	}
+	if v, ok := u.Age.Get(); ok {
+		_builder.SetAge(v)
+	}
+	if v, ok := u.Type.Get(); ok {
+		_builder.SetType(v)
+	}
+
+	_builder.ClearCategories()
+	if v, ok := u.Categories.Get(); ok {
+		_builder.AddCategoryIDs(v...)
+	}
+	if v, ok := u.Owner.Get(); ok && v != nil {
+		_builder.SetOwnerID(*v)
+	} else {
+		_builder.ClearOwner()
+	}
+	_builder.ClearFriends()
+	if v, ok := u.Friends.Get(); ok {
+		_builder.AddFriendIDs(v...)
+	}
+	_builder.ClearFollowedBy()
+	if v, ok := u.FollowedBy.Get(); ok {
+		_builder.AddFollowedByIDs(v...)
+	}
+	_builder.AddVersion(1)
+	return _builder
+}
+
+// Exec wraps all logic (validating and mapping all provided values to the builder),
+// replaces the entity, and does another query (using provided query as base) to get
+// the entity, with all eager loaded edges.
+func (u *ReplacePetParams) Exec(ctx context.Context, _builder *ent.PetUpdateOne, _query *ent.PetQuery) (*ent.Pet, error) {
+	if err := u.Included.Validate(PetIncludeConfig); err != nil {
+		return nil, err
+	}
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	_result, err := u.ApplyInputs(_builder).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return eagerLoadPet(_query.Where(pet.ID(_result.ID)), u.Included.Include, nil).Only(ctx)
+}
+
+// UpdatePetBulkParams defines parameters for updating multiple Pets via a PATCH
+// request. The request body is the same as [UpdatePetParams], and entities are selected
+// using the provided filters.
+type UpdatePetBulkParams struct {
+	UpdatePetParams
+
+	// Filter selects which entities are updated, and is provided via query parameters.
+	Filter PetBulkFilter `json:"-" form:"-"`
+}
+
+func (u *UpdatePetBulkParams) ApplyInputs(_builder *ent.PetUpdate) *ent.PetUpdate {
+	if v, ok := u.Name.Get(); ok {
+		_builder.SetName(v)
+	}
+	if v, ok := u.Nicknames.Get(); ok {
+		_builder.SetNicknames(v)
+	}
+	if v, ok := u.Age.Get(); ok {
+		_builder.SetAge(v)
+	}
+	if v, ok := u.Type.Get(); ok {
+		_builder.SetType(v)
+	}
+
+	if v, ok := u.AddCategories.Get(); ok && v != nil {
+		_builder.AddCategoryIDs(v...)
+	}
+	if v, ok := u.RemoveCategories.Get(); ok && v != nil {
+		_builder.RemoveCategoryIDs(v...)
+	}
+	// If add_<edge> or remove_<edge> is provided, don't clear or use this field.
+	if v, ok := u.Categories.Get(); ok && !u.AddCategories.Present() && !u.RemoveCategories.Present() {
+		_builder.ClearCategories()
+		if v != nil {
+			_builder.AddCategoryIDs(v...)
+		}
+	}
+	if v, ok := u.Owner.Get(); ok {
+		if v != nil {
+			_builder.SetOwnerID(*v)
+		} else {
+			_builder.ClearOwner()
+		}
+	}
+	if v, ok := u.AddFriends.Get(); ok && v != nil {
+		_builder.AddFriendIDs(v...)
+	}
+	if v, ok := u.RemoveFriends.Get(); ok && v != nil {
+		_builder.RemoveFriendIDs(v...)
+	}
+	if v, ok := u.AddFollowedBy.Get(); ok && v != nil {
+		_builder.AddFollowedByIDs(v...)
+	}
+	if v, ok := u.RemoveFollowedBy.Get(); ok && v != nil {
+		_builder.RemoveFollowedByIDs(v...)
+	}
+	_builder.AddVersion(1)
+	return _builder
+}
+
+// Exec wraps all logic (filtering, and mapping all provided values to the builder), and
+// updates all matching entities, returning the number of affected entities.
+func (u *UpdatePetBulkParams) Exec(ctx context.Context, _builder *ent.PetUpdate) (*BulkResponse, error) {
+	if len(u.jsonPatches) > 0 {
+		return nil, &ErrBadRequest{Err: errors.New("JSON-typed fields cannot be merged or patched in bulk updates")}
+	}
+	_predicates, err := u.Filter.Predicates()
+	if err != nil {
+		return nil, err
+	}
+	_affected, err := u.ApplyInputs(_builder.Where(_predicates...)).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &BulkResponse{Affected: _affected}, nil
 }
 
 // UpdatePostParams defines parameters for updating a Post via a PATCH request.
@@ -201,6 +707,48 @@ func (u *UpdatePostParams) ApplyInputs(_builder *ent.PostUpdateOne) *ent.PostUpd
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdatePostParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdatePostParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
@@ -209,7 +757,7 @@ func (c *UpdatePostParams) Exec(ctx context.Context, _builder *ent.PostUpdateOne
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadPost(_query.Where(post.ID(_result.ID))).Only(ctx)
+	return eagerLoadPost(_query.Where(post.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // UpdateSettingParams defines parameters for updating a Setting via a PATCH request.
@@ -240,6 +788,74 @@ func (u *UpdateSettingParams) ApplyInputs(_builder *ent.SettingsUpdateOne) *ent.
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdateSettingParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdateSettingParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		case "admins":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/admins/-")
+				}
+				var _id uuid.UUID
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddAdmins.Get()
+				u.AddAdmins.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[uuid.UUID](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveAdmins.Get()
+				u.RemoveAdmins.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
@@ -248,11 +864,12 @@ func (c *UpdateSettingParams) Exec(ctx context.Context, _builder *ent.SettingsUp
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadSetting(_query.Where(settings.ID(_result.ID))).Only(ctx)
+	return eagerLoadSetting(_query.Where(settings.ID(_result.ID)), nil, nil).Only(ctx)
 }
 
 // UpdateUserParams defines parameters for updating a User via a PATCH request.
 type UpdateUserParams struct {
+	Included
 	// Name of the user.
 	Name Option[string] `json:"name"`
 	// Type of object being defined (user or system which is for internal usecases).
@@ -269,6 +886,8 @@ type UpdateUserParams struct {
 	PasswordHashed Option[string] `json:"password_hashed"`
 	// The github user raw JSON data.
 	GithubData Option[*github.User] `json:"github_data,omitempty"`
+	// ID of the user on GitHub, used as a natural key when syncing users.
+	GithubID Option[*int] `json:"github_id,omitempty"`
 	// Any data that is not defined in the schema.
 	AnyData             Option[*github.User]          `json:"any_data,omitempty"`
 	ProfileURL          Option[*schema.ExampleValuer] `json:"profile_url,omitempty"`
@@ -289,6 +908,8 @@ type UpdateUserParams struct {
 	RemovePosts       Option[[]int]       `json:"remove_posts,omitempty"`
 	AddFriendships    Option[[]int]       `json:"add_friendships,omitempty"`
 	RemoveFriendships Option[[]int]       `json:"remove_friendships,omitempty"`
+
+	jsonPatches jsonFieldPatches
 }
 
 func (u *UpdateUserParams) ApplyInputs(_builder *ent.UserUpdateOne) *ent.UserUpdateOne {
@@ -328,6 +949,13 @@ func (u *UpdateUserParams) ApplyInputs(_builder *ent.UserUpdateOne) *ent.UserUpd
 	if v, ok := u.GithubData.Get(); ok {
 		_builder.SetGithubData(v)
 	}
+	if v, ok := u.GithubID.Get(); ok {
+		if v != nil {
+			_builder.SetGithubID(*v)
+		} else {
+			_builder.ClearGithubID()
+		}
+	}
 	if v, ok := u.AnyData.Get(); ok {
 		_builder.SetAnyData(v)
 	}
@@ -375,13 +1003,419 @@ func (u *UpdateUserParams) ApplyInputs(_builder *ent.UserUpdateOne) *ent.UserUpd
 	return _builder
 }
 
+// DecodeMergePatch decodes a JSON Merge Patch (RFC 7396) request body. JSON-typed fields
+// are merged with their current value, rather than being replaced.
+func (u *UpdateUserParams) DecodeMergePatch(_data []byte) error {
+	var _values map[string]json.RawMessage
+	if err := json.Unmarshal(_data, &_values); err != nil {
+		return err
+	}
+	if v, ok := _values["github_data"]; ok {
+		_patch, err := decodeJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if _, ok := _patch.(map[string]any); ok {
+			u.jsonPatches.add("github_data", func(_doc any) (any, error) {
+				return mergePatch(_doc, _patch), nil
+			})
+			delete(_values, "github_data")
+		}
+	}
+	if v, ok := _values["any_data"]; ok {
+		_patch, err := decodeJSONValue(v)
+		if err != nil {
+			return err
+		}
+		if _, ok := _patch.(map[string]any); ok {
+			u.jsonPatches.add("any_data", func(_doc any) (any, error) {
+				return mergePatch(_doc, _patch), nil
+			})
+			delete(_values, "any_data")
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
+// DecodeJSONPatch decodes a JSON Patch (RFC 6902) request body. Operations on non-unique
+// edges (e.g. "add /<edge>/-" or "remove /<edge>/<id>") add or remove the referenced entities,
+// and operations within JSON-typed fields are applied to their current value.
+func (u *UpdateUserParams) DecodeJSONPatch(_ops []JSONPatchOperation) error {
+	_values := map[string]json.RawMessage{}
+	for _, _op := range _ops {
+		_path, err := splitJSONPointer(_op.Path)
+		if err != nil {
+			return err
+		}
+		if len(_path) == 0 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+
+		switch _path[0] {
+		case "github_data":
+			_patch, err := rebaseJSONPatch(_op, "github_data")
+			if err != nil {
+				return err
+			}
+			u.jsonPatches.add("github_data", _patch)
+			continue
+		case "any_data":
+			_patch, err := rebaseJSONPatch(_op, "any_data")
+			if err != nil {
+				return err
+			}
+			u.jsonPatches.add("any_data", _patch)
+			continue
+		case "pets":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/pets/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddPets.Get()
+				u.AddPets.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemovePets.Get()
+				u.RemovePets.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "followed_pets":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/followed_pets/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddFollowedPets.Get()
+				u.AddFollowedPets.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveFollowedPets.Get()
+				u.RemoveFollowedPets.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "friends":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/friends/-")
+				}
+				var _id uuid.UUID
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddFriends.Get()
+				u.AddFriends.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[uuid.UUID](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveFriends.Get()
+				u.RemoveFriends.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "posts":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/posts/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddPosts.Get()
+				u.AddPosts.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemovePosts.Get()
+				u.RemovePosts.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		case "friendships":
+			if len(_path) != 2 {
+				break
+			}
+			switch _op.Op {
+			case "add":
+				if _path[1] != "-" {
+					return fmt.Errorf("unsupported patch path %q, expected %q", _op.Path, "/friendships/-")
+				}
+				var _id int
+				if err = json.Unmarshal(_op.Value, &_id); err != nil {
+					return err
+				}
+				_ids, _ := u.AddFriendships.Get()
+				u.AddFriendships.Set(append(_ids, _id))
+			case "remove":
+				_id, err := decodePatchID[int](_path[1])
+				if err != nil {
+					return err
+				}
+				_ids, _ := u.RemoveFriendships.Get()
+				u.RemoveFriendships.Set(append(_ids, _id))
+			default:
+				return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+			}
+			continue
+		}
+
+		if len(_path) > 1 {
+			return fmt.Errorf("unsupported patch path %q", _op.Path)
+		}
+		switch _op.Op {
+		case "add", "replace":
+			_values[_path[0]] = _op.Value
+		case "remove":
+			_values[_path[0]] = json.RawMessage("null")
+		default:
+			return fmt.Errorf("unsupported patch operation %q for path %q", _op.Op, _op.Path)
+		}
+	}
+	return decodePatchValues(_values, u)
+}
+
 // Exec wraps all logic (mapping all provided values to the build), updates the entity,
 // and does another query (using provided query as base) to get the entity, with all eager
 // loaded edges.
+//
+// If any JSON-typed fields are patched, the current entity is read (and locked) first, so
+// the builder and query should belong to a transaction (see [Server.UpdateUser]).
 func (c *UpdateUserParams) Exec(ctx context.Context, _builder *ent.UserUpdateOne, _query *ent.UserQuery) (*ent.User, error) {
+	if err := c.Included.Validate(UserIncludeConfig); err != nil {
+		return nil, err
+	}
+	if len(c.jsonPatches) > 0 {
+		// Patched JSON-typed fields are applied to their current value.
+		_id, _ := _builder.Mutation().ID()
+		_current, err := _query.Clone().Where(user.ID(_id), lockForUpdate).Only(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _patches, ok := c.jsonPatches["github_data"]; ok {
+			_value, err := patchJSON(_current.GithubData, _patches)
+			if err != nil {
+				return nil, &ErrBadRequest{Err: fmt.Errorf("patching field %q: %w", "github_data", err)}
+			}
+			c.GithubData.Set(_value)
+		}
+		if _patches, ok := c.jsonPatches["any_data"]; ok {
+			_value, err := patchJSON(_current.AnyData, _patches)
+			if err != nil {
+				return nil, &ErrBadRequest{Err: fmt.Errorf("patching field %q: %w", "any_data", err)}
+			}
+			c.AnyData.Set(_value)
+		}
+	}
 	_result, err := c.ApplyInputs(_builder).Save(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return EagerLoadUser(_query.Where(user.ID(_result.ID))).Only(ctx)
+	return eagerLoadUser(_query.Where(user.ID(_result.ID)), c.Included.Include, nil).Only(ctx)
+}
+
+// ReplaceUserParams defines parameters for replacing a User via a PUT request.
+// Unlike [UpdateUserParams], optional fields which aren't provided are cleared (or reset
+// to their default), and non-unique edges are replaced entirely.
+type ReplaceUserParams struct {
+	Included
+	// Name of the user.
+	Name Option[string] `json:"name"`
+	// Type of object being defined (user or system which is for internal usecases).
+	Type Option[user.Type] `json:"type"`
+	// Full name if USER, otherwise null.
+	Description Option[*string] `json:"description,omitempty"`
+	// If the user is still in the source system.
+	Enabled Option[bool] `json:"enabled"`
+	// Email associated with the user. Note that not all users have an associated email address.
+	Email Option[*string] `json:"email,omitempty"`
+	// Avatar data for the user. This should generally only apply to the USER user type.
+	Avatar Option[*[]byte] `json:"avatar,omitempty"`
+	// Hashed password for the user, this shouldn't be readable in the spec anywhere.
+	PasswordHashed Option[string] `json:"password_hashed"`
+	// The github user raw JSON data.
+	GithubData Option[*github.User] `json:"github_data,omitempty"`
+	// ID of the user on GitHub, used as a natural key when syncing users.
+	GithubID Option[*int] `json:"github_id,omitempty"`
+	// Any data that is not defined in the schema.
+	AnyData             Option[*github.User]          `json:"any_data,omitempty"`
+	ProfileURL          Option[*schema.ExampleValuer] `json:"profile_url,omitempty"`
+	LastAuthenticatedAt Option[*time.Time]            `json:"last_authenticated_at,omitempty"`
+	// Pets owned by the user.
+	Pets Option[[]int] `json:"pets,omitempty"`
+	// Pets that the user is following.
+	FollowedPets Option[[]int] `json:"followed_pets,omitempty"`
+	// Friends of the user.
+	Friends     Option[[]uuid.UUID] `json:"friends,omitempty"`
+	Posts       Option[[]int]       `json:"posts,omitempty"`
+	Friendships Option[[]int]       `json:"friendships,omitempty"`
+}
+
+// Validate ensures all required fields and edges have been provided.
+func (u *ReplaceUserParams) Validate() error {
+	if !u.Name.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: name")}
+	}
+	if !u.Type.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: type")}
+	}
+	if !u.Enabled.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: enabled")}
+	}
+	if !u.PasswordHashed.Present() {
+		return &ErrBadRequest{Err: errors.New("missing required field: password_hashed")}
+	}
+	return nil
+}
+
+func (u *ReplaceUserParams) ApplyInputs(_builder *ent.UserUpdateOne) *ent.UserUpdateOne {
+	if v, ok := u.Name.Get(); ok {
+		_builder.SetName(v)
+	}
+	if v, ok := u.Type.Get(); ok {
+		_builder.SetType(v)
+	}
+	if v, ok := u.Description.Get(); ok {
+		if v != nil {
+			_builder.SetDescription(*v)
+		} else {
+			_builder.ClearDescription()
+		}
+	} else {
+		_builder.ClearDescription()
+	}
+	if v, ok := u.Enabled.Get(); ok {
+		_builder.SetEnabled(v)
+	}
+	if v, ok := u.Email.Get(); ok {
+		if v != nil {
+			_builder.SetEmail(*v)
+		} else {
+			_builder.ClearEmail()
+		}
+	} else {
+		_builder.ClearEmail()
+	}
+	if v, ok := u.Avatar.Get(); ok {
+		if v != nil {
+			_builder.SetAvatar(*v)
+		} else {
+			_builder.ClearAvatar()
+		}
+	} else {
+		_builder.ClearAvatar()
+	}
+	if v, ok := u.PasswordHashed.Get(); ok {
+		_builder.SetPasswordHashed(v)
+	}
+	if v, ok := u.GithubData.Get(); ok {
+		_builder.SetGithubData(v)
+	} else {
+		_builder.ClearGithubData()
+	}
+	if v, ok := u.GithubID.Get(); ok {
+		if v != nil {
+			_builder.SetGithubID(*v)
+		} else {
+			_builder.ClearGithubID()
+		}
+	} else {
+		_builder.ClearGithubID()
+	}
+	if v, ok := u.AnyData.Get(); ok {
+		_builder.SetAnyData(v)
+	} else {
+		_builder.ClearAnyData()
+	}
+	if v, ok := u.ProfileURL.Get(); ok {
+		_builder.SetProfileURL(v)
+	} else {
+		_builder.SetProfileURL(user.DefaultProfileURL)
+	}
+	if v, ok := u.LastAuthenticatedAt.Get(); ok {
+		if v != nil {
+			_builder.SetLastAuthenticatedAt(*v)
+		} else {
+			_builder.ClearLastAuthenticatedAt()
+		}
+	} else {
+		_builder.ClearLastAuthenticatedAt()
+	}
+
+	_builder.ClearPets()
+	if v, ok := u.Pets.Get(); ok {
+		_builder.AddPetIDs(v...)
+	}
+	_builder.ClearFollowedPets()
+	if v, ok := u.FollowedPets.Get(); ok {
+		_builder.AddFollowedPetIDs(v...)
+	}
+	_builder.ClearFriends()
+	if v, ok := u.Friends.Get(); ok {
+		_builder.AddFriendIDs(v...)
+	}
+	_builder.ClearPosts()
+	if v, ok := u.Posts.Get(); ok {
+		_builder.AddPostIDs(v...)
+	}
+	_builder.ClearFriendships()
+	if v, ok := u.Friendships.Get(); ok {
+		_builder.AddFriendshipIDs(v...)
+	}
+	return _builder
+}
+
+// Exec wraps all logic (validating and mapping all provided values to the builder),
+// replaces the entity, and does another query (using provided query as base) to get
+// the entity, with all eager loaded edges.
+func (u *ReplaceUserParams) Exec(ctx context.Context, _builder *ent.UserUpdateOne, _query *ent.UserQuery) (*ent.User, error) {
+	if err := u.Included.Validate(UserIncludeConfig); err != nil {
+		return nil, err
+	}
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	_result, err := u.ApplyInputs(_builder).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return eagerLoadUser(_query.Where(user.ID(_result.ID)), u.Included.Include, nil).Only(ctx)
 }
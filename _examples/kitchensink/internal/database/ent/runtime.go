@@ -9,10 +9,14 @@ import (
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/category"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/follows"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/friendship"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/history"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/idempotencykey"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/pet"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/post"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/settings"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/user"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/webhookdelivery"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/webhooksubscription"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/schema"
 )
 
@@ -51,6 +55,66 @@ func init() {
 	friendshipDescCreatedAt := friendshipFields[0].Descriptor()
 	// friendship.DefaultCreatedAt holds the default value on creation for the created_at field.
 	friendship.DefaultCreatedAt = friendshipDescCreatedAt.Default.(func() time.Time)
+	historyMixin := schema.History{}.Mixin()
+	historyMixinFields0 := historyMixin[0].Fields()
+	_ = historyMixinFields0
+	historyFields := schema.History{}.Fields()
+	_ = historyFields
+	// historyDescEntityType is the schema descriptor for entity_type field.
+	historyDescEntityType := historyMixinFields0[0].Descriptor()
+	// history.EntityTypeValidator is a validator for the "entity_type" field. It is called by the builders before save.
+	history.EntityTypeValidator = historyDescEntityType.Validators[0].(func(string) error)
+	// historyDescEntityID is the schema descriptor for entity_id field.
+	historyDescEntityID := historyMixinFields0[1].Descriptor()
+	// history.EntityIDValidator is a validator for the "entity_id" field. It is called by the builders before save.
+	history.EntityIDValidator = historyDescEntityID.Validators[0].(func(string) error)
+	// historyDescVersion is the schema descriptor for version field.
+	historyDescVersion := historyMixinFields0[2].Descriptor()
+	// history.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	history.VersionValidator = historyDescVersion.Validators[0].(func(int) error)
+	// historyDescActor is the schema descriptor for actor field.
+	historyDescActor := historyMixinFields0[4].Descriptor()
+	// history.DefaultActor holds the default value on creation for the actor field.
+	history.DefaultActor = historyDescActor.Default.(string)
+	// historyDescRequestID is the schema descriptor for request_id field.
+	historyDescRequestID := historyMixinFields0[5].Descriptor()
+	// history.DefaultRequestID holds the default value on creation for the request_id field.
+	history.DefaultRequestID = historyDescRequestID.Default.(string)
+	// historyDescCreatedAt is the schema descriptor for created_at field.
+	historyDescCreatedAt := historyMixinFields0[7].Descriptor()
+	// history.DefaultCreatedAt holds the default value on creation for the created_at field.
+	history.DefaultCreatedAt = historyDescCreatedAt.Default.(func() time.Time)
+	idempotencykeyMixin := schema.IdempotencyKey{}.Mixin()
+	idempotencykeyMixinFields0 := idempotencykeyMixin[0].Fields()
+	_ = idempotencykeyMixinFields0
+	idempotencykeyFields := schema.IdempotencyKey{}.Fields()
+	_ = idempotencykeyFields
+	// idempotencykeyDescKey is the schema descriptor for key field.
+	idempotencykeyDescKey := idempotencykeyMixinFields0[0].Descriptor()
+	// idempotencykey.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	idempotencykey.KeyValidator = func() func(string) error {
+		validators := idempotencykeyDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// idempotencykeyDescStatusCode is the schema descriptor for status_code field.
+	idempotencykeyDescStatusCode := idempotencykeyMixinFields0[2].Descriptor()
+	// idempotencykey.DefaultStatusCode holds the default value on creation for the status_code field.
+	idempotencykey.DefaultStatusCode = idempotencykeyDescStatusCode.Default.(int)
+	// idempotencykeyDescCreatedAt is the schema descriptor for created_at field.
+	idempotencykeyDescCreatedAt := idempotencykeyMixinFields0[5].Descriptor()
+	// idempotencykey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	idempotencykey.DefaultCreatedAt = idempotencykeyDescCreatedAt.Default.(func() time.Time)
 	petFields := schema.Pet{}.Fields()
 	_ = petFields
 	// petDescAge is the schema descriptor for age field.
@@ -71,6 +135,10 @@ func init() {
 			return nil
 		}
 	}()
+	// petDescVersion is the schema descriptor for version field.
+	petDescVersion := petFields[5].Descriptor()
+	// pet.DefaultVersion holds the default value on creation for the version field.
+	pet.DefaultVersion = petDescVersion.Default.(int)
 	postMixin := schema.Post{}.Mixin()
 	postMixinFields0 := postMixin[0].Fields()
 	_ = postMixinFields0
@@ -219,11 +287,81 @@ func init() {
 	// user.PasswordHashedValidator is a validator for the "password_hashed" field. It is called by the builders before save.
 	user.PasswordHashedValidator = userDescPasswordHashed.Validators[0].(func(string) error)
 	// userDescProfileURL is the schema descriptor for profile_url field.
-	userDescProfileURL := userFields[10].Descriptor()
+	userDescProfileURL := userFields[11].Descriptor()
 	// user.DefaultProfileURL holds the default value on creation for the profile_url field.
 	user.DefaultProfileURL = userDescProfileURL.Default.(*schema.ExampleValuer)
 	// userDescID is the schema descriptor for id field.
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
 	user.DefaultID = userDescID.Default.(func() uuid.UUID)
+	webhookdeliveryMixin := schema.WebhookDelivery{}.Mixin()
+	webhookdeliveryMixinFields0 := webhookdeliveryMixin[0].Fields()
+	_ = webhookdeliveryMixinFields0
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescKey is the schema descriptor for key field.
+	webhookdeliveryDescKey := webhookdeliveryMixinFields0[0].Descriptor()
+	// webhookdelivery.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	webhookdelivery.KeyValidator = func() func(string) error {
+		validators := webhookdeliveryDescKey.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(key string) error {
+			for _, fn := range fns {
+				if err := fn(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhookdeliveryDescStatus is the schema descriptor for status field.
+	webhookdeliveryDescStatus := webhookdeliveryMixinFields0[4].Descriptor()
+	// webhookdelivery.DefaultStatus holds the default value on creation for the status field.
+	webhookdelivery.DefaultStatus = webhookdeliveryDescStatus.Default.(string)
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryMixinFields0[5].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdeliveryDescLastError is the schema descriptor for last_error field.
+	webhookdeliveryDescLastError := webhookdeliveryMixinFields0[6].Descriptor()
+	// webhookdelivery.DefaultLastError holds the default value on creation for the last_error field.
+	webhookdelivery.DefaultLastError = webhookdeliveryDescLastError.Default.(string)
+	// webhookdeliveryDescLastStatusCode is the schema descriptor for last_status_code field.
+	webhookdeliveryDescLastStatusCode := webhookdeliveryMixinFields0[7].Descriptor()
+	// webhookdelivery.DefaultLastStatusCode holds the default value on creation for the last_status_code field.
+	webhookdelivery.DefaultLastStatusCode = webhookdeliveryDescLastStatusCode.Default.(int)
+	// webhookdeliveryDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	webhookdeliveryDescNextAttemptAt := webhookdeliveryMixinFields0[8].Descriptor()
+	// webhookdelivery.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	webhookdelivery.DefaultNextAttemptAt = webhookdeliveryDescNextAttemptAt.Default.(func() time.Time)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryMixinFields0[9].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	// webhookdeliveryDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookdeliveryDescUpdatedAt := webhookdeliveryMixinFields0[10].Descriptor()
+	// webhookdelivery.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookdelivery.DefaultUpdatedAt = webhookdeliveryDescUpdatedAt.Default.(func() time.Time)
+	// webhookdelivery.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookdelivery.UpdateDefaultUpdatedAt = webhookdeliveryDescUpdatedAt.UpdateDefault.(func() time.Time)
+	webhooksubscriptionMixin := schema.WebhookSubscription{}.Mixin()
+	webhooksubscriptionMixinFields0 := webhooksubscriptionMixin[0].Fields()
+	_ = webhooksubscriptionMixinFields0
+	webhooksubscriptionFields := schema.WebhookSubscription{}.Fields()
+	_ = webhooksubscriptionFields
+	// webhooksubscriptionDescURL is the schema descriptor for url field.
+	webhooksubscriptionDescURL := webhooksubscriptionMixinFields0[0].Descriptor()
+	// webhooksubscription.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhooksubscription.URLValidator = webhooksubscriptionDescURL.Validators[0].(func(string) error)
+	// webhooksubscriptionDescSecret is the schema descriptor for secret field.
+	webhooksubscriptionDescSecret := webhooksubscriptionMixinFields0[1].Descriptor()
+	// webhooksubscription.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhooksubscription.SecretValidator = webhooksubscriptionDescSecret.Validators[0].(func(string) error)
+	// webhooksubscriptionDescEnabled is the schema descriptor for enabled field.
+	webhooksubscriptionDescEnabled := webhooksubscriptionMixinFields0[3].Descriptor()
+	// webhooksubscription.DefaultEnabled holds the default value on creation for the enabled field.
+	webhooksubscription.DefaultEnabled = webhooksubscriptionDescEnabled.Default.(bool)
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ type SettingsCreate struct {
 	config
 	mutation *SettingsMutation
 	hooks    []Hook
+	conflict []sql.ConflictOption
 }
 
 // SetCreatedAt sets the "created_at" field.
@@ -163,6 +165,7 @@ func (_c *SettingsCreate) createSpec() (*Settings, *sqlgraph.CreateSpec) {
 		_node = &Settings{config: _c.config}
 		_spec = sqlgraph.NewCreateSpec(settings.Table, sqlgraph.NewFieldSpec(settings.FieldID, field.TypeInt))
 	)
+	_spec.OnConflict = _c.conflict
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(settings.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -194,11 +197,204 @@ func (_c *SettingsCreate) createSpec() (*Settings, *sqlgraph.CreateSpec) {
 	return _node, _spec
 }
 
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Settings.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SettingsUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *SettingsCreate) OnConflict(opts ...sql.ConflictOption) *SettingsUpsertOne {
+	_c.conflict = opts
+	return &SettingsUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *SettingsCreate) OnConflictColumns(columns ...string) *SettingsUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &SettingsUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// SettingsUpsertOne is the builder for "upsert"-ing
+	//  one Settings node.
+	SettingsUpsertOne struct {
+		create *SettingsCreate
+	}
+
+	// SettingsUpsert is the "OnConflict" setter.
+	SettingsUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SettingsUpsert) SetUpdatedAt(v time.Time) *SettingsUpsert {
+	u.Set(settings.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SettingsUpsert) UpdateUpdatedAt() *SettingsUpsert {
+	u.SetExcluded(settings.FieldUpdatedAt)
+	return u
+}
+
+// SetGlobalBanner sets the "global_banner" field.
+func (u *SettingsUpsert) SetGlobalBanner(v string) *SettingsUpsert {
+	u.Set(settings.FieldGlobalBanner, v)
+	return u
+}
+
+// UpdateGlobalBanner sets the "global_banner" field to the value that was provided on create.
+func (u *SettingsUpsert) UpdateGlobalBanner() *SettingsUpsert {
+	u.SetExcluded(settings.FieldGlobalBanner)
+	return u
+}
+
+// ClearGlobalBanner clears the value of the "global_banner" field.
+func (u *SettingsUpsert) ClearGlobalBanner() *SettingsUpsert {
+	u.SetNull(settings.FieldGlobalBanner)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SettingsUpsertOne) UpdateNewValues() *SettingsUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(settings.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *SettingsUpsertOne) Ignore() *SettingsUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SettingsUpsertOne) DoNothing() *SettingsUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SettingsCreate.OnConflict
+// documentation for more info.
+func (u *SettingsUpsertOne) Update(set func(*SettingsUpsert)) *SettingsUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SettingsUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SettingsUpsertOne) SetUpdatedAt(v time.Time) *SettingsUpsertOne {
+	return u.Update(func(s *SettingsUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SettingsUpsertOne) UpdateUpdatedAt() *SettingsUpsertOne {
+	return u.Update(func(s *SettingsUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetGlobalBanner sets the "global_banner" field.
+func (u *SettingsUpsertOne) SetGlobalBanner(v string) *SettingsUpsertOne {
+	return u.Update(func(s *SettingsUpsert) {
+		s.SetGlobalBanner(v)
+	})
+}
+
+// UpdateGlobalBanner sets the "global_banner" field to the value that was provided on create.
+func (u *SettingsUpsertOne) UpdateGlobalBanner() *SettingsUpsertOne {
+	return u.Update(func(s *SettingsUpsert) {
+		s.UpdateGlobalBanner()
+	})
+}
+
+// ClearGlobalBanner clears the value of the "global_banner" field.
+func (u *SettingsUpsertOne) ClearGlobalBanner() *SettingsUpsertOne {
+	return u.Update(func(s *SettingsUpsert) {
+		s.ClearGlobalBanner()
+	})
+}
+
+// Exec executes the query.
+func (u *SettingsUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SettingsCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SettingsUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *SettingsUpsertOne) ID(ctx context.Context) (id int, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *SettingsUpsertOne) IDX(ctx context.Context) int {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 // SettingsCreateBulk is the builder for creating many Settings entities in bulk.
 type SettingsCreateBulk struct {
 	config
 	err      error
 	builders []*SettingsCreate
+	conflict []sql.ConflictOption
 }
 
 // Save creates the Settings entities in the database.
@@ -228,6 +424,7 @@ func (_c *SettingsCreateBulk) Save(ctx context.Context) ([]*Settings, error) {
 					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
 				} else {
 					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
 					// Invoke the actual operation on the latest mutation in the chain.
 					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
 						if sqlgraph.IsConstraintError(err) {
@@ -281,3 +478,149 @@ func (_c *SettingsCreateBulk) ExecX(ctx context.Context) {
 		panic(err)
 	}
 }
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Settings.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.SettingsUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *SettingsCreateBulk) OnConflict(opts ...sql.ConflictOption) *SettingsUpsertBulk {
+	_c.conflict = opts
+	return &SettingsUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *SettingsCreateBulk) OnConflictColumns(columns ...string) *SettingsUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &SettingsUpsertBulk{
+		create: _c,
+	}
+}
+
+// SettingsUpsertBulk is the builder for "upsert"-ing
+// a bulk of Settings nodes.
+type SettingsUpsertBulk struct {
+	create *SettingsCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *SettingsUpsertBulk) UpdateNewValues() *SettingsUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(settings.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Settings.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *SettingsUpsertBulk) Ignore() *SettingsUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *SettingsUpsertBulk) DoNothing() *SettingsUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the SettingsCreateBulk.OnConflict
+// documentation for more info.
+func (u *SettingsUpsertBulk) Update(set func(*SettingsUpsert)) *SettingsUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&SettingsUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *SettingsUpsertBulk) SetUpdatedAt(v time.Time) *SettingsUpsertBulk {
+	return u.Update(func(s *SettingsUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *SettingsUpsertBulk) UpdateUpdatedAt() *SettingsUpsertBulk {
+	return u.Update(func(s *SettingsUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetGlobalBanner sets the "global_banner" field.
+func (u *SettingsUpsertBulk) SetGlobalBanner(v string) *SettingsUpsertBulk {
+	return u.Update(func(s *SettingsUpsert) {
+		s.SetGlobalBanner(v)
+	})
+}
+
+// UpdateGlobalBanner sets the "global_banner" field to the value that was provided on create.
+func (u *SettingsUpsertBulk) UpdateGlobalBanner() *SettingsUpsertBulk {
+	return u.Update(func(s *SettingsUpsert) {
+		s.UpdateGlobalBanner()
+	})
+}
+
+// ClearGlobalBanner clears the value of the "global_banner" field.
+func (u *SettingsUpsertBulk) ClearGlobalBanner() *SettingsUpsertBulk {
+	return u.Update(func(s *SettingsUpsert) {
+		s.ClearGlobalBanner()
+	})
+}
+
+// Exec executes the query.
+func (u *SettingsUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the SettingsCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for SettingsCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *SettingsUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
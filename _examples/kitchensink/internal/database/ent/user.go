@@ -41,6 +41,8 @@ type User struct {
 	PasswordHashed string `json:"-"`
 	// The github user raw JSON data.
 	GithubData *github.User `json:"github_data"`
+	// ID of the user on GitHub, used as a natural key when syncing users.
+	GithubID *int `json:"github_id"`
 	// Any data that is not defined in the schema.
 	AnyData *github.User `json:"any_data"`
 	// ProfileURL holds the value of the "profile_url" field.
@@ -138,6 +140,8 @@ func (*User) scanValues(columns []string) ([]any, error) {
 			values[i] = new(schema.ExampleValuer)
 		case user.FieldEnabled:
 			values[i] = new(sql.NullBool)
+		case user.FieldGithubID:
+			values[i] = new(sql.NullInt64)
 		case user.FieldName, user.FieldType, user.FieldDescription, user.FieldEmail, user.FieldPasswordHashed:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt, user.FieldLastAuthenticatedAt:
@@ -231,6 +235,13 @@ func (_m *User) assignValues(columns []string, values []any) error {
 					return fmt.Errorf("unmarshal field github_data: %w", err)
 				}
 			}
+		case user.FieldGithubID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field github_id", values[i])
+			} else if value.Valid {
+				_m.GithubID = new(int)
+				*_m.GithubID = int(value.Int64)
+			}
 		case user.FieldAnyData:
 			if value, ok := values[i].(*[]byte); !ok {
 				return fmt.Errorf("unexpected type %T for field any_data", values[i])
@@ -360,6 +371,11 @@ func (_m *User) String() string {
 	builder.WriteString("github_data=")
 	builder.WriteString(fmt.Sprintf("%v", _m.GithubData))
 	builder.WriteString(", ")
+	if v := _m.GithubID; v != nil {
+		builder.WriteString("github_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("any_data=")
 	builder.WriteString(fmt.Sprintf("%v", _m.AnyData))
 	builder.WriteString(", ")
@@ -37,6 +37,8 @@ const (
 	FieldPasswordHashed = "password_hashed"
 	// FieldGithubData holds the string denoting the github_data field in the database.
 	FieldGithubData = "github_data"
+	// FieldGithubID holds the string denoting the github_id field in the database.
+	FieldGithubID = "github_id"
 	// FieldAnyData holds the string denoting the any_data field in the database.
 	FieldAnyData = "any_data"
 	// FieldProfileURL holds the string denoting the profile_url field in the database.
@@ -107,6 +109,7 @@ var Columns = []string{
 	FieldAvatar,
 	FieldPasswordHashed,
 	FieldGithubData,
+	FieldGithubID,
 	FieldAnyData,
 	FieldProfileURL,
 	FieldLastAuthenticatedAt,
@@ -239,6 +242,11 @@ func ByPasswordHashed(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPasswordHashed, opts...).ToFunc()
 }
 
+// ByGithubID orders the results by the github_id field.
+func ByGithubID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldGithubID, opts...).ToFunc()
+}
+
 // ByProfileURL orders the results by the profile_url field.
 func ByProfileURL(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldProfileURL, opts...).ToFunc()
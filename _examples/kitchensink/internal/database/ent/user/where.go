@@ -97,6 +97,11 @@ func PasswordHashed(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldPasswordHashed, v))
 }
 
+// GithubID applies equality check predicate on the "github_id" field. It's identical to GithubIDEQ.
+func GithubID(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldGithubID, v))
+}
+
 // ProfileURL applies equality check predicate on the "profile_url" field. It's identical to ProfileURLEQ.
 func ProfileURL(v *schema.ExampleValuer) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldProfileURL, v))
@@ -557,6 +562,56 @@ func GithubDataNotNil() predicate.User {
 	return predicate.User(sql.FieldNotNull(FieldGithubData))
 }
 
+// GithubIDEQ applies the EQ predicate on the "github_id" field.
+func GithubIDEQ(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldGithubID, v))
+}
+
+// GithubIDNEQ applies the NEQ predicate on the "github_id" field.
+func GithubIDNEQ(v int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldGithubID, v))
+}
+
+// GithubIDIn applies the In predicate on the "github_id" field.
+func GithubIDIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldGithubID, vs...))
+}
+
+// GithubIDNotIn applies the NotIn predicate on the "github_id" field.
+func GithubIDNotIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldGithubID, vs...))
+}
+
+// GithubIDGT applies the GT predicate on the "github_id" field.
+func GithubIDGT(v int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldGithubID, v))
+}
+
+// GithubIDGTE applies the GTE predicate on the "github_id" field.
+func GithubIDGTE(v int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldGithubID, v))
+}
+
+// GithubIDLT applies the LT predicate on the "github_id" field.
+func GithubIDLT(v int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldGithubID, v))
+}
+
+// GithubIDLTE applies the LTE predicate on the "github_id" field.
+func GithubIDLTE(v int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldGithubID, v))
+}
+
+// GithubIDIsNil applies the IsNil predicate on the "github_id" field.
+func GithubIDIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldGithubID))
+}
+
+// GithubIDNotNil applies the NotNil predicate on the "github_id" field.
+func GithubIDNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldGithubID))
+}
+
 // AnyDataIsNil applies the IsNil predicate on the "any_data" field.
 func AnyDataIsNil() predicate.User {
 	return predicate.User(sql.FieldIsNull(FieldAnyData))
@@ -160,6 +160,33 @@ func (_u *UserUpdate) ClearGithubData() *UserUpdate {
 	return _u
 }
 
+// SetGithubID sets the "github_id" field.
+func (_u *UserUpdate) SetGithubID(v int) *UserUpdate {
+	_u.mutation.ResetGithubID()
+	_u.mutation.SetGithubID(v)
+	return _u
+}
+
+// SetNillableGithubID sets the "github_id" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableGithubID(v *int) *UserUpdate {
+	if v != nil {
+		_u.SetGithubID(*v)
+	}
+	return _u
+}
+
+// AddGithubID adds value to the "github_id" field.
+func (_u *UserUpdate) AddGithubID(v int) *UserUpdate {
+	_u.mutation.AddGithubID(v)
+	return _u
+}
+
+// ClearGithubID clears the value of the "github_id" field.
+func (_u *UserUpdate) ClearGithubID() *UserUpdate {
+	_u.mutation.ClearGithubID()
+	return _u
+}
+
 // SetAnyData sets the "any_data" field.
 func (_u *UserUpdate) SetAnyData(v *github.User) *UserUpdate {
 	_u.mutation.SetAnyData(v)
@@ -506,6 +533,15 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.GithubDataCleared() {
 		_spec.ClearField(user.FieldGithubData, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.GithubID(); ok {
+		_spec.SetField(user.FieldGithubID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedGithubID(); ok {
+		_spec.AddField(user.FieldGithubID, field.TypeInt, value)
+	}
+	if _u.mutation.GithubIDCleared() {
+		_spec.ClearField(user.FieldGithubID, field.TypeInt)
+	}
 	if value, ok := _u.mutation.AnyData(); ok {
 		_spec.SetField(user.FieldAnyData, field.TypeJSON, value)
 	}
@@ -919,6 +955,33 @@ func (_u *UserUpdateOne) ClearGithubData() *UserUpdateOne {
 	return _u
 }
 
+// SetGithubID sets the "github_id" field.
+func (_u *UserUpdateOne) SetGithubID(v int) *UserUpdateOne {
+	_u.mutation.ResetGithubID()
+	_u.mutation.SetGithubID(v)
+	return _u
+}
+
+// SetNillableGithubID sets the "github_id" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableGithubID(v *int) *UserUpdateOne {
+	if v != nil {
+		_u.SetGithubID(*v)
+	}
+	return _u
+}
+
+// AddGithubID adds value to the "github_id" field.
+func (_u *UserUpdateOne) AddGithubID(v int) *UserUpdateOne {
+	_u.mutation.AddGithubID(v)
+	return _u
+}
+
+// ClearGithubID clears the value of the "github_id" field.
+func (_u *UserUpdateOne) ClearGithubID() *UserUpdateOne {
+	_u.mutation.ClearGithubID()
+	return _u
+}
+
 // SetAnyData sets the "any_data" field.
 func (_u *UserUpdateOne) SetAnyData(v *github.User) *UserUpdateOne {
 	_u.mutation.SetAnyData(v)
@@ -1295,6 +1358,15 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	if _u.mutation.GithubDataCleared() {
 		_spec.ClearField(user.FieldGithubData, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.GithubID(); ok {
+		_spec.SetField(user.FieldGithubID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedGithubID(); ok {
+		_spec.AddField(user.FieldGithubID, field.TypeInt, value)
+	}
+	if _u.mutation.GithubIDCleared() {
+		_spec.ClearField(user.FieldGithubID, field.TypeInt)
+	}
 	if value, ok := _u.mutation.AnyData(); ok {
 		_spec.SetField(user.FieldAnyData, field.TypeJSON, value)
 	}
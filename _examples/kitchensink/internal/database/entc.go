@@ -21,7 +21,9 @@ func main() {
 		WithTesting:           true,
 		StrictMutate:          true,
 		ListNotFound:          true,
+		ListExport:            true,
 		DefaultFilterID:       true,
+		IdempotencyKey:        true,
 		GlobalRequestHeaders:  entrest.RequestIDHeader,
 		GlobalResponseHeaders: entrest.RateLimitHeaders,
 	})
@@ -37,6 +39,7 @@ func main() {
 			Package: "github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent",
 			Features: []gen.Feature{
 				gen.FeaturePrivacy,
+				gen.FeatureUpsert,
 			},
 		},
 		entc.Extensions(ex),
@@ -43,6 +43,11 @@ func (Category) Fields() []ent.Field {
 			),
 		field.Ints("ints").
 			Optional(),
+		field.Time("deleted_at").
+			Optional().
+			Nillable().
+			Comment("Time the category was deleted, if it has been deleted.").
+			Annotations(entrest.WithReadOnly(true)),
 	}
 }
 
@@ -59,5 +64,8 @@ func (Category) Edges() []ent.Edge {
 }
 
 func (Category) Annotations() []schema.Annotation {
-	return []schema.Annotation{}
+	return []schema.Annotation{
+		entrest.WithSoftDelete("deleted_at"),
+		entrest.WithHistory(true),
+	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
@@ -56,3 +57,9 @@ func (Friendship) Edges() []ent.Edge {
 			),
 	}
 }
+
+func (Friendship) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entrest.WithCountStrategy(entrest.CountEstimated),
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"github.com/lrstanley/entrest"
+)
+
+// History stores the history of entities with history enabled, when using
+// rest.NewEntHistoryStore.
+type History struct {
+	ent.Schema
+}
+
+func (History) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		entrest.HistoryMixin{},
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"github.com/lrstanley/entrest"
+)
+
+// IdempotencyKey stores the responses of requests which include an "Idempotency-Key"
+// header, when using rest.NewEntIdempotencyStore.
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+func (IdempotencyKey) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		entrest.IdempotencyStoreMixin{},
+	}
+}
@@ -24,6 +24,7 @@ func (AuditableTimestamp) Fields() []ent.Field {
 				entrest.WithReadOnly(true),
 				entrest.WithSortable(true),
 				entrest.WithFilter(entrest.FilterGroupLength),
+				entrest.WithAggregate(entrest.AggregateGroupBy),
 			).
 			Comment("Time in which the resource was initially created."),
 		field.Time("updated_at").
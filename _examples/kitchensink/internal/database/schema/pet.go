@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/lrstanley/entrest"
+	"github.com/ogen-go/ogen"
 )
 
 type Pet struct {
@@ -25,6 +26,7 @@ func (Pet) Fields() []ent.Field {
 				entrest.WithExample("Kuro"),
 				entrest.WithSortable(true),
 				entrest.WithFilter(entrest.FilterGroupEqual|entrest.FilterGroupArray),
+				entrest.WithSearch(true),
 			),
 		field.JSON("nicknames", []string{}).
 			Optional().
@@ -37,6 +39,7 @@ func (Pet) Fields() []ent.Field {
 				entrest.WithExample(2),
 				entrest.WithSortable(true),
 				entrest.WithFilter(entrest.FilterGroupEqualExact|entrest.FilterGroupArray|entrest.FilterGroupLength),
+				entrest.WithAggregate(entrest.AggregateGroupBy|entrest.AggregateGroupNumeric),
 			),
 		field.Enum("type").
 			NamedValues(
@@ -51,7 +54,14 @@ func (Pet) Fields() []ent.Field {
 			entrest.WithExample("DOG"),
 			entrest.WithSortable(true),
 			entrest.WithFilter(entrest.FilterGroupEqualExact|entrest.FilterGroupArray),
+			entrest.WithAggregate(entrest.AggregateGroupBy),
 		),
+		field.Int("version").
+			Default(1).
+			Annotations(
+				entrest.WithReadOnly(true),
+			).
+			Comment("Version of the pet, incremented on every update."),
 	}
 }
 
@@ -70,6 +80,7 @@ func (Pet) Edges() []ent.Edge {
 				entrest.WithEagerLoad(true),
 				entrest.WithFilter(entrest.FilterEdge),
 				entrest.WithEdgeUpdateBulk(true),
+				entrest.WithEdgeMutation(true),
 			),
 		edge.From("owner", User.Type).
 			Ref("pets").
@@ -78,11 +89,16 @@ func (Pet) Edges() []ent.Edge {
 			Annotations(
 				entrest.WithEagerLoad(true),
 				entrest.WithFilter(entrest.FilterEdge),
+				entrest.WithEdgeMutation(true),
+				entrest.WithNestedCreate(true),
+				entrest.WithSecurity(entrest.OperationRead, ogen.SecurityRequirement{"OAuth2": {}}),
+				entrest.WithScopes(entrest.OperationRead, "users:read"),
 			),
 		edge.To("friends", Pet.Type).
 			Comment("Pets that this pet is friends with.").
 			Annotations(
 				entrest.WithFilter(entrest.FilterEdge),
+				entrest.WithIncludable(true),
 			),
 		edge.From("followed_by", User.Type).
 			Ref("followed_pets").
@@ -99,5 +115,23 @@ func (Pet) Annotations() []schema.Annotation {
 	return []schema.Annotation{
 		entrest.WithDefaultSort("name"),
 		entrest.WithDefaultOrder(entrest.OrderAsc),
+		entrest.WithVersionField("version"),
+		entrest.WithWebhooks(true),
+		entrest.WithHistory(true),
+		entrest.WithSecurity(entrest.OperationCreate, ogen.SecurityRequirement{"OAuth2": {}}),
+		entrest.WithScopes(entrest.OperationCreate, "pets:write"),
+		entrest.WithIncludeOperations(
+			entrest.OperationCreate,
+			entrest.OperationRead,
+			entrest.OperationUpdate,
+			entrest.OperationDelete,
+			entrest.OperationList,
+			entrest.OperationReplace,
+			entrest.OperationCreateBulk,
+			entrest.OperationUpdateBulk,
+			entrest.OperationDeleteBulk,
+			entrest.OperationAggregate,
+			entrest.OperationEvents,
+		),
 	}
 }
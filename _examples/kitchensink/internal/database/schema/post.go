@@ -5,6 +5,8 @@
 package schema
 
 import (
+	"time"
+
 	"entgo.io/ent"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
@@ -45,5 +47,11 @@ func (Post) Edges() []ent.Edge {
 }
 
 func (Post) Annotations() []schema.Annotation {
-	return []schema.Annotation{}
+	return []schema.Annotation{
+		entrest.WithPaginationMode(entrest.PaginationCursor),
+		entrest.WithSearch(true),
+		entrest.WithSearchTable("post_search"),
+		entrest.WithRateLimit(100, time.Minute),
+		entrest.WithOperationRateLimit(entrest.OperationRead, 3, time.Minute),
+	}
 }
@@ -51,5 +51,6 @@ func (Settings) Annotations() []schema.Annotation {
 	return []schema.Annotation{
 		entrest.WithExcludeOperations(entrest.OperationCreate, entrest.OperationDelete),
 		entrest.WithDescription("Settings contains the global settings for the platform. Generally only one should ever be returned."),
+		entrest.WithCountStrategy(entrest.CountNone),
 	}
 }
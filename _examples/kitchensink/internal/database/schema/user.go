@@ -101,6 +101,11 @@ func (User) Fields() []ent.Field {
 				entrest.WithSchema(entrest.SchemaObjectAny),
 			).
 			Comment("The github user raw JSON data."),
+		field.Int("github_id").
+			Optional().
+			Nillable().
+			Unique().
+			Comment("ID of the user on GitHub, used as a natural key when syncing users."),
 		field.JSON("any_data", &github.User{}).
 			Optional().
 			Annotations(
@@ -131,6 +136,8 @@ func (User) Edges() []ent.Edge {
 				entrest.WithEagerLoad(true),
 				entrest.WithEagerLoadLimit(-1),
 				entrest.WithFilter(entrest.FilterEdge),
+				entrest.WithEdgeMutation(true),
+				entrest.WithNestedCreate(true),
 				entsql.OnDelete(entsql.SetNull),
 			),
 		edge.To("followed_pets", Pet.Type).
@@ -147,7 +154,10 @@ func (User) Edges() []ent.Edge {
 				entrest.WithFilter(entrest.FilterEdge),
 				entsql.OnDelete(entsql.Cascade),
 			),
-		edge.To("posts", Post.Type).Annotations(),
+		edge.To("posts", Post.Type).Annotations(
+			entrest.WithIncludable(true),
+			entrest.WithNestedCreate(true),
+		),
 	}
 }
 
@@ -156,6 +166,9 @@ func (User) Annotations() []schema.Annotation {
 		entrest.WithDefaultSort("name"),
 		entrest.WithDefaultOrder(entrest.OrderAsc),
 		entrest.WithAllowClientIDs(true),
+		entrest.WithUpsert("github_id"),
+		entrest.WithWebhooks(true),
+		entrest.WithIncludeOperations(append(entrest.BaseOperations, entrest.OperationReplace, entrest.OperationAggregate, entrest.OperationImport)...),
 	}
 }
 
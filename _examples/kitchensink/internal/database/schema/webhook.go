@@ -0,0 +1,33 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"github.com/lrstanley/entrest"
+)
+
+// WebhookDelivery stores webhook deliveries, when using rest.NewEntWebhookOutbox.
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+func (WebhookDelivery) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		entrest.WebhookOutboxMixin{},
+	}
+}
+
+// WebhookSubscription stores webhook subscriptions, when using
+// rest.NewEntWebhookSubscriptionStore.
+type WebhookSubscription struct {
+	ent.Schema
+}
+
+func (WebhookSubscription) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		entrest.WebhookSubscriptionMixin{},
+	}
+}
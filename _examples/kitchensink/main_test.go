@@ -1,24 +1,40 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/enttest"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/friendship"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/migrate"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/pet"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/rest"
 	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/user"
+	"github.com/lrstanley/entrest/_examples/kitchensink/internal/database/ent/webhookdelivery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"modernc.org/sqlite"
@@ -26,8 +42,22 @@ import (
 
 var sqlRegister sync.Once
 
+const (
+	testDSN = "file:ent?mode=memory&_pragma=foreign_keys(1)"
+
+	// testTimeDSN stores times in a lexically sortable format which SQLite can parse, for
+	// tests which compare time fields in queries (e.g. cursors sorted by time fields), or
+	// group them into time buckets.
+	testTimeDSN = testDSN + "&_time_format=sqlite"
+)
+
 func newClient(t *testing.T) *ent.Client {
 	t.Helper()
+	return newClientDSN(t, testDSN)
+}
+
+func newClientDSN(t *testing.T, dsn string) *ent.Client {
+	t.Helper()
 
 	sqlRegister.Do(func() {
 		sql.Register("sqlite3", &sqlite.Driver{})
@@ -43,14 +73,19 @@ func newClient(t *testing.T) *ent.Client {
 		),
 	}
 
-	db := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_pragma=foreign_keys(1)", opts...)
+	db := enttest.Open(t, "sqlite3", dsn, opts...)
 	return db
 }
 
 func newRestServer(t *testing.T, cfg *rest.ServerConfig) (ctx context.Context, db *ent.Client, s *enttest.TestServer) {
+	t.Helper()
+	return newRestServerDSN(t, testDSN, cfg)
+}
+
+func newRestServerDSN(t *testing.T, dsn string, cfg *rest.ServerConfig) (ctx context.Context, db *ent.Client, s *enttest.TestServer) {
 	t.Helper()
 	ctx = context.Background()
-	db = newClient(t)
+	db = newClientDSN(t, dsn)
 	s = enttest.NewServer(t, db, cfg)
 	return ctx, db, s
 }
@@ -90,6 +125,14 @@ func newCategory(db *ent.Client) *ent.CategoryCreate {
 		SetReadonly(gofakeit.UUID())
 }
 
+func newPost(db *ent.Client, author *ent.User) *ent.PostCreate {
+	return db.Post.Create().
+		SetTitle(gofakeit.Sentence(5)).
+		SetSlug(gofakeit.UUID()).
+		SetBody(gofakeit.Paragraph(1, 3, 10, " ")).
+		SetAuthor(author)
+}
+
 func TestHandler_Get(t *testing.T) {
 	t.Parallel()
 
@@ -467,6 +510,353 @@ func TestHandler_Create(t *testing.T) {
 	assert.Equal(t, user1.ID, pet1.Edges.Owner.ID)
 }
 
+func TestHandler_CreateBulk(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+
+	newData := func() map[string]any {
+		return map[string]any{
+			"name":  gofakeit.PetName(),
+			"age":   gofakeit.Number(1, 15),
+			"type":  pet.TypeDog,
+			"owner": user1.ID,
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		data := []map[string]any{newData(), newData(), newData()}
+
+		resp := enttest.Request[[]*ent.Pet](ctx, s, http.MethodPost, "/pets/bulk", data).Must(t)
+
+		require.Equal(t, http.StatusCreated, resp.Data.Code)
+		require.Len(t, *resp.Value, len(data))
+
+		// Results should be in the same order as provided, with eager-loaded edges.
+		for i, p := range *resp.Value {
+			assert.Equal(t, data[i]["name"], p.Name)
+			require.NotNil(t, p.Edges.Owner)
+			assert.Equal(t, user1.ID, p.Edges.Owner.ID)
+		}
+	})
+
+	t.Run("invalid-item", func(t *testing.T) {
+		count := db.Pet.Query().CountX(ctx)
+
+		invalid := newData()
+		invalid["age"] = 100 // Fails the max validator.
+
+		resp := enttest.Request[map[string]any](
+			ctx, s,
+			http.MethodPost,
+			"/pets/bulk",
+			[]map[string]any{newData(), invalid, newData()},
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+		assert.Contains(t, resp.Error.Error, "item 1:")
+
+		// Nothing should have been created.
+		assert.Equal(t, count, db.Pet.Query().CountX(ctx))
+	})
+
+	t.Run("invalid-decode", func(t *testing.T) {
+		invalid := newData()
+		invalid["unknown"] = true // Strict mutate is enabled.
+
+		resp := enttest.Request[map[string]any](
+			ctx, s,
+			http.MethodPost,
+			"/pets/bulk",
+			[]map[string]any{newData(), newData(), invalid},
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+		assert.Contains(t, resp.Error.Error, "item 2:")
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPost, "/pets/bulk", []map[string]any{})
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		data := make([]map[string]any, 101)
+		for i := range data {
+			data[i] = newData()
+		}
+
+		resp = enttest.Request[map[string]any](ctx, s, http.MethodPost, "/pets/bulk", data)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	})
+}
+
+func TestHandler_NestedCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	pet1 := newPet(db).SaveX(ctx)
+
+	newUserData := func() map[string]any {
+		first := gofakeit.FirstName()
+		return map[string]any{
+			"name":            first,
+			"email":           first + "." + gofakeit.UUID() + "@example.com",
+			"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
+		}
+	}
+
+	newPetData := func() map[string]any {
+		return map[string]any{
+			"name": gofakeit.PetName(),
+			"age":  gofakeit.Number(1, 15),
+			"type": pet.TypeCat,
+		}
+	}
+
+	t.Run("ids-and-nested", func(t *testing.T) {
+		data := newUserData()
+		data["pets"] = []any{pet1.ID, newPetData(), newPetData()}
+		data["posts"] = []any{map[string]any{
+			"title": "A nested post title",
+			"slug":  "a-nested-post",
+			"body":  "Created alongside the user.",
+		}}
+
+		resp := enttest.Request[ent.User](ctx, s, http.MethodPost, "/users", data).Must(t)
+		require.Equal(t, http.StatusCreated, resp.Data.Code)
+
+		// Pets are eager-loaded, so they should be in the response.
+		assert.Len(t, resp.Value.Edges.Pets, 3)
+		assert.Equal(t, 3, db.User.Query().Where(user.ID(resp.Value.ID)).QueryPets().CountX(ctx))
+
+		post1 := db.User.Query().Where(user.ID(resp.Value.ID)).QueryPosts().OnlyX(ctx)
+		assert.Equal(t, "a-nested-post", post1.Slug)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		data := newPetData()
+		data["owner"] = newUserData()
+
+		resp := enttest.Request[ent.Pet](ctx, s, http.MethodPost, "/pets", data).Must(t)
+		require.Equal(t, http.StatusCreated, resp.Data.Code)
+		require.NotNil(t, resp.Value.Edges.Owner)
+		assert.Equal(t, data["owner"].(map[string]any)["name"], resp.Value.Edges.Owner.Name)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		users := db.User.Query().CountX(ctx)
+		pets := db.Pet.Query().CountX(ctx)
+
+		invalid := newPetData()
+		invalid["age"] = 100 // Fails the max validator.
+
+		data := newUserData()
+		data["pets"] = []any{newPetData(), invalid}
+
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPost, "/users", data)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		// Nothing should have been created.
+		assert.Equal(t, users, db.User.Query().CountX(ctx))
+		assert.Equal(t, pets, db.Pet.Query().CountX(ctx))
+	})
+
+	t.Run("invalid-decode", func(t *testing.T) {
+		invalid := newPetData()
+		invalid["unknown"] = true // Strict mutate is enabled.
+
+		data := newUserData()
+		data["pets"] = []any{invalid}
+
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPost, "/users", data)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		data["pets"] = []any{nil}
+		resp = enttest.Request[map[string]any](ctx, s, http.MethodPost, "/users", data)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	})
+}
+
+func TestHandler_Upsert(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	githubID := gofakeit.Number(1, 1_000_000)
+	path := "/users/by-github-id/" + strconv.Itoa(githubID)
+
+	data := map[string]any{
+		"name":            gofakeit.Name(),
+		"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
+	}
+
+	resp := enttest.Request[ent.User](ctx, s, http.MethodPut, path, data).Must(t)
+	require.Equal(t, http.StatusCreated, resp.Data.Code)
+	require.NotNil(t, resp.Value.GithubID)
+	assert.Equal(t, githubID, *resp.Value.GithubID)
+	assert.Equal(t, data["name"], resp.Value.Name)
+
+	// The second request should update the same entity.
+	data["name"] = gofakeit.Name()
+
+	resp2 := enttest.Request[ent.User](ctx, s, http.MethodPut, path, data).Must(t)
+	require.Equal(t, http.StatusOK, resp2.Data.Code)
+	assert.Equal(t, resp.Value.ID, resp2.Value.ID)
+	assert.Equal(t, data["name"], resp2.Value.Name)
+	assert.Equal(t, 1, db.User.Query().Where(user.GithubID(githubID)).CountX(ctx))
+
+	resp3 := enttest.Request[map[string]any](ctx, s, http.MethodPut, "/users/by-github-id/invalid", data)
+	assert.Equal(t, http.StatusBadRequest, resp3.Data.Code)
+}
+
+func TestHandler_CountStrategy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, db, s := newRestServer(t, nil)
+		t.Cleanup(func() { db.Close() })
+
+		db.Settings.CreateBulk(db.Settings.Create(), db.Settings.Create(), db.Settings.Create()).ExecX(ctx)
+
+		resp := enttest.Request[rest.PagedResponse[ent.Settings]](ctx, s, http.MethodGet, "/settings?per_page=2", nil).Must(t)
+		assert.Len(t, resp.Value.Content, 2)
+		assert.False(t, resp.Value.IsLastPage)
+
+		// Until the last page is reached, the count is based on the results seen so far.
+		assert.True(t, resp.Value.IsEstimate)
+		assert.Equal(t, 3, resp.Value.TotalCount)
+		assert.Equal(t, 2, resp.Value.LastPage)
+
+		resp = enttest.Request[rest.PagedResponse[ent.Settings]](ctx, s, http.MethodGet, "/settings?per_page=2&page=2", nil).Must(t)
+		assert.Len(t, resp.Value.Content, 1)
+		assert.True(t, resp.Value.IsLastPage)
+		assert.False(t, resp.Value.IsEstimate)
+		assert.Equal(t, 3, resp.Value.TotalCount)
+		assert.Equal(t, 2, resp.Value.LastPage)
+
+		// Pages past the last page have no results, rather than being out of bounds.
+		resp3 := enttest.Request[map[string]any](ctx, s, http.MethodGet, "/settings?per_page=2&page=3", nil)
+		assert.Equal(t, http.StatusNotFound, resp3.Data.Code)
+		assert.Equal(t, true, (*resp3.Value)["is_estimate"])
+	})
+
+	t.Run("estimated", func(t *testing.T) {
+		t.Parallel()
+
+		var tables []string
+		ctx, db, s := newRestServer(t, &rest.ServerConfig{
+			CountEstimator: func(_ context.Context, table string) (int, bool, error) {
+				tables = append(tables, table)
+				return 1000, true, nil
+			},
+		})
+		t.Cleanup(func() { db.Close() })
+
+		users := db.User.CreateBulk(enttest.Multiple(newUser, db, 3)...).SaveX(ctx)
+		newUser(db).AddFriends(users...).ExecX(ctx)
+		total := db.Friendship.Query().CountX(ctx)
+		require.GreaterOrEqual(t, total, 3)
+
+		resp := enttest.Request[rest.PagedResponse[ent.Friendship]](ctx, s, http.MethodGet, "/friendships?per_page=2", nil).Must(t)
+		assert.True(t, resp.Value.IsEstimate)
+		assert.Equal(t, 1000, resp.Value.TotalCount)
+		assert.Equal(t, 500, resp.Value.LastPage)
+		assert.False(t, resp.Value.IsLastPage)
+		assert.Equal(t, []string{friendship.Table}, tables)
+
+		// On the last page, the exact count is known.
+		last := (total + 1) / 2
+		resp = enttest.Request[rest.PagedResponse[ent.Friendship]](ctx, s, http.MethodGet, "/friendships?per_page=2&page="+strconv.Itoa(last), nil).Must(t)
+		assert.False(t, resp.Value.IsEstimate)
+		assert.Equal(t, total, resp.Value.TotalCount)
+		assert.Equal(t, last, resp.Value.LastPage)
+		assert.True(t, resp.Value.IsLastPage)
+
+		// Filtered queries always use an exact count.
+		id := db.Friendship.Query().FirstIDX(ctx)
+		resp = enttest.Request[rest.PagedResponse[ent.Friendship]](ctx, s, http.MethodGet, "/friendships?id.eq="+strconv.Itoa(id), nil).Must(t)
+		assert.False(t, resp.Value.IsEstimate)
+		assert.Equal(t, 1, resp.Value.TotalCount)
+		assert.Len(t, tables, 2)
+	})
+}
+
+func TestHandler_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(db *ent.Client) rest.IdempotencyStore{
+		"memory": func(_ *ent.Client) rest.IdempotencyStore { return rest.NewMemoryIdempotencyStore(0) },
+		"ent":    func(db *ent.Client) rest.IdempotencyStore { return rest.NewEntIdempotencyStore(db, 0) },
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := newClient(t)
+			t.Cleanup(func() { db.Close() })
+			s := enttest.NewServer(t, db, &rest.ServerConfig{IdempotencyStore: store(db)})
+
+			headers := http.Header{"Idempotency-Key": []string{gofakeit.UUID()}}
+			data := map[string]any{
+				"name":            gofakeit.Name(),
+				"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
+			}
+
+			resp := enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPost, "/users", headers, data).Must(t)
+			require.Equal(t, http.StatusCreated, resp.Data.Code)
+			assert.Empty(t, resp.Data.Header().Get("Idempotent-Replayed"))
+
+			// Retrying with the same key and body should replay the original response.
+			resp2 := enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPost, "/users", headers, data).Must(t)
+			require.Equal(t, http.StatusCreated, resp2.Data.Code)
+			assert.Equal(t, "true", resp2.Data.Header().Get("Idempotent-Replayed"))
+			assert.Equal(t, resp.Value.ID, resp2.Value.ID)
+			assert.Equal(t, 1, db.User.Query().CountX(ctx))
+
+			// Re-using the key with a different body should be rejected.
+			data["name"] = gofakeit.Name()
+			resp3 := enttest.RequestWithHeaders[map[string]any](ctx, s, http.MethodPost, "/users", headers, data)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp3.Data.Code)
+			assert.Equal(t, 1, db.User.Query().CountX(ctx))
+
+			// Keys are scoped to the path, and requests without a key aren't affected.
+			resp4 := enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPatch, "/users/"+resp.Value.ID.String(), headers, map[string]any{"name": data["name"]}).Must(t)
+			assert.Equal(t, http.StatusOK, resp4.Data.Code)
+
+			resp5 := enttest.Request[ent.User](ctx, s, http.MethodPost, "/users", data).Must(t)
+			assert.Equal(t, http.StatusCreated, resp5.Data.Code)
+			assert.Equal(t, 2, db.User.Query().CountX(ctx))
+
+			// Keys are scoped to the client, so other clients can't replay the response.
+			other := http.Header{"Idempotency-Key": headers["Idempotency-Key"], "Authorization": {"Bearer other"}}
+			resp6 := enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPost, "/users", other, data).Must(t)
+			assert.Equal(t, http.StatusCreated, resp6.Data.Code)
+			assert.Empty(t, resp6.Data.Header().Get("Idempotent-Replayed"))
+			assert.Equal(t, 3, db.User.Query().CountX(ctx))
+
+			// Bodies are only buffered up to the maximum size.
+			body := bytes.NewReader([]byte(`{"name": "` + strings.Repeat("a", int(rest.DefaultDecodeMaxBytes)) + `"}`))
+			resp7 := enttest.RequestWithHeaders[map[string]any](
+				ctx, s, http.MethodPost, "/users",
+				http.Header{"Idempotency-Key": []string{gofakeit.UUID()}, "Content-Type": []string{"application/json"}},
+				body,
+			)
+			assert.Equal(t, http.StatusBadRequest, resp7.Data.Code)
+			assert.Contains(t, resp7.Data.Body.String(), "exceeds the maximum size")
+			assert.Equal(t, 3, db.User.Query().CountX(ctx))
+		})
+	}
+}
+
 func TestHandler_Update(t *testing.T) {
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
@@ -514,153 +904,560 @@ func TestHandler_Update(t *testing.T) {
 	assert.Equal(t, categories[2].ID, resp.Value.Edges.Categories[0].ID)
 }
 
-func TestHandler_Delete(t *testing.T) {
+func TestHandler_UpdatePatch(t *testing.T) {
+	t.Parallel()
+
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
 
-	pet1 := newPet(db).SaveX(ctx)
-
-	resp := enttest.Request[string](ctx, s, http.MethodDelete, "/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
-
-	assert.Equal(t, http.StatusNoContent, resp.Data.Code)
-
-	resp = enttest.Request[string](ctx, s, http.MethodDelete, "/pets/"+strconv.Itoa(pet1.ID), nil)
-	require.NotNil(t, resp.Error)
-	assert.Equal(t, http.StatusNotFound, resp.Data.Code)
-}
+	categories := db.Category.CreateBulk(enttest.Multiple(newCategory, db, 3)...).SaveX(ctx)
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetNicknames([]string{"foo"}).AddCategories(categories[0], categories[1]).SaveX(ctx)
+
+	t.Run("merge-patch", func(t *testing.T) {
+		resp := enttest.Request[ent.User](
+			ctx, s,
+			http.MethodPatch,
+			"/users/"+user1.ID.String(),
+			map[string]any{"github_data": map[string]any{"login": "foo", "name": "bar"}},
+		).Must(t)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
 
-func TestHandler_SortRandom(t *testing.T) {
-	ctx, db, s := newRestServer(t, nil)
-	t.Cleanup(func() { db.Close() })
+		// JSON-typed fields should be deep merged, and null values removed.
+		resp = enttest.RequestWithHeaders[ent.User](
+			ctx, s,
+			http.MethodPatch,
+			"/users/"+user1.ID.String(),
+			http.Header{"Content-Type": {rest.MediaTypeMergePatch}},
+			map[string]any{"github_data": map[string]any{"login": "baz"}, "description": nil},
+		).Must(t)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
 
-	db.Pet.CreateBulk(enttest.Multiple(newPet, db, 100)...).ExecX(ctx)
+		user1 = db.User.GetX(ctx, user1.ID)
+		assert.Equal(t, "baz", user1.GithubData.GetLogin())
+		assert.Equal(t, "bar", user1.GithubData.GetName())
+		assert.Nil(t, user1.Description)
+	})
 
-	var results [][]*ent.Pet
+	t.Run("json-patch", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPatch,
+			"/pets/"+strconv.Itoa(pet1.ID),
+			http.Header{"Content-Type": {rest.MediaTypeJSONPatch}},
+			[]map[string]any{
+				{"op": "replace", "path": "/name", "value": "patched"},
+				{"op": "add", "path": "/nicknames/-", "value": "bar"},
+				{"op": "add", "path": "/categories/-", "value": categories[2].ID},
+				{"op": "remove", "path": "/categories/" + strconv.Itoa(categories[0].ID)},
+			},
+		).Must(t)
 
-	for range 50 {
-		resp := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets?page=1&per_page=100&sort=random", nil)
 		require.Equal(t, http.StatusOK, resp.Data.Code)
-		require.Len(t, resp.Value.Content, 100)
-		results = append(results, resp.Value.Content)
-	}
+		assert.Equal(t, "patched", resp.Value.Name)
+		assert.Equal(t, []string{"foo", "bar"}, resp.Value.Nicknames)
+		assert.ElementsMatch(t, []int{categories[1].ID, categories[2].ID}, db.Pet.QueryCategories(pet1).IDsX(ctx))
+	})
 
-	// Ensure that all results are different.
-	for i := range results {
-		if i == 0 {
-			continue
-		}
-		assert.NotEqual(t, results[i-1], results[i])
-	}
+	t.Run("json-patch-test-failed", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPatch,
+			"/pets/"+strconv.Itoa(pet1.ID),
+			http.Header{"Content-Type": {rest.MediaTypeJSONPatch}},
+			[]map[string]any{
+				{"op": "test", "path": "/nicknames/0", "value": "not-foo"},
+				{"op": "remove", "path": "/nicknames/0"},
+			},
+		)
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+		assert.Equal(t, []string{"foo", "bar"}, db.Pet.GetX(ctx, pet1.ID).Nicknames)
+	})
+
+	t.Run("merge-patch-too-large", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.User](
+			ctx, s,
+			http.MethodPatch,
+			"/users/"+user1.ID.String(),
+			http.Header{"Content-Type": {rest.MediaTypeMergePatch}},
+			map[string]any{"description": strings.Repeat("a", int(rest.DefaultDecodeMaxBytes))},
+		)
+
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+		assert.Contains(t, resp.Error.Error, "request body too large")
+	})
 }
 
-func TestHandler_StrictMutate(t *testing.T) {
+func TestHandler_Replace(t *testing.T) {
+	t.Parallel()
+
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
 
+	categories := db.Category.CreateBulk(enttest.Multiple(newCategory, db, 3)...).SaveX(ctx)
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetOwner(user1).AddCategories(categories[0], categories[1]).SaveX(ctx)
+
 	data := map[string]any{
-		"name":        "foo",
-		"nonexistent": "bar",
+		"name":       gofakeit.Regex("^[a-z][a-z-]{10,40}$"),
+		"age":        25,
+		"type":       pet.TypeCat,
+		"categories": []int{categories[2].ID},
 	}
 
-	// POST/create.
-	resp := enttest.Request[ent.Pet](ctx, s, http.MethodPost, "/pets", data)
-	require.Equal(t, http.StatusBadRequest, resp.Data.Code)
-	require.NotNil(t, resp.Error)
-	assert.Equal(t, http.StatusBadRequest, resp.Error.Code)
+	resp := enttest.Request[ent.Pet](ctx, s, http.MethodPut, "/pets/"+strconv.Itoa(pet1.ID), data).Must(t)
 
-	// PATCH/update.
-	pet1 := newPet(db).SaveX(ctx)
-	resp = enttest.Request[ent.Pet](ctx, s, http.MethodPatch, "/pets/"+strconv.Itoa(pet1.ID), data)
-	require.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	assert.Equal(t, http.StatusOK, resp.Data.Code)
+	assert.Equal(t, data["name"], resp.Value.Name)
+	assert.Equal(t, data["age"], resp.Value.Age)
+	assert.Empty(t, resp.Value.Nicknames)
+	assert.Nil(t, resp.Value.Edges.Owner)
+	require.Len(t, resp.Value.Edges.Categories, 1)
+	assert.Equal(t, categories[2].ID, resp.Value.Edges.Categories[0].ID)
+
+	// Required fields must always be provided.
+	resp = enttest.Request[ent.Pet](ctx, s, http.MethodPut, "/pets/"+strconv.Itoa(pet1.ID), map[string]any{
+		"name": data["name"],
+	})
 	require.NotNil(t, resp.Error)
-	assert.Equal(t, http.StatusBadRequest, resp.Error.Code)
+	assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
 }
 
-func TestHandler_Valuer(t *testing.T) {
+func TestHandler_Delete(t *testing.T) {
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
 
-	data := map[string]any{
-		"name":            "John Smith",
-		"email":           "john.smith@example.com",
-		"enabled":         true,
-		"type":            user.TypeUser,
-		"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
-		"profile_url":     "https://test.example.com/some-path",
-	}
+	pet1 := newPet(db).SaveX(ctx)
 
-	resp := enttest.Request[ent.User](ctx, s, http.MethodPost, "/users", data).Must(t)
-	assert.Equal(t, http.StatusCreated, resp.Data.Code)
-	assert.Equal(t, data["profile_url"], resp.Value.ProfileURL.String())
+	resp := enttest.Request[string](ctx, s, http.MethodDelete, "/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
+
+	assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+
+	resp = enttest.Request[string](ctx, s, http.MethodDelete, "/pets/"+strconv.Itoa(pet1.ID), nil)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusNotFound, resp.Data.Code)
 }
 
-func TestHandler_DefaultSort(t *testing.T) {
+func TestHandler_ConditionalRequests(t *testing.T) {
+	t.Parallel()
+
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
 
-	pet1 := newPet(db).SetName("cdefgh").SaveX(ctx)
-	pet2 := newPet(db).SetName("bcdefg").SaveX(ctx)
-	pet3 := newPet(db).SetName("abcdef").SaveX(ctx)
+	pet1 := newPet(db).SaveX(ctx)
+	path := "/pets/" + strconv.Itoa(pet1.ID)
 
-	// First fetch pets with no sorting in the query. Theoretically, default is ID & ASC (1, 2, 3),
-	// but we override this to be name & ASC (3, 2, 1).
-	resp1 := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", nil).Must(t)
+	resp := enttest.Request[ent.Pet](ctx, s, http.MethodGet, path, nil).Must(t)
+	etag := resp.Data.Header().Get("ETag")
+	require.Equal(t, `"1"`, etag)
 
-	assert.Equal(t, http.StatusOK, resp1.Data.Code)
-	require.Len(t, resp1.Value.Content, 3)
-	assert.Equal(t, pet3.Name, resp1.Value.Content[0].Name)
-	assert.Equal(t, pet2.Name, resp1.Value.Content[1].Name)
-	assert.Equal(t, pet1.Name, resp1.Value.Content[2].Name)
+	t.Run("if-none-match", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodGet, path, http.Header{"If-None-Match": {etag}}, nil)
+		assert.Equal(t, http.StatusNotModified, resp.Data.Code)
+		assert.Empty(t, resp.Data.Body.String())
 
-	// Now fetch another entity type, and see if the eager-loaded edges also have the appropriate
-	// default sorting.
-	user1 := newUser(db).AddPets(pet1, pet2, pet3).SaveX(ctx)
-	resp2 := enttest.Request[ent.User](ctx, s, http.MethodGet, "/users/"+user1.ID.String(), nil).Must(t)
+		// The version is always selected, even if not requested.
+		resp = enttest.Request[ent.Pet](ctx, s, http.MethodGet, path+"?fields=name", nil).Must(t)
+		assert.Equal(t, etag, resp.Data.Header().Get("ETag"))
 
-	assert.Equal(t, http.StatusOK, resp2.Data.Code)
-	require.Len(t, resp2.Value.Edges.Pets, 3)
-	assert.Equal(t, pet3.Name, resp2.Value.Edges.Pets[0].Name)
-	assert.Equal(t, pet2.Name, resp2.Value.Edges.Pets[1].Name)
-	assert.Equal(t, pet1.Name, resp2.Value.Edges.Pets[2].Name)
+		list := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", nil).Must(t)
+		listETag := list.Data.Header().Get("ETag")
+		require.True(t, strings.HasPrefix(listETag, "W/"))
+
+		list = enttest.RequestWithHeaders[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", http.Header{"If-None-Match": {listETag}}, nil)
+		assert.Equal(t, http.StatusNotModified, list.Data.Code)
+	})
+
+	t.Run("if-match", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPatch,
+			path,
+			http.Header{"If-Match": {etag}},
+			map[string]any{"name": "updated"},
+		).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 2, resp.Value.Version)
+		assert.Equal(t, `"2"`, resp.Data.Header().Get("ETag"))
+
+		// The previous version is now stale.
+		resp = enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPatch,
+			path,
+			http.Header{"If-Match": {etag}},
+			map[string]any{"name": "stale"},
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+		assert.Equal(t, "updated", db.Pet.GetX(ctx, pet1.ID).Name)
+
+		dresp := enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, path, http.Header{"If-Match": {etag}}, nil)
+		require.NotNil(t, dresp.Error)
+		assert.Equal(t, http.StatusPreconditionFailed, dresp.Data.Code)
+
+		dresp = enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, path, http.Header{"If-Match": {`"2"`}}, nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, dresp.Data.Code)
+
+		// Entities which don't exist are still not found.
+		dresp = enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, path, http.Header{"If-Match": {`"2"`}}, nil)
+		require.NotNil(t, dresp.Error)
+		assert.Equal(t, http.StatusNotFound, dresp.Data.Code)
+	})
 }
 
-func TestHandler_EagerLoadLimit(t *testing.T) {
+func TestHandler_SoftDelete(t *testing.T) {
+	t.Parallel()
+
 	ctx, db, s := newRestServer(t, nil)
 	t.Cleanup(func() { db.Close() })
 
-	// Ensure that the limit is respected when eager-loading.
 	pet1 := newPet(db).SaveX(ctx)
-
-	db.Category.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.CategoryCreate {
+	categories := db.Category.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.CategoryCreate {
 		return newCategory(db).AddPets(pet1)
-	}, db, 1020)...).ExecX(ctx)
+	}, db, 3)...).SaveX(ctx)
+	path := "/categories/" + strconv.Itoa(categories[0].ID)
 
-	resp1 := enttest.Request[ent.Pet](ctx, s, http.MethodGet, "/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
-	require.Len(t, resp1.Value.Edges.Categories, 1000)
+	resp := enttest.Request[string](ctx, s, http.MethodDelete, path, nil).Must(t)
+	assert.Equal(t, http.StatusNoContent, resp.Data.Code)
 
-	// And when we hit something which has no limit...
-	user1 := newUser(db).SaveX(ctx)
+	// The entity still exists, but is hidden from all operations.
+	deleted := db.Category.GetX(ctx, categories[0].ID)
+	require.NotNil(t, deleted.DeletedAt)
 
-	db.Pet.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.PetCreate {
-		return newPet(db).SetOwner(user1)
-	}, db, 1020)...).ExecX(ctx)
+	t.Run("hidden", func(t *testing.T) {
+		resp := enttest.Request[ent.Category](ctx, s, http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
 
-	resp2 := enttest.Request[ent.User](ctx, s, http.MethodGet, "/users/"+user1.ID.String(), nil).Must(t)
-	require.Len(t, resp2.Value.Edges.Pets, 1020)
-}
+		resp = enttest.Request[ent.Category](ctx, s, http.MethodPatch, path, map[string]any{"name": "updated"})
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
 
-func TestHandler_FilterGroups(t *testing.T) {
-	ctx, db, s := newRestServer(t, nil)
-	t.Cleanup(func() { db.Close() })
+		dresp := enttest.Request[string](ctx, s, http.MethodDelete, path, nil)
+		assert.Equal(t, http.StatusNotFound, dresp.Data.Code)
 
-	db.User.CreateBulk(enttest.Multiple(newUser, db, 100)...).ExecX(ctx)
+		list := enttest.Request[rest.PagedResponse[ent.Category]](ctx, s, http.MethodGet, "/categories", nil).Must(t)
+		assert.Equal(t, 2, list.Value.TotalCount)
 
-	user1 := newUser(db).
-		SetName("123username").
-		SetDescription("456description").
-		SetEmail("789email@example.com").
-		SetEnabled(true).
-		SaveX(ctx)
+		edges := enttest.Request[rest.PagedResponse[ent.Category]](ctx, s, http.MethodGet, "/pets/"+strconv.Itoa(pet1.ID)+"/categories", nil).Must(t)
+		assert.Equal(t, 2, edges.Value.TotalCount)
+	})
+
+	t.Run("include-deleted", func(t *testing.T) {
+		list := enttest.Request[rest.PagedResponse[ent.Category]](ctx, s, http.MethodGet, "/categories?include_deleted=true", nil).Must(t)
+		assert.Equal(t, 3, list.Value.TotalCount)
+
+		list = enttest.Request[rest.PagedResponse[ent.Category]](ctx, s, http.MethodGet, "/categories?only_deleted=true", nil).Must(t)
+		require.Len(t, list.Value.Content, 1)
+		assert.Equal(t, categories[0].ID, list.Value.Content[0].ID)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		resp := enttest.Request[ent.Category](ctx, s, http.MethodPost, path+"/restore", nil).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, categories[0].ID, resp.Value.ID)
+		assert.Nil(t, resp.Value.DeletedAt)
+
+		// Restoring is idempotent.
+		resp = enttest.Request[ent.Category](ctx, s, http.MethodPost, path+"/restore", nil).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+
+		resp = enttest.Request[ent.Category](ctx, s, http.MethodPost, "/categories/999999/restore", nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+
+		enttest.Request[ent.Category](ctx, s, http.MethodGet, path, nil).Must(t)
+	})
+}
+
+func TestHandler_EdgeMutation(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SaveX(ctx)
+	category1 := newCategory(db).SaveX(ctx)
+
+	userPath := "/users/" + user1.ID.String()
+	petPath := "/pets/" + strconv.Itoa(pet1.ID)
+
+	t.Run("attach-detach", func(t *testing.T) {
+		resp := enttest.Request[string](ctx, s, http.MethodPut, userPath+"/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.True(t, user1.QueryPets().Where(pet.ID(pet1.ID)).ExistX(ctx))
+
+		// Attaching is idempotent.
+		enttest.Request[string](ctx, s, http.MethodPut, userPath+"/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
+
+		resp = enttest.Request[string](ctx, s, http.MethodDelete, userPath+"/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.False(t, user1.QueryPets().Where(pet.ID(pet1.ID)).ExistX(ctx))
+
+		resp = enttest.Request[string](ctx, s, http.MethodPut, petPath+"/categories/"+strconv.Itoa(category1.ID), nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.Equal(t, 1, pet1.QueryCategories().CountX(ctx))
+
+		resp = enttest.Request[string](ctx, s, http.MethodDelete, petPath+"/categories/"+strconv.Itoa(category1.ID), nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.Equal(t, 0, pet1.QueryCategories().CountX(ctx))
+	})
+
+	t.Run("set-clear", func(t *testing.T) {
+		resp := enttest.Request[string](ctx, s, http.MethodPut, petPath+"/owner/"+user1.ID.String(), nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.Equal(t, user1.ID, pet1.QueryOwner().OnlyIDX(ctx))
+
+		resp = enttest.Request[string](ctx, s, http.MethodDelete, petPath+"/owner", nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.False(t, pet1.QueryOwner().ExistX(ctx))
+	})
+
+	t.Run("if-match", func(t *testing.T) {
+		stale := http.Header{"If-Match": {strconv.Quote(strconv.Itoa(db.Pet.GetX(ctx, pet1.ID).Version - 1))}}
+		categoryPath := petPath + "/categories/" + strconv.Itoa(category1.ID)
+
+		resp := enttest.RequestWithHeaders[string](ctx, s, http.MethodPut, categoryPath, stale, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+		assert.Equal(t, 0, pet1.QueryCategories().CountX(ctx))
+
+		current := http.Header{"If-Match": {strconv.Quote(strconv.Itoa(db.Pet.GetX(ctx, pet1.ID).Version))}}
+		resp = enttest.RequestWithHeaders[string](ctx, s, http.MethodPut, categoryPath, current, nil).Must(t)
+		assert.Equal(t, http.StatusNoContent, resp.Data.Code)
+		assert.Equal(t, 1, pet1.QueryCategories().CountX(ctx))
+
+		// Attaching an already attached entity still checks the version.
+		resp = enttest.RequestWithHeaders[string](ctx, s, http.MethodPut, categoryPath, current, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+
+		resp = enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, categoryPath, current, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+		assert.Equal(t, 1, pet1.QueryCategories().CountX(ctx))
+
+		resp = enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, petPath+"/owner", current, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		resp := enttest.Request[string](ctx, s, http.MethodPut, userPath+"/pets/999999", nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+
+		resp = enttest.Request[string](ctx, s, http.MethodPut, "/pets/999999/owner/"+user1.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+
+		resp = enttest.Request[string](ctx, s, http.MethodPut, petPath+"/owner/invalid", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		// Soft-deleted entities can't be attached.
+		deleted := newCategory(db).SetDeletedAt(time.Now()).SaveX(ctx)
+		resp = enttest.Request[string](ctx, s, http.MethodPut, petPath+"/categories/"+strconv.Itoa(deleted.ID), nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+	})
+}
+
+func TestHandler_UpdateDeleteBulk(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	pet1 := newPet(db).SetAge(1).SaveX(ctx)
+	pet2 := newPet(db).SetAge(1).SaveX(ctx)
+	pet3 := newPet(db).SetAge(2).SaveX(ctx)
+
+	t.Run("update", func(t *testing.T) {
+		resp := enttest.Request[rest.BulkResponse](
+			ctx, s,
+			http.MethodPatch,
+			"/pets?age.in=1",
+			map[string]any{"age": 5},
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 2, resp.Value.Affected)
+		assert.Equal(t, 5, db.Pet.GetX(ctx, pet1.ID).Age)
+		assert.Equal(t, 5, db.Pet.GetX(ctx, pet2.ID).Age)
+		assert.Equal(t, 2, db.Pet.GetX(ctx, pet3.ID).Age)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp := enttest.Request[rest.BulkResponse](
+			ctx, s,
+			http.MethodDelete,
+			"/pets?age.in=2",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 1, resp.Value.Affected)
+		assert.False(t, db.Pet.Query().Where(pet.ID(pet3.ID)).ExistX(ctx))
+	})
+
+	t.Run("no-filters", func(t *testing.T) {
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPatch, "/pets", map[string]any{"age": 10})
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		resp = enttest.Request[map[string]any](ctx, s, http.MethodDelete, "/pets", http.NoBody)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		assert.Equal(t, 2, db.Pet.Query().CountX(ctx))
+	})
+
+	t.Run("all", func(t *testing.T) {
+		resp := enttest.Request[rest.BulkResponse](ctx, s, http.MethodDelete, "/pets?all=true", http.NoBody).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 2, resp.Value.Affected)
+		assert.Zero(t, db.Pet.Query().CountX(ctx))
+	})
+
+	t.Run("search", func(t *testing.T) {
+		pet4 := newPet(db).SetName("Zzyzx").SaveX(ctx)
+		pet5 := newPet(db).SetName("Kuro").SaveX(ctx)
+
+		resp := enttest.Request[rest.BulkResponse](ctx, s, http.MethodDelete, "/pets?q=zzyzx", http.NoBody).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 1, resp.Value.Affected)
+		assert.False(t, db.Pet.Query().Where(pet.ID(pet4.ID)).ExistX(ctx))
+		assert.True(t, db.Pet.Query().Where(pet.ID(pet5.ID)).ExistX(ctx))
+	})
+}
+
+func TestHandler_SortRandom(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	db.Pet.CreateBulk(enttest.Multiple(newPet, db, 100)...).ExecX(ctx)
+
+	var results [][]*ent.Pet
+
+	for range 50 {
+		resp := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets?page=1&per_page=100&sort=random", nil)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Content, 100)
+		results = append(results, resp.Value.Content)
+	}
+
+	// Ensure that all results are different.
+	for i := range results {
+		if i == 0 {
+			continue
+		}
+		assert.NotEqual(t, results[i-1], results[i])
+	}
+}
+
+func TestHandler_StrictMutate(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	data := map[string]any{
+		"name":        "foo",
+		"nonexistent": "bar",
+	}
+
+	// POST/create.
+	resp := enttest.Request[ent.Pet](ctx, s, http.MethodPost, "/pets", data)
+	require.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusBadRequest, resp.Error.Code)
+
+	// PATCH/update.
+	pet1 := newPet(db).SaveX(ctx)
+	resp = enttest.Request[ent.Pet](ctx, s, http.MethodPatch, "/pets/"+strconv.Itoa(pet1.ID), data)
+	require.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusBadRequest, resp.Error.Code)
+}
+
+func TestHandler_Valuer(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	data := map[string]any{
+		"name":            "John Smith",
+		"email":           "john.smith@example.com",
+		"enabled":         true,
+		"type":            user.TypeUser,
+		"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
+		"profile_url":     "https://test.example.com/some-path",
+	}
+
+	resp := enttest.Request[ent.User](ctx, s, http.MethodPost, "/users", data).Must(t)
+	assert.Equal(t, http.StatusCreated, resp.Data.Code)
+	assert.Equal(t, data["profile_url"], resp.Value.ProfileURL.String())
+}
+
+func TestHandler_DefaultSort(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	pet1 := newPet(db).SetName("cdefgh").SaveX(ctx)
+	pet2 := newPet(db).SetName("bcdefg").SaveX(ctx)
+	pet3 := newPet(db).SetName("abcdef").SaveX(ctx)
+
+	// First fetch pets with no sorting in the query. Theoretically, default is ID & ASC (1, 2, 3),
+	// but we override this to be name & ASC (3, 2, 1).
+	resp1 := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", nil).Must(t)
+
+	assert.Equal(t, http.StatusOK, resp1.Data.Code)
+	require.Len(t, resp1.Value.Content, 3)
+	assert.Equal(t, pet3.Name, resp1.Value.Content[0].Name)
+	assert.Equal(t, pet2.Name, resp1.Value.Content[1].Name)
+	assert.Equal(t, pet1.Name, resp1.Value.Content[2].Name)
+
+	// Now fetch another entity type, and see if the eager-loaded edges also have the appropriate
+	// default sorting.
+	user1 := newUser(db).AddPets(pet1, pet2, pet3).SaveX(ctx)
+	resp2 := enttest.Request[ent.User](ctx, s, http.MethodGet, "/users/"+user1.ID.String(), nil).Must(t)
+
+	assert.Equal(t, http.StatusOK, resp2.Data.Code)
+	require.Len(t, resp2.Value.Edges.Pets, 3)
+	assert.Equal(t, pet3.Name, resp2.Value.Edges.Pets[0].Name)
+	assert.Equal(t, pet2.Name, resp2.Value.Edges.Pets[1].Name)
+	assert.Equal(t, pet1.Name, resp2.Value.Edges.Pets[2].Name)
+}
+
+func TestHandler_EagerLoadLimit(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	// Ensure that the limit is respected when eager-loading.
+	pet1 := newPet(db).SaveX(ctx)
+
+	db.Category.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.CategoryCreate {
+		return newCategory(db).AddPets(pet1)
+	}, db, 1020)...).ExecX(ctx)
+
+	resp1 := enttest.Request[ent.Pet](ctx, s, http.MethodGet, "/pets/"+strconv.Itoa(pet1.ID), nil).Must(t)
+	require.Len(t, resp1.Value.Edges.Categories, 1000)
+
+	// And when we hit something which has no limit...
+	user1 := newUser(db).SaveX(ctx)
+
+	db.Pet.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.PetCreate {
+		return newPet(db).SetOwner(user1)
+	}, db, 1020)...).ExecX(ctx)
+
+	resp2 := enttest.Request[ent.User](ctx, s, http.MethodGet, "/users/"+user1.ID.String(), nil).Must(t)
+	require.Len(t, resp2.Value.Edges.Pets, 1020)
+}
+
+func TestHandler_FilterGroups(t *testing.T) {
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	db.User.CreateBulk(enttest.Multiple(newUser, db, 100)...).ExecX(ctx)
+
+	user1 := newUser(db).
+		SetName("123username").
+		SetDescription("456description").
+		SetEmail("789email@example.com").
+		SetEnabled(true).
+		SaveX(ctx)
 
 	newUser(db).
 		SetName("123username").
@@ -684,3 +1481,1737 @@ func TestHandler_FilterGroups(t *testing.T) {
 		assert.Equal(t, user1.ID, resp.Value.Content[0].ID)
 	}
 }
+
+func TestHandler_Search(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, db, s := newRestServer(t, nil)
+		t.Cleanup(func() { db.Close() })
+
+		db.Pet.CreateBulk(enttest.Multiple(newPet, db, 20)...).ExecX(ctx)
+		pet1 := newPet(db).SetName("Kurosawa").SaveX(ctx)
+		pet2 := newPet(db).SetName("kuro").SaveX(ctx)
+
+		// SQLite without a search table falls back to matching any field (case insensitive).
+		resp := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets?q=KURO&sort=relevance&order=desc", nil).Must(t)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Content, 2)
+		assert.ElementsMatch(t, []int{pet1.ID, pet2.ID}, []int{resp.Value.Content[0].ID, resp.Value.Content[1].ID})
+
+		// Relevance requires a search query.
+		resp = enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets?sort=relevance", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	})
+
+	t.Run("fts5", func(t *testing.T) {
+		t.Parallel()
+
+		sqlRegister.Do(func() {
+			sql.Register("sqlite3", &sqlite.Driver{})
+		})
+
+		drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&_pragma=foreign_keys(1)&_time_format=sqlite")
+		require.NoError(t, err)
+		drv.DB().SetMaxOpenConns(1) // In-memory databases aren't shared across connections.
+
+		ctx := context.Background()
+		db := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+		t.Cleanup(func() { db.Close() })
+		s := enttest.NewServer(t, db, nil)
+
+		_, err = drv.DB().ExecContext(
+			ctx,
+			"CREATE VIRTUAL TABLE post_search USING fts5(title, slug, body, content='posts', content_rowid='id')",
+		)
+		require.NoError(t, err)
+
+		author := newUser(db).SaveX(ctx)
+		db.Post.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.PostCreate { return newPost(db, author) }, db, 20)...).ExecX(ctx)
+		post1 := newPost(db, author).SetTitle("Writing a gopher friendly API").SaveX(ctx)
+
+		_, err = drv.DB().ExecContext(ctx, "INSERT INTO post_search(post_search) VALUES('rebuild')")
+		require.NoError(t, err)
+
+		resp := enttest.Request[rest.CursorResponse[ent.Post]](ctx, s, http.MethodGet, "/posts?q=gopher+api", nil).Must(t)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Content, 1)
+		assert.Equal(t, post1.ID, resp.Value.Content[0].ID)
+
+		// FTS5 query syntax should be matched literally (so "not" is a term which isn't in
+		// any post), rather than resulting in errors.
+		resp = enttest.Request[rest.CursorResponse[ent.Post]](ctx, s, http.MethodGet, "/posts?q="+url.QueryEscape(`"gopher NOT (friendly`), nil)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+	})
+}
+
+func TestHandler_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	newPet(db).SetType(pet.TypeDog).SetAge(2).SaveX(ctx)
+	newPet(db).SetType(pet.TypeDog).SetAge(4).SaveX(ctx)
+	newPet(db).SetType(pet.TypeCat).SetAge(10).SaveX(ctx)
+
+	resp := enttest.Request[[]*rest.PetAggregate](ctx, s, http.MethodGet, "/pets/aggregate", nil).Must(t)
+	groups := *resp.Value
+	require.Len(t, groups, 1)
+	assert.Equal(t, 3, *groups[0].Count)
+	assert.Nil(t, groups[0].Type)
+
+	resp = enttest.Request[[]*rest.PetAggregate](ctx, s, http.MethodGet, "/pets/aggregate?group_by=type&metrics=count,avg:age,max:age", nil).Must(t)
+	groups = *resp.Value
+	require.Len(t, groups, 2)
+	assert.Equal(t, pet.TypeCat, *groups[0].Type)
+	assert.Equal(t, 1, *groups[0].Count)
+	assert.InDelta(t, 10, *groups[0].AvgAge, 0.001)
+	assert.Equal(t, pet.TypeDog, *groups[1].Type)
+	assert.Equal(t, 2, *groups[1].Count)
+	assert.InDelta(t, 3, *groups[1].AvgAge, 0.001)
+	assert.InDelta(t, 4, *groups[1].MaxAge, 0.001)
+	assert.Nil(t, groups[1].SumAge)
+
+	// Filters are applied before grouping.
+	resp = enttest.Request[[]*rest.PetAggregate](ctx, s, http.MethodGet, "/pets/aggregate?type.eq=DOG&metrics=sum:age", nil).Must(t)
+	groups = *resp.Value
+	require.Len(t, groups, 1)
+	assert.Nil(t, groups[0].Count)
+	assert.InDelta(t, 6, *groups[0].SumAge, 0.001)
+
+	for _, query := range []string{
+		"group_by=name",
+		"metrics=sum:type",
+		"metrics=median:age",
+	} {
+		resp = enttest.Request[[]*rest.PetAggregate](ctx, s, http.MethodGet, "/pets/aggregate?"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code, query)
+	}
+
+	t.Run("time-buckets", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, db, s := newRestServerDSN(t, testTimeDSN, nil)
+		t.Cleanup(func() { db.Close() })
+
+		for _, ts := range []string{
+			"2024-01-01T10:00:00Z", // Monday.
+			"2024-01-01T23:00:00Z",
+			"2024-01-07T12:00:00Z", // Sunday.
+			"2024-01-08T12:00:00Z",
+			"2024-02-15T12:00:00Z",
+		} {
+			createdAt, err := time.Parse(time.RFC3339, ts)
+			require.NoError(t, err)
+			newUser(db).SetCreatedAt(createdAt).SaveX(ctx)
+		}
+
+		tests := []struct {
+			bucket string
+			want   map[string]int
+		}{
+			{"day", map[string]int{"2024-01-01": 2, "2024-01-07": 1, "2024-01-08": 1, "2024-02-15": 1}},
+			{"week", map[string]int{"2024-01-01": 3, "2024-01-08": 1, "2024-02-12": 1}},
+			{"month", map[string]int{"2024-01-01": 4, "2024-02-01": 1}},
+		}
+
+		for _, tt := range tests {
+			resp := enttest.Request[[]*rest.UserAggregate](ctx, s, http.MethodGet, "/users/aggregate?group_by=created_at:"+tt.bucket, nil).Must(t)
+
+			got := map[string]int{}
+			var keys []string
+			for _, group := range *resp.Value {
+				got[*group.CreatedAt] = *group.Count
+				keys = append(keys, *group.CreatedAt)
+			}
+			assert.Equal(t, tt.want, got, tt.bucket)
+			assert.IsIncreasing(t, keys, tt.bucket)
+		}
+
+		// Fields can only be grouped by a single time bucket.
+		resp := enttest.Request[[]*rest.UserAggregate](ctx, s, http.MethodGet, "/users/aggregate?group_by=created_at:day,created_at:week", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	})
+}
+
+func TestHandler_Export(t *testing.T) {
+	// Not parallel, as the batch size is changed, so results span multiple batches.
+	batchSize := rest.ExportBatchSize
+	rest.ExportBatchSize = 7
+	t.Cleanup(func() { rest.ExportBatchSize = batchSize })
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	owner := newUser(db).SaveX(ctx)
+	for range 25 {
+		newPet(db).SetOwner(owner).SaveX(ctx)
+	}
+
+	decodeNDJSON := func(t *testing.T, body string) (pets []*ent.Pet) {
+		t.Helper()
+		for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+			p := &ent.Pet{}
+			require.NoError(t, json.Unmarshal([]byte(line), p))
+			pets = append(pets, p)
+		}
+		return pets
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		resp := enttest.Request[string](ctx, s, http.MethodGet, "/pets?format=ndjson&sort=age&order=desc", nil).Must(t)
+		assert.Equal(t, rest.MediaTypeNDJSON, resp.Data.Header().Get("Content-Type"))
+
+		pets := decodeNDJSON(t, *resp.Value)
+		require.Len(t, pets, 25)
+
+		ids := map[int]bool{}
+		var ages []int
+		for _, p := range pets {
+			ids[p.ID] = true
+			ages = append(ages, p.Age)
+			require.NotNil(t, p.Edges.Owner)
+			assert.Equal(t, owner.ID, p.Edges.Owner.ID)
+		}
+		assert.Len(t, ids, 25)
+		assert.IsNonIncreasing(t, ages)
+
+		// Sorting by fields which can't be used with keyset iteration.
+		resp = enttest.Request[string](ctx, s, http.MethodGet, "/pets?format=ndjson&sort=owner.name", nil).Must(t)
+		assert.Len(t, decodeNDJSON(t, *resp.Value), 25)
+	})
+
+	t.Run("ndjson-accept", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[string](
+			ctx, s,
+			http.MethodGet,
+			"/pets?age.gt=5&fields=name",
+			http.Header{"Accept": {rest.MediaTypeNDJSON}},
+			nil,
+		).Must(t)
+
+		pets := decodeNDJSON(t, *resp.Value)
+		assert.Len(t, pets, db.Pet.Query().Where(pet.AgeGT(5)).CountX(ctx))
+		for _, p := range pets {
+			assert.NotEmpty(t, p.Name)
+			assert.Zero(t, p.Age)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[string](
+			ctx, s,
+			http.MethodGet,
+			"/pets",
+			http.Header{"Accept": {rest.MediaTypeCSV}},
+			nil,
+		).Must(t)
+		assert.True(t, strings.HasPrefix(resp.Data.Header().Get("Content-Type"), rest.MediaTypeCSV))
+
+		records, err := csv.NewReader(strings.NewReader(*resp.Value)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 26)
+		assert.Equal(t, rest.PetExportColumns, records[0])
+
+		column := slices.Index(records[0], "owner.name")
+		require.NotEqual(t, -1, column)
+		for _, record := range records[1:] {
+			assert.Equal(t, owner.Name, record[column])
+		}
+
+		resp = enttest.Request[string](ctx, s, http.MethodGet, "/pets?format=csv&fields=name,owner.name", nil).Must(t)
+		records, err = csv.NewReader(strings.NewReader(*resp.Value)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 26)
+		assert.Equal(t, []string{"id", "name", "owner.name"}, records[0])
+		assert.Equal(t, owner.Name, records[1][2])
+
+		// Empty exports still include the header.
+		resp = enttest.Request[string](ctx, s, http.MethodGet, "/pets?format=csv&fields=name&age.gt=100", nil).Must(t)
+		assert.Equal(t, "id,name\n", *resp.Value)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, query := range []string{
+			"format=xml",
+			"format=csv&sort=invalid",
+			"format=ndjson&fields=invalid",
+		} {
+			resp := enttest.Request[string](ctx, s, http.MethodGet, "/pets?"+query, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.Data.Code, query)
+		}
+
+		// Regular (paginated) responses are still returned when JSON is requested.
+		resp := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets?format=json", nil).Must(t)
+		assert.Len(t, resp.Value.Content, 10)
+		assert.Equal(t, 25, resp.Value.TotalCount)
+	})
+}
+
+func TestHandler_CursorPagination(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+	posts := db.Post.CreateBulk(enttest.Multiple(func(db *ent.Client) *ent.PostCreate {
+		return newPost(db, user1)
+	}, db, 25)...).SaveX(ctx)
+
+	collect := func(t *testing.T, uri string) (pages []*rest.CursorResponse[ent.Post]) {
+		t.Helper()
+
+		for {
+			resp := enttest.Request[rest.CursorResponse[ent.Post]](ctx, s, http.MethodGet, uri, nil).Must(t)
+			require.Equal(t, http.StatusOK, resp.Data.Code)
+			pages = append(pages, resp.Value)
+
+			if resp.Value.IsLastPage {
+				assert.Nil(t, resp.Value.NextCursor)
+				return pages
+			}
+
+			require.NotNil(t, resp.Value.NextCursor)
+			require.Less(t, len(pages), len(posts))
+			uri = strings.Split(uri, "&cursor=")[0] + "&cursor=" + url.QueryEscape(*resp.Value.NextCursor)
+		}
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		t.Parallel()
+
+		pages := collect(t, "/posts?per_page=10")
+		require.Len(t, pages, 3)
+		assert.Nil(t, pages[0].PrevCursor)
+
+		var ids []int
+		for _, page := range pages {
+			for _, p := range page.Content {
+				ids = append(ids, p.ID)
+			}
+		}
+		require.Len(t, ids, len(posts))
+		for i := range posts {
+			assert.Equal(t, posts[i].ID, ids[i])
+		}
+	})
+
+	t.Run("forward-desc", func(t *testing.T) {
+		t.Parallel()
+
+		pages := collect(t, "/posts?per_page=7&sort=created_at&order=desc")
+		require.Len(t, pages, 4)
+
+		var ids []int
+		for _, page := range pages {
+			for _, p := range page.Content {
+				ids = append(ids, p.ID)
+			}
+		}
+		require.Len(t, ids, len(posts))
+		for i := range posts {
+			assert.Equal(t, posts[len(posts)-1-i].ID, ids[i])
+		}
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		t.Parallel()
+
+		pages := collect(t, "/posts?per_page=10")
+		require.Len(t, pages, 3)
+		require.NotNil(t, pages[2].PrevCursor)
+
+		resp := enttest.Request[rest.CursorResponse[ent.Post]](
+			ctx, s,
+			http.MethodGet,
+			"/posts?per_page=10&cursor="+url.QueryEscape(*pages[2].PrevCursor),
+			nil,
+		).Must(t)
+		assert.Equal(t, pages[1].Content, resp.Value.Content)
+		assert.NotNil(t, resp.Value.PrevCursor)
+		assert.NotNil(t, resp.Value.NextCursor)
+	})
+
+	t.Run("edge", func(t *testing.T) {
+		t.Parallel()
+
+		pages := collect(t, "/users/"+user1.ID.String()+"/posts?per_page=20")
+		require.Len(t, pages, 2)
+		assert.Len(t, pages[0].Content, 20)
+		assert.Len(t, pages[1].Content, 5)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		cursor := *collect(t, "/posts?per_page=10")[0].NextCursor
+
+		for _, uri := range []string{
+			"/posts?page=2",
+			"/posts?sort=random",
+			"/posts?cursor=invalid",
+			"/posts?sort=created_at&cursor=" + url.QueryEscape(cursor),
+		} {
+			resp := enttest.Request[rest.CursorResponse[ent.Post]](ctx, s, http.MethodGet, uri, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.Data.Code, uri)
+		}
+	})
+}
+
+func TestHandler_SparseFieldsets(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServerDSN(t, testTimeDSN, nil)
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetOwner(user1).AddCategories(newCategory(db).SaveX(ctx)).SaveX(ctx)
+	newPost(db, user1).SaveX(ctx)
+	newPost(db, user1).SaveX(ctx)
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		resp := enttest.Request[map[string]any](
+			ctx, s,
+			http.MethodGet,
+			"/pets/"+strconv.Itoa(pet1.ID)+"?fields=name,owner.name",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.ElementsMatch(t, []string{"id", "name", "edges"}, slices.Collect(maps.Keys(*resp.Value)))
+		assert.Equal(t, pet1.Name, (*resp.Value)["name"])
+
+		edges := (*resp.Value)["edges"].(map[string]any)
+		assert.ElementsMatch(t, []string{"owner"}, slices.Collect(maps.Keys(edges)))
+
+		owner := edges["owner"].(map[string]any)
+		assert.Equal(t, user1.ID.String(), owner["id"])
+		assert.Equal(t, user1.Name, owner["name"])
+		assert.NotContains(t, owner, "email")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		resp := enttest.Request[map[string]any](
+			ctx, s,
+			http.MethodGet,
+			"/pets?fields=age&fields=categories",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Contains(t, *resp.Value, "total_count")
+
+		content := (*resp.Value)["content"].([]any)
+		require.Len(t, content, 1)
+
+		result := content[0].(map[string]any)
+		assert.ElementsMatch(t, []string{"id", "age", "edges"}, slices.Collect(maps.Keys(result)))
+		assert.ElementsMatch(t, []string{"categories"}, slices.Collect(maps.Keys(result["edges"].(map[string]any))))
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+
+		resp := enttest.Request[rest.CursorResponse[ent.Post]](
+			ctx, s,
+			http.MethodGet,
+			"/posts?fields=title&sort=created_at&per_page=1",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Content, 1)
+		assert.NotEmpty(t, resp.Value.Content[0].Title)
+		assert.Empty(t, resp.Value.Content[0].Body)
+		require.NotNil(t, resp.Value.NextCursor)
+
+		next := enttest.Request[rest.CursorResponse[ent.Post]](
+			ctx, s,
+			http.MethodGet,
+			"/posts?fields=title&sort=created_at&per_page=1&cursor="+url.QueryEscape(*resp.Value.NextCursor),
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, next.Data.Code)
+		require.Len(t, next.Value.Content, 1)
+		assert.NotEqual(t, resp.Value.Content[0].ID, next.Value.Content[0].ID)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, uri := range []string{
+			"/pets?fields=invalid",
+			"/pets/" + strconv.Itoa(pet1.ID) + "?fields=owner.password_hashed",
+			"/users/" + user1.ID.String() + "?fields=password_hashed",
+		} {
+			resp := enttest.Request[map[string]any](ctx, s, http.MethodGet, uri, http.NoBody)
+			assert.Equal(t, http.StatusBadRequest, resp.Data.Code, uri)
+		}
+	})
+}
+
+func TestHandler_Include(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetOwner(user1).SaveX(ctx)
+	pet2 := newPet(db).SetOwner(user1).AddFriends(pet1).SaveX(ctx)
+	post1 := newPost(db, user1).SaveX(ctx)
+
+	t.Run("read", func(t *testing.T) {
+		resp := enttest.Request[ent.User](
+			ctx, s,
+			http.MethodGet,
+			"/users/"+user1.ID.String()+"?include=posts",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Edges.Posts, 1)
+		assert.Equal(t, post1.ID, resp.Value.Edges.Posts[0].ID)
+		assert.Len(t, resp.Value.Edges.Pets, 2) // Eager-loaded edges are still loaded.
+
+		// Not included, so shouldn't be loaded.
+		resp = enttest.Request[ent.User](ctx, s, http.MethodGet, "/users/"+user1.ID.String(), http.NoBody).Must(t)
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Empty(t, resp.Value.Edges.Posts)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		resp := enttest.Request[rest.PagedResponse[ent.Pet]](
+			ctx, s,
+			http.MethodGet,
+			"/pets?include=friends,friends.friends&sort=id",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		require.Len(t, resp.Value.Content, 2)
+		require.Len(t, resp.Value.Content[0].Edges.Friends, 1)
+
+		friend := resp.Value.Content[0].Edges.Friends[0]
+		assert.Equal(t, pet2.ID, friend.ID)
+		require.NotNil(t, friend.Edges.Owner) // Included edges are loaded like a regular read.
+		assert.Equal(t, user1.ID, friend.Edges.Owner.ID)
+		require.Len(t, friend.Edges.Friends, 1)
+		assert.Equal(t, pet1.ID, friend.Edges.Friends[0].ID)
+	})
+
+	t.Run("with-fields", func(t *testing.T) {
+		resp := enttest.Request[map[string]any](
+			ctx, s,
+			http.MethodGet,
+			"/pets/"+strconv.Itoa(pet1.ID)+"?include=friends&fields=name,friends.name",
+			http.NoBody,
+		).Must(t)
+
+		require.Equal(t, http.StatusOK, resp.Data.Code)
+		edges := (*resp.Value)["edges"].(map[string]any)
+		assert.ElementsMatch(t, []string{"friends"}, slices.Collect(maps.Keys(edges)))
+
+		friends := edges["friends"].([]any)
+		require.Len(t, friends, 1)
+		assert.ElementsMatch(t, []string{"id", "name", "edges"}, slices.Collect(maps.Keys(friends[0].(map[string]any))))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		resp := enttest.Request[ent.Pet](ctx, s, http.MethodPost, "/pets?include=friends", map[string]any{
+			"name":    gofakeit.PetName(),
+			"age":     gofakeit.Number(1, 15),
+			"type":    pet.TypeCat,
+			"friends": []int{pet1.ID},
+		}).Must(t)
+
+		require.Equal(t, http.StatusCreated, resp.Data.Code)
+		require.Len(t, resp.Value.Edges.Friends, 1)
+		assert.Equal(t, pet1.ID, resp.Value.Edges.Friends[0].ID)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, uri := range []string{
+			"/pets?include=owner",
+			"/pets?include=friends.friends.friends",
+			"/users/" + user1.ID.String() + "?include=posts.author",
+		} {
+			resp := enttest.Request[map[string]any](ctx, s, http.MethodGet, uri, http.NoBody)
+			assert.Equal(t, http.StatusBadRequest, resp.Data.Code, uri)
+		}
+	})
+}
+
+func TestHandler_Import(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	csvHeaders := http.Header{"Content-Type": []string{rest.MediaTypeCSV}}
+	ndjsonHeaders := http.Header{"Content-Type": []string{rest.MediaTypeNDJSON}}
+
+	t.Run("csv", func(t *testing.T) {
+		body := "name,email,password_hashed,enabled,github_id\n" +
+			"csv-1,csv-1@example.com,secret,false,1001\n" +
+			"csv-2,,secret,,\n"
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import", csvHeaders, strings.NewReader(body),
+		).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+		assert.Equal(t, 2, resp.Value.Created)
+		assert.Equal(t, 0, resp.Value.Failed)
+		require.Len(t, resp.Value.Rows, 2)
+
+		u1 := db.User.GetX(ctx, *resp.Value.Rows[0].ID)
+		assert.Equal(t, "csv-1", u1.Name)
+		assert.Equal(t, "csv-1@example.com", *u1.Email)
+		assert.False(t, u1.Enabled)
+		assert.Equal(t, 1001, *u1.GithubID)
+
+		u2 := db.User.GetX(ctx, *resp.Value.Rows[1].ID)
+		assert.Equal(t, "csv-2", u2.Name)
+		assert.Nil(t, u2.Email)
+		assert.True(t, u2.Enabled) // Default value, as empty values are omitted.
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		body := `{"name": "ndjson-1", "password_hashed": "secret"}` + "\n" +
+			`{"name": "ndjson-2", "password_hashed": "secret", "type": "SYSTEM"}` + "\n"
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import", ndjsonHeaders, strings.NewReader(body),
+		).Must(t)
+		assert.Equal(t, 2, resp.Value.Created)
+		assert.Equal(t, user.TypeSystem, db.User.GetX(ctx, *resp.Value.Rows[1].ID).Type)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		part, err := form.CreateFormFile("file", "users.csv")
+		require.NoError(t, err)
+		_, _ = part.Write([]byte("name,password_hashed\nmultipart-1,secret\n"))
+		require.NoError(t, form.Close())
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import",
+			http.Header{"Content-Type": []string{form.FormDataContentType()}}, body,
+		).Must(t)
+		assert.Equal(t, 1, resp.Value.Created)
+		assert.Equal(t, "multipart-1", db.User.GetX(ctx, *resp.Value.Rows[0].ID).Name)
+	})
+
+	t.Run("dry-run", func(t *testing.T) {
+		count := db.User.Query().CountX(ctx)
+		body := "name,password_hashed\ndry-1,secret\ndry-2,\n"
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import?dry_run=true&mode=best-effort", csvHeaders, strings.NewReader(body),
+		).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.True(t, resp.Value.DryRun)
+		assert.Equal(t, 1, resp.Value.Created)
+		assert.Equal(t, 1, resp.Value.Failed)
+		assert.Nil(t, resp.Value.Rows[0].ID)
+		assert.Contains(t, resp.Value.Rows[1].Error, "password_hashed")
+		assert.Equal(t, count, db.User.Query().CountX(ctx))
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		count := db.User.Query().CountX(ctx)
+		body := "name,password_hashed,github_id\natomic-1,secret,2001\natomic-2,secret,2001\natomic-3,secret,\n"
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import", csvHeaders, strings.NewReader(body),
+		).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, 0, resp.Value.Created)
+		assert.Equal(t, 1, resp.Value.Failed)
+		assert.NotEmpty(t, resp.Value.Rows[1].Error)
+		for _, row := range resp.Value.Rows {
+			assert.Nil(t, row.ID)
+		}
+		assert.Equal(t, count, db.User.Query().CountX(ctx))
+
+		// Rows which fail to decode prevent all rows from being created.
+		resp = enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import", ndjsonHeaders,
+			strings.NewReader(`{"name": "atomic-4", "password_hashed": "secret"}`+"\n"+`{"name": 1}`+"\n"),
+		).Must(t)
+		assert.Equal(t, 0, resp.Value.Created)
+		assert.Equal(t, 1, resp.Value.Failed)
+		assert.Equal(t, count, db.User.Query().CountX(ctx))
+	})
+
+	t.Run("best-effort", func(t *testing.T) {
+		body := "name,password_hashed,github_id\neffort-1,secret,3001\neffort-2,secret,3001\neffort-3,,\neffort-4,secret,\n"
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import?mode=best-effort", csvHeaders, strings.NewReader(body),
+		).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+		assert.Equal(t, 2, resp.Value.Created)
+		assert.Equal(t, 2, resp.Value.Failed)
+		assert.NotNil(t, resp.Value.Rows[0].ID)
+		assert.NotEmpty(t, resp.Value.Rows[1].Error)
+		assert.NotEmpty(t, resp.Value.Rows[2].Error)
+		assert.NotNil(t, resp.Value.Rows[3].ID)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[map[string]any](
+			ctx, s, http.MethodPost, "/users/import?mode=invalid", csvHeaders, strings.NewReader("name\nfoo\n"),
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		resp = enttest.RequestWithHeaders[map[string]any](
+			ctx, s, http.MethodPost, "/users/import", csvHeaders, strings.NewReader("name,password_hashed\n"),
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+
+		resp = enttest.RequestWithHeaders[map[string]any](
+			ctx, s, http.MethodPost, "/users/import",
+			http.Header{"Content-Type": []string{"application/xml"}}, strings.NewReader("<users />"),
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.Data.Code)
+	})
+}
+
+func TestHandler_ImportLimits(t *testing.T) {
+	// Not parallel, as the import limits are changed.
+	maxRows, maxBytes := rest.DefaultImportMaxRows, rest.DefaultImportMaxBytes
+	rest.DefaultImportMaxRows, rest.DefaultImportMaxBytes = 2, 256
+	t.Cleanup(func() { rest.DefaultImportMaxRows, rest.DefaultImportMaxBytes = maxRows, maxBytes })
+
+	ctx, db, s := newRestServer(t, nil)
+	t.Cleanup(func() { db.Close() })
+
+	headers := http.Header{"Content-Type": []string{rest.MediaTypeCSV}}
+
+	resp := enttest.RequestWithHeaders[map[string]any](
+		ctx, s, http.MethodPost, "/users/import", headers,
+		strings.NewReader("name,password_hashed\na,secret\nb,secret\nc,secret\n"),
+	)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	assert.Contains(t, resp.Error.Error, "too many rows")
+
+	resp = enttest.RequestWithHeaders[map[string]any](
+		ctx, s, http.MethodPost, "/users/import", headers,
+		strings.NewReader("name,password_hashed\n"+strings.Repeat("a", 300)+",secret\n"),
+	)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, http.StatusBadRequest, resp.Data.Code)
+	assert.Contains(t, resp.Error.Error, "maximum size")
+	assert.Zero(t, db.User.Query().CountX(ctx))
+
+	t.Run("idempotency", func(t *testing.T) {
+		// Imports with an "Idempotency-Key" header are limited by the import limit, not the
+		// (usually smaller) decode limit.
+		decodeMaxBytes := rest.DefaultDecodeMaxBytes
+		rest.DefaultDecodeMaxBytes = 16
+		t.Cleanup(func() { rest.DefaultDecodeMaxBytes = decodeMaxBytes })
+
+		db := newClient(t)
+		t.Cleanup(func() { db.Close() })
+		s := enttest.NewServer(t, db, &rest.ServerConfig{IdempotencyStore: rest.NewMemoryIdempotencyStore(0)})
+		headers := http.Header{
+			"Content-Type":    []string{rest.MediaTypeCSV},
+			"Idempotency-Key": []string{gofakeit.UUID()},
+		}
+
+		resp := enttest.RequestWithHeaders[rest.ImportResponse[uuid.UUID]](
+			ctx, s, http.MethodPost, "/users/import", headers,
+			strings.NewReader("name,password_hashed\nidempotent,secret\n"),
+		).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+		assert.Equal(t, 1, resp.Value.Created)
+	})
+}
+
+func TestHandler_Encoding(t *testing.T) {
+	t.Parallel()
+
+	// Wraps JSON in an envelope, so it can be distinguished from regular JSON responses.
+	const mediaType = "application/vnd.kitchensink+json"
+	type envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		Encoders: map[string]rest.Encoder{
+			mediaType: rest.EncoderFunc(func(w io.Writer, v any) error {
+				data, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				return json.NewEncoder(w).Encode(envelope{Data: data})
+			}),
+		},
+		Decoders: map[string]rest.Decoder{
+			mediaType: rest.DecoderFunc(func(r io.Reader, v any) error {
+				var env envelope
+				if err := json.NewDecoder(r).Decode(&env); err != nil {
+					return err
+				}
+				return json.Unmarshal(env.Data, v)
+			}),
+		},
+	})
+	t.Cleanup(func() { db.Close() })
+
+	owner := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetOwner(owner).SaveX(ctx)
+	path := "/pets/" + strconv.Itoa(pet1.ID)
+
+	decode := func(t *testing.T, resp enttest.Response[envelope]) (v map[string]any) {
+		t.Helper()
+		assert.Equal(t, mediaType, resp.Data.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(resp.Value.Data, &v))
+		return v
+	}
+
+	t.Run("negotiated", func(t *testing.T) {
+		for _, accept := range []string{
+			mediaType,
+			"application/xml, " + mediaType + ";q=0.9",
+			"application/json;q=0.5, " + mediaType,
+		} {
+			resp := enttest.RequestWithHeaders[envelope](
+				ctx, s, http.MethodGet, path, http.Header{"Accept": []string{accept}}, nil,
+			).Must(t)
+			assert.Equal(t, pet1.Name, decode(t, resp)["name"], accept)
+			assert.Equal(t, "Accept", resp.Data.Header().Get("Vary"))
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		for _, accept := range []string{"", "application/json", "*/*", "application/xml, */*;q=0.1", mediaType + ";q=0, */*"} {
+			resp := enttest.RequestWithHeaders[ent.Pet](
+				ctx, s, http.MethodGet, path, http.Header{"Accept": []string{accept}}, nil,
+			).Must(t)
+			assert.Equal(t, "application/json", resp.Data.Header().Get("Content-Type"), accept)
+			assert.Equal(t, pet1.ID, resp.Value.ID)
+		}
+	})
+
+	t.Run("sparse", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[envelope](
+			ctx, s, http.MethodGet, path+"?fields=name", http.Header{"Accept": []string{mediaType}}, nil,
+		).Must(t)
+		v := decode(t, resp)
+		assert.Equal(t, pet1.Name, v["name"])
+		assert.NotContains(t, v, "age")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[envelope](
+			ctx, s, http.MethodGet, "/pets/999999", http.Header{"Accept": []string{mediaType}}, nil,
+		)
+		assert.Equal(t, http.StatusNotFound, resp.Data.Code)
+		assert.InDelta(t, http.StatusNotFound, decode(t, resp)["code"], 0)
+	})
+
+	t.Run("not-acceptable", func(t *testing.T) {
+		count := db.Pet.Query().CountX(ctx)
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s, http.MethodPost, "/pets", http.Header{"Accept": []string{"application/xml"}},
+			map[string]any{"name": "rejected", "age": 1, "type": pet.TypeDog, "owner": owner.ID},
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusNotAcceptable, resp.Data.Code)
+		assert.Equal(t, count, db.Pet.Query().CountX(ctx)) // Rejected before creating the pet.
+	})
+
+	t.Run("decoder", func(t *testing.T) {
+		body, err := json.Marshal(map[string]any{
+			"data": map[string]any{"name": "decoded", "age": 2, "type": pet.TypeCat, "owner": owner.ID},
+		})
+		require.NoError(t, err)
+
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s, http.MethodPost, "/pets", http.Header{"Content-Type": []string{mediaType}}, bytes.NewReader(body),
+		).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+		assert.Equal(t, "decoded", resp.Value.Name)
+	})
+
+	t.Run("unsupported-media-type", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s, http.MethodPost, "/pets", http.Header{"Content-Type": []string{"application/xml"}},
+			strings.NewReader("<pet><name>unsupported</name></pet>"),
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.Data.Code)
+	})
+}
+
+func TestNewServer_Hooks(t *testing.T) {
+	t.Parallel()
+
+	db := newClient(t)
+	t.Cleanup(func() { db.Close() })
+
+	_, err := rest.NewServer(db, nil)
+	require.NoError(t, err)
+
+	// Hooks (e.g. for event streams) would otherwise be registered, and run, twice.
+	_, err = rest.NewServer(db, nil)
+	require.Error(t, err)
+}
+
+func TestHandler_Events(t *testing.T) {
+	t.Parallel()
+
+	sqlRegister.Do(func() {
+		sql.Register("sqlite3", &sqlite.Driver{})
+	})
+
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&_pragma=foreign_keys(1)&_time_format=sqlite")
+	require.NoError(t, err)
+	drv.DB().SetMaxOpenConns(1) // In-memory databases aren't shared across connections.
+
+	ctx := context.Background()
+	db := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { db.Close() })
+
+	// Streams need a real connection, as the recorder used by enttest doesn't stream.
+	srv, err := rest.NewServer(db, nil)
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	type event struct {
+		ID   string
+		Op   string
+		Data map[string]any
+	}
+
+	stream := func(t *testing.T, query, lastID string) <-chan event {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/pets/events?"+query, http.NoBody)
+		require.NoError(t, err)
+		if lastID != "" {
+			req.Header.Set("Last-Event-ID", lastID)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, rest.MediaTypeEventStream, resp.Header.Get("Content-Type"))
+
+		events := make(chan event, 10)
+		go func() {
+			defer close(events)
+			defer resp.Body.Close()
+
+			var e event
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				switch {
+				case line == "":
+					if e.Op != "" {
+						events <- e
+					}
+					e = event{}
+				case strings.HasPrefix(line, "id: "):
+					e.ID = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "event: "):
+					e.Op = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e.Data)
+				}
+			}
+		}()
+		return events
+	}
+
+	next := func(t *testing.T, events <-chan event, op string, id int) event {
+		t.Helper()
+		select {
+		case e, ok := <-events:
+			require.True(t, ok, "stream closed")
+			assert.Equal(t, op, e.Op)
+			assert.Equal(t, op, e.Data["op"])
+			assert.Equal(t, "Pet", e.Data["type"])
+			assert.InDelta(t, id, e.Data["id"], 0)
+			assert.NotEmpty(t, e.ID)
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s event", op)
+			return event{}
+		}
+	}
+
+	all := stream(t, "", "")
+	filtered := stream(t, "name.eq=events-match&entity=true", "")
+
+	pet1 := newPet(db).SetName("events-match").SaveX(ctx)
+	e := next(t, all, "create", pet1.ID)
+	assert.NotContains(t, e.Data, "entity")
+	firstID := e.ID
+
+	e = next(t, filtered, "create", pet1.ID)
+	if assert.Contains(t, e.Data, "entity") {
+		assert.Equal(t, "events-match", e.Data["entity"].(map[string]any)["name"])
+	}
+
+	// Non-matching entities are filtered out, though deletes are always sent.
+	pet2 := newPet(db).SetName("events-other").SaveX(ctx)
+	next(t, all, "create", pet2.ID)
+	db.Pet.UpdateOne(pet2).SetAge(3).ExecX(ctx)
+	next(t, all, "update", pet2.ID)
+	db.Pet.DeleteOne(pet1).ExecX(ctx)
+	next(t, all, "delete", pet1.ID)
+	e = next(t, filtered, "delete", pet1.ID)
+	assert.NotContains(t, e.Data, "entity")
+
+	// Events are only sent once the transaction is committed.
+	tx, err := db.Tx(ctx)
+	require.NoError(t, err)
+	_, err = tx.Pet.Create().SetName("events-rollback").SetAge(1).SetType(pet.TypeDog).Save(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	tx, err = db.Tx(ctx)
+	require.NoError(t, err)
+	pet3, err := tx.Pet.Create().SetName("events-commit").SetAge(1).SetType(pet.TypeDog).Save(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	next(t, all, "create", pet3.ID)
+
+	t.Run("resume", func(t *testing.T) {
+		resumed := stream(t, "", firstID)
+		next(t, resumed, "create", pet2.ID)
+		next(t, resumed, "update", pet2.ID)
+		next(t, resumed, "delete", pet1.ID)
+		next(t, resumed, "create", pet3.ID)
+	})
+
+	t.Run("invalid-last-event-id", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/pets/events", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "invalid")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+// newSingleConnClient returns a client which uses a single connection, for tests which
+// query the database concurrently (e.g. from hooks within transactions), as in-memory
+// databases aren't shared across connections.
+func newSingleConnClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	sqlRegister.Do(func() {
+		sql.Register("sqlite3", &sqlite.Driver{})
+	})
+
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&_pragma=foreign_keys(1)&_time_format=sqlite")
+	require.NoError(t, err)
+	drv.DB().SetMaxOpenConns(1)
+
+	db := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestHandler_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	const secret = "test-secret"
+
+	type webhook struct {
+		header http.Header
+		event  map[string]any
+	}
+
+	// Receives webhooks, failing with a 500 while fail is set.
+	var fail atomic.Bool
+	received := make(chan webhook, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, rest.VerifyWebhook(secret, r.Header, body, time.Minute))
+
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var event map[string]any
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received <- webhook{header: r.Header, event: event}
+	}))
+	t.Cleanup(receiver.Close)
+
+	next := func(t *testing.T) webhook {
+		t.Helper()
+		select {
+		case wh := <-received:
+			return wh
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for webhook")
+			return webhook{}
+		}
+	}
+
+	deliver := func(t *testing.T, srv *rest.Server, want int) {
+		t.Helper()
+		attempted, err := srv.DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, want, attempted)
+	}
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := context.Background()
+		db := newSingleConnClient(t)
+		srv, err := rest.NewServer(db, &rest.ServerConfig{
+			Webhooks: []*rest.WebhookSubscription{
+				{ID: "pets", URL: receiver.URL, Secret: secret, Events: []string{"pet.*"}},
+			},
+		})
+		require.NoError(t, err)
+
+		// Only pet events are delivered to the subscription.
+		newUser(db).SaveX(ctx)
+		deliver(t, srv, 0)
+
+		pet1 := newPet(db).SaveX(ctx)
+		deliver(t, srv, 1)
+
+		wh := next(t)
+		assert.Equal(t, "create", wh.event["op"])
+		assert.Equal(t, "Pet", wh.event["type"])
+		assert.InDelta(t, pet1.ID, wh.event["id"], 0)
+		if assert.Contains(t, wh.event, "entity") {
+			assert.Equal(t, pet1.Name, wh.event["entity"].(map[string]any)["name"])
+		}
+
+		delivery, err := srv.WebhookDelivery(ctx, wh.header.Get("webhook-id"))
+		require.NoError(t, err)
+		require.NotNil(t, delivery)
+		assert.Equal(t, rest.WebhookDelivered, delivery.Status)
+		assert.Equal(t, "pet.create", delivery.Event)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusOK, delivery.LastStatusCode)
+
+		// Deliveries within transactions are only delivered once committed.
+		tx, err := db.Tx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, tx.Pet.UpdateOneID(pet1.ID).SetAge(2).Exec(ctx))
+		require.NoError(t, tx.Rollback())
+		deliver(t, srv, 0)
+
+		db.Pet.DeleteOne(pet1).ExecX(ctx)
+		deliver(t, srv, 1)
+		wh = next(t)
+		assert.Equal(t, "delete", wh.event["op"])
+		assert.NotContains(t, wh.event, "entity")
+	})
+
+	t.Run("ent", func(t *testing.T) {
+		ctx := context.Background()
+		db := newSingleConnClient(t)
+		outbox := rest.NewEntWebhookOutbox(db)
+		srv, err := rest.NewServer(db, &rest.ServerConfig{
+			WebhookStore:  rest.NewEntWebhookSubscriptionStore(db),
+			WebhookOutbox: outbox,
+		})
+		require.NoError(t, err)
+
+		sub := db.WebhookSubscription.Create().
+			SetURL(receiver.URL).
+			SetSecret(secret).
+			SetEvents([]string{"user.create", "pet.update"}).
+			SaveX(ctx)
+
+		// Deliveries are stored within the transaction of the change.
+		tx, err := db.Tx(ctx)
+		require.NoError(t, err)
+		_, err = tx.User.Create().
+			SetName("Webhook User").
+			SetEmail("webhook@example.com").
+			SetType(user.TypeUser).
+			SetPasswordHashed(gofakeit.Password(true, true, true, true, true, 15)).
+			Save(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.WebhookDelivery.Query().CountX(ctx))
+		require.NoError(t, tx.Rollback())
+		assert.Equal(t, 0, db.WebhookDelivery.Query().CountX(ctx))
+
+		owner := newUser(db).SaveX(ctx)
+		pet1 := newPet(db).SetOwner(owner).SaveX(ctx) // Not subscribed.
+		assert.Equal(t, 1, db.WebhookDelivery.Query().CountX(ctx))
+
+		deliver(t, srv, 1)
+		wh := next(t)
+		assert.Equal(t, "create", wh.event["op"])
+		assert.Equal(t, "User", wh.event["type"])
+		assert.Equal(t, owner.ID.String(), wh.event["id"])
+
+		// Failed deliveries are retried with a backoff.
+		fail.Store(true)
+		db.Pet.UpdateOne(pet1).SetAge(3).ExecX(ctx)
+		deliver(t, srv, 1)
+
+		delivery := db.WebhookDelivery.Query().Where(webhookdelivery.Event("pet.update")).OnlyX(ctx)
+		assert.Equal(t, string(rest.WebhookPending), delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+		assert.NotEmpty(t, delivery.LastError)
+		assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+		deliver(t, srv, 0)
+
+		fail.Store(false)
+		delivery.Update().SetNextAttemptAt(time.Now()).ExecX(ctx)
+		deliver(t, srv, 1)
+		wh = next(t)
+		assert.Equal(t, "update", wh.event["op"])
+		assert.Equal(t, delivery.Key, wh.header.Get("webhook-id"))
+
+		status, err := srv.WebhookDelivery(ctx, delivery.Key)
+		require.NoError(t, err)
+		assert.Equal(t, rest.WebhookDelivered, status.Status)
+		assert.Equal(t, 2, status.Attempts)
+		assert.Empty(t, status.LastError)
+
+		// Deliveries to removed subscriptions fail.
+		db.Pet.UpdateOne(pet1).SetAge(4).ExecX(ctx)
+		db.WebhookSubscription.DeleteOne(sub).ExecX(ctx)
+		deliver(t, srv, 1)
+		assert.Equal(
+			t,
+			string(rest.WebhookFailed),
+			db.WebhookDelivery.Query().Where(webhookdelivery.Event("pet.update"), webhookdelivery.Attempts(1)).OnlyX(ctx).Status,
+		)
+
+		n, err := outbox.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		db := newSingleConnClient(t)
+		srv, err := rest.NewServer(db, &rest.ServerConfig{
+			Webhooks: []*rest.WebhookSubscription{{ID: "all", URL: receiver.URL, Secret: secret}},
+		})
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+		go func() { done <- srv.RunWebhooks(ctx) }()
+
+		// New deliveries are delivered without waiting for the poll interval.
+		pet1 := newPet(db).SaveX(ctx)
+		wh := next(t)
+		assert.InDelta(t, pet1.ID, wh.event["id"], 0)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestHandler_Audit(t *testing.T) {
+	t.Parallel()
+
+	sink := rest.NewMemoryAuditSink()
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		AuditSink:        sink,
+		ActorFromRequest: func(r *http.Request) string { return r.Header.Get("X-Actor") },
+	})
+	t.Cleanup(func() { db.Close() })
+
+	headers := http.Header{"X-Actor": {"admin"}, "X-Request-Id": {"req-1"}}
+
+	changes := func(record *rest.AuditRecord) map[string][2]any {
+		out := map[string][2]any{}
+		for _, change := range record.Changes {
+			out[change.Field] = [2]any{change.Before, change.After}
+		}
+		return out
+	}
+
+	t.Run("create", func(t *testing.T) {
+		sink.Reset()
+		resp := enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPost, "/users", headers, map[string]any{
+			"name":            "John Doe",
+			"type":            "USER",
+			"password_hashed": "secret",
+		}).Must(t)
+
+		records := sink.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, rest.OperationCreate, records[0].Operation)
+		assert.Equal(t, rest.AuditCreate, records[0].Action)
+		assert.Equal(t, "User", records[0].Type)
+		assert.Equal(t, resp.Value.ID, records[0].EntityID)
+		assert.Equal(t, "admin", records[0].Actor)
+		assert.Equal(t, "req-1", records[0].RequestID)
+
+		diff := changes(records[0])
+		assert.Equal(t, [2]any{nil, "John Doe"}, diff["name"])
+		assert.Equal(t, [2]any{nil, rest.AuditRedacted}, diff["password_hashed"])
+	})
+
+	t.Run("update", func(t *testing.T) {
+		user1 := newUser(db).SaveX(ctx)
+		sink.Reset()
+
+		enttest.RequestWithHeaders[ent.User](ctx, s, http.MethodPatch, "/users/"+user1.ID.String(), headers, map[string]any{
+			"name":            "Jane Doe",
+			"password_hashed": "updated",
+		}).Must(t)
+
+		records := sink.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, rest.OperationUpdate, records[0].Operation)
+		assert.Equal(t, rest.AuditUpdate, records[0].Action)
+
+		// Only changed fields are included.
+		diff := changes(records[0])
+		assert.Equal(t, [2]any{user1.Name, "Jane Doe"}, diff["name"])
+		assert.Equal(t, [2]any{rest.AuditRedacted, rest.AuditRedacted}, diff["password_hashed"])
+		assert.NotContains(t, diff, "email")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		pet1 := newPet(db).SaveX(ctx)
+		category1 := newCategory(db).SaveX(ctx)
+		sink.Reset()
+
+		enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, "/pets/"+strconv.Itoa(pet1.ID), headers, nil).Must(t)
+		enttest.RequestWithHeaders[string](ctx, s, http.MethodDelete, "/categories/"+strconv.Itoa(category1.ID), headers, nil).Must(t)
+
+		records := sink.Records()
+		require.Len(t, records, 2)
+		assert.Equal(t, rest.AuditDelete, records[0].Action)
+		assert.Equal(t, [2]any{pet1.Name, nil}, changes(records[0])["name"])
+
+		// Soft-deletes are recorded as deletes, including the soft-delete field.
+		assert.Equal(t, rest.AuditDelete, records[1].Action)
+		assert.Equal(t, "Category", records[1].Type)
+		diff := changes(records[1])
+		require.Contains(t, diff, "deleted_at")
+		assert.Nil(t, diff["deleted_at"][0])
+		assert.NotContains(t, diff, "name")
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		sink.Reset()
+
+		enttest.RequestWithHeaders[[]*ent.Pet](ctx, s, http.MethodPost, "/pets/bulk", headers, []map[string]any{
+			{"name": "Alfalfa", "age": 1, "type": "DOG"},
+			{"name": "Buckwheat", "age": 2, "type": "CAT"},
+		}).Must(t)
+
+		records := sink.Records()
+		require.Len(t, records, 2)
+		for _, record := range records {
+			assert.Equal(t, rest.OperationCreateBulk, record.Operation)
+			assert.Equal(t, rest.AuditCreate, record.Action)
+			assert.Equal(t, "req-1", record.RequestID)
+		}
+	})
+
+	t.Run("non-api", func(t *testing.T) {
+		sink.Reset()
+
+		// Changes made outside of the API aren't recorded.
+		newPet(db).SaveX(ctx)
+		assert.Empty(t, sink.Records())
+	})
+
+	t.Run("sink-error", func(t *testing.T) {
+		var failed atomic.Int64
+		ctx, db, s := newRestServer(t, &rest.ServerConfig{
+			AuditSink: failingAuditSink{},
+			AuditErrorHandler: func(_ context.Context, records []*rest.AuditRecord, _ error) {
+				failed.Add(int64(len(records)))
+			},
+		})
+		t.Cleanup(func() { db.Close() })
+
+		// The changes have already been made, so sink errors don't fail the request.
+		resp := enttest.Request[[]*ent.Pet](ctx, s, http.MethodPost, "/pets/bulk", []map[string]any{
+			{"name": "Alfalfa", "age": 1, "type": "DOG"},
+			{"name": "Buckwheat", "age": 2, "type": "CAT"},
+		}).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+
+		pet1 := (*resp.Value)[0]
+		enttest.Request[ent.Pet](ctx, s, http.MethodPatch, "/pets/"+strconv.Itoa(pet1.ID), map[string]any{"age": 5}).Must(t)
+		assert.Equal(t, 5, db.Pet.GetX(ctx, pet1.ID).Age)
+		assert.Equal(t, int64(3), failed.Load())
+	})
+}
+
+type failingAuditSink struct{}
+
+func (failingAuditSink) Write(context.Context, []*rest.AuditRecord) error {
+	return errors.New("sink unavailable")
+}
+
+func TestHandler_History(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		ActorFromRequest: func(r *http.Request) string { return r.Header.Get("X-Actor") },
+	})
+	t.Cleanup(func() { db.Close() })
+
+	headers := http.Header{"X-Actor": {"admin"}, "X-Request-Id": {"req-1"}}
+
+	pet1 := enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPost, "/pets", headers, map[string]any{
+		"name": "Kuro",
+		"age":  2,
+		"type": "DOG",
+	}).Must(t).Value
+	path := "/pets/" + strconv.Itoa(pet1.ID)
+
+	enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPatch, path, headers, map[string]any{"name": "Shiro"}).Must(t)
+
+	// Changes made outside of the API are also recorded, without an actor.
+	db.Pet.UpdateOneID(pet1.ID).SetAge(3).ExecX(ctx)
+
+	t.Run("list", func(t *testing.T) {
+		resp := enttest.Request[rest.PagedResponse[rest.HistoryEntry]](ctx, s, http.MethodGet, path+"/history", nil).Must(t)
+		require.Len(t, resp.Value.Content, 3)
+		assert.Equal(t, 3, resp.Value.TotalCount)
+
+		// Newest first.
+		assert.Equal(t, 3, resp.Value.Content[0].Version)
+		assert.Equal(t, rest.AuditUpdate, resp.Value.Content[0].Action)
+		assert.Empty(t, resp.Value.Content[0].Actor)
+
+		assert.Equal(t, 1, resp.Value.Content[2].Version)
+		assert.Equal(t, rest.AuditCreate, resp.Value.Content[2].Action)
+		assert.Equal(t, "admin", resp.Value.Content[2].Actor)
+		assert.Equal(t, "req-1", resp.Value.Content[2].RequestID)
+
+		resp = enttest.Request[rest.PagedResponse[rest.HistoryEntry]](ctx, s, http.MethodGet, path+"/history?per_page=2&page=2", nil).Must(t)
+		require.Len(t, resp.Value.Content, 1)
+		assert.True(t, resp.Value.IsLastPage)
+		assert.Equal(t, 1, resp.Value.Content[0].Version)
+
+		respErr := enttest.Request[rest.PagedResponse[rest.HistoryEntry]](ctx, s, http.MethodGet, path+"/history?per_page=2&page=3", nil)
+		assert.Equal(t, http.StatusBadRequest, respErr.Data.Code)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		resp := enttest.Request[rest.HistoryEntry](ctx, s, http.MethodGet, path+"/history/2", nil).Must(t)
+		assert.Equal(t, 2, resp.Value.Version)
+
+		var snapshot ent.Pet
+		require.NoError(t, json.Unmarshal(resp.Value.Entity, &snapshot))
+		assert.Equal(t, "Shiro", snapshot.Name)
+		assert.Equal(t, 2, snapshot.Age)
+
+		respErr := enttest.Request[rest.HistoryEntry](ctx, s, http.MethodGet, path+"/history/10", nil)
+		assert.Equal(t, http.StatusNotFound, respErr.Data.Code)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		resp := enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPost, path+"/history/1/restore", headers, nil).Must(t)
+		assert.Equal(t, http.StatusOK, resp.Data.Code)
+		assert.Equal(t, "Kuro", resp.Value.Name)
+		assert.Equal(t, 2, resp.Value.Age)
+		// The version field is only incremented by the API (update, and restore).
+		assert.Equal(t, pet1.Version+2, resp.Value.Version)
+
+		// Restoring records a new version.
+		entry := enttest.Request[rest.HistoryEntry](ctx, s, http.MethodGet, path+"/history/4", nil).Must(t)
+		assert.Equal(t, rest.AuditUpdate, entry.Value.Action)
+		assert.Equal(t, "admin", entry.Value.Actor)
+	})
+
+	t.Run("restore-if-match", func(t *testing.T) {
+		version := db.Pet.GetX(ctx, pet1.ID).Version
+
+		// Restoring honors the "If-Match" header, like other updates.
+		resp := enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPost,
+			path+"/history/2/restore",
+			http.Header{"If-Match": {strconv.Quote(strconv.Itoa(version - 1))}},
+			nil,
+		)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.Data.Code)
+		assert.Equal(t, "Kuro", db.Pet.GetX(ctx, pet1.ID).Name)
+
+		resp = enttest.RequestWithHeaders[ent.Pet](
+			ctx, s,
+			http.MethodPost,
+			path+"/history/2/restore",
+			http.Header{"If-Match": {strconv.Quote(strconv.Itoa(version))}},
+			nil,
+		).Must(t)
+		assert.Equal(t, "Shiro", resp.Value.Name)
+		assert.Equal(t, version+1, resp.Value.Version)
+	})
+
+	t.Run("soft-delete", func(t *testing.T) {
+		category1 := newCategory(db).SaveX(ctx)
+		categoryPath := "/categories/" + strconv.Itoa(category1.ID)
+
+		enttest.Request[string](ctx, s, http.MethodDelete, categoryPath, nil).Must(t)
+
+		resp := enttest.Request[rest.HistoryEntry](ctx, s, http.MethodGet, categoryPath+"/history/2", nil).Must(t)
+		assert.Equal(t, rest.AuditDelete, resp.Value.Action)
+
+		// Soft-deleted entities can't be restored to a previous version (they must be
+		// restored using the restore operation first).
+		respErr := enttest.Request[ent.Category](ctx, s, http.MethodPost, categoryPath+"/history/1/restore", nil)
+		assert.Equal(t, http.StatusNotFound, respErr.Data.Code)
+		assert.NotNil(t, db.Category.GetX(ctx, category1.ID).DeletedAt)
+
+		enttest.Request[ent.Category](ctx, s, http.MethodPost, categoryPath+"/restore", nil).Must(t)
+		enttest.Request[ent.Category](ctx, s, http.MethodPost, categoryPath+"/history/1/restore", nil).Must(t)
+		enttest.Request[ent.Category](ctx, s, http.MethodGet, categoryPath, nil).Must(t)
+	})
+
+	t.Run("tx", func(t *testing.T) {
+		tx, err := db.Tx(ctx)
+		require.NoError(t, err)
+		pet2 := tx.Pet.Create().SetName("Alfalfa").SetAge(1).SetType(pet.TypeCat).SaveX(ctx)
+		require.NoError(t, tx.Rollback())
+
+		// History of rolled back changes isn't recorded.
+		resp := enttest.Request[rest.PagedResponse[rest.HistoryEntry]](ctx, s, http.MethodGet, "/pets/"+strconv.Itoa(pet2.ID)+"/history", nil)
+		require.NotNil(t, resp.Value)
+		assert.Empty(t, resp.Value.Content)
+	})
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		RateLimitKey: func(r *http.Request) string { return r.Header.Get("X-Api-Key") },
+	})
+	t.Cleanup(func() { db.Close() })
+
+	post1 := newPost(db, newUser(db).SaveX(ctx)).SaveX(ctx)
+
+	read := func(key string) enttest.Response[ent.Post] {
+		return enttest.RequestWithHeaders[ent.Post](ctx, s, http.MethodGet, "/posts/"+strconv.Itoa(post1.ID), http.Header{"X-Api-Key": {key}}, nil)
+	}
+
+	for i := range 3 {
+		resp := read("client-1").Must(t)
+		assert.Equal(t, "3", resp.Data.Header().Get("X-Ratelimit-Limit"))
+		assert.Equal(t, strconv.Itoa(2-i), resp.Data.Header().Get("X-Ratelimit-Remaining"))
+		assert.NotEmpty(t, resp.Data.Header().Get("X-Ratelimit-Reset"))
+	}
+
+	resp := read("client-1")
+	assert.Equal(t, http.StatusTooManyRequests, resp.Data.Code)
+	assert.Equal(t, "0", resp.Data.Header().Get("X-Ratelimit-Remaining"))
+	retryAfter, err := strconv.Atoi(resp.Data.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.Positive(t, retryAfter)
+
+	// Each client is limited separately.
+	read("client-2").Must(t)
+
+	// Each endpoint is limited separately, using the limit of the schema if the operation
+	// doesn't have its own limit.
+	list := enttest.RequestWithHeaders[rest.CursorResponse[ent.Post]](ctx, s, http.MethodGet, "/posts", http.Header{"X-Api-Key": {"client-1"}}, nil).Must(t)
+	assert.Equal(t, "100", list.Data.Header().Get("X-Ratelimit-Limit"))
+
+	// Endpoints which aren't rate limited don't include the headers.
+	pets := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", nil)
+	assert.Empty(t, pets.Data.Header().Get("X-Ratelimit-Limit"))
+}
+
+func TestHandler_Authorizer(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		op     rest.Operation
+		entity string
+		scopes []string
+	}
+
+	var mu sync.Mutex
+	var calls []call
+	var revoked atomic.Bool
+
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		Authorizer: func(r *http.Request, op rest.Operation, entity string, scopes []string) error {
+			mu.Lock()
+			calls = append(calls, call{op: op, entity: entity, scopes: scopes})
+			mu.Unlock()
+
+			token := r.Header.Get("Authorization")
+			if token == "" {
+				return rest.ErrUnauthorized
+			}
+			if revoked.Load() {
+				return errors.New("token revoked")
+			}
+			for _, scope := range scopes {
+				if !slices.Contains(strings.Split(strings.TrimPrefix(token, "Bearer "), ","), scope) {
+					return fmt.Errorf("missing scope %q", scope)
+				}
+			}
+			return nil
+		},
+	})
+	t.Cleanup(func() { db.Close() })
+
+	user1 := newUser(db).SaveX(ctx)
+	pet1 := newPet(db).SetOwner(user1).SaveX(ctx)
+
+	data := map[string]any{
+		"name":  gofakeit.FirstName(),
+		"age":   gofakeit.Number(1, 20),
+		"type":  pet.TypeDog,
+		"owner": user1.ID,
+	}
+
+	create := func(token string) enttest.Response[ent.Pet] {
+		headers := http.Header{}
+		if token != "" {
+			headers.Set("Authorization", "Bearer "+token)
+		}
+		return enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPost, "/pets", headers, data)
+	}
+
+	resp := create("")
+	assert.Equal(t, http.StatusUnauthorized, resp.Data.Code)
+	assert.Equal(t, call{op: rest.OperationCreate, entity: "Pet", scopes: []string{"pets:write"}}, calls[len(calls)-1])
+
+	resp = create("pets:read")
+	assert.Equal(t, http.StatusForbidden, resp.Data.Code)
+	assert.Contains(t, resp.Data.Body.String(), `missing scope \"pets:write\"`)
+	assert.Equal(t, 1, db.Pet.Query().CountX(ctx))
+
+	resp = create("pets:read,pets:write").Must(t)
+	assert.Equal(t, http.StatusCreated, resp.Data.Code)
+
+	// Scopes of edges override the scopes of the schema.
+	owner := enttest.RequestWithHeaders[ent.User](
+		ctx, s, http.MethodGet, "/pets/"+strconv.Itoa(pet1.ID)+"/owner",
+		http.Header{"Authorization": {"Bearer pets:write"}}, nil,
+	)
+	assert.Equal(t, http.StatusForbidden, owner.Data.Code)
+	assert.Equal(t, call{op: rest.OperationRead, entity: "Pet", scopes: []string{"users:read"}}, calls[len(calls)-1])
+
+	// Operations without scopes are still authorized.
+	pets := enttest.Request[rest.PagedResponse[ent.Pet]](ctx, s, http.MethodGet, "/pets", nil)
+	assert.Equal(t, http.StatusUnauthorized, pets.Data.Code)
+	assert.Equal(t, call{op: rest.OperationList, entity: "Pet"}, calls[len(calls)-1])
+
+	// Requests which re-use an idempotency key are still authorized, rather than replaying
+	// the stored response.
+	headers := http.Header{"Authorization": {"Bearer pets:write"}, "Idempotency-Key": {gofakeit.UUID()}}
+	data["name"] = gofakeit.FirstName()
+	resp = enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPost, "/pets", headers, data).Must(t)
+	assert.Equal(t, http.StatusCreated, resp.Data.Code)
+
+	revoked.Store(true)
+	resp = enttest.RequestWithHeaders[ent.Pet](ctx, s, http.MethodPost, "/pets", headers, data)
+	assert.Equal(t, http.StatusForbidden, resp.Data.Code)
+	assert.Empty(t, resp.Data.Header().Get("Idempotent-Replayed"))
+	revoked.Store(false)
+
+	// The spec endpoint is public.
+	spec := enttest.Request[map[string]any](ctx, s, http.MethodGet, "/openapi.json", nil)
+	assert.Equal(t, http.StatusOK, spec.Data.Code)
+}
+
+func TestHandler_AuthorizerNestedCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, s := newRestServer(t, &rest.ServerConfig{
+		Authorizer: func(r *http.Request, op rest.Operation, entity string, scopes []string) error {
+			if op == rest.OperationCreate && entity == "User" {
+				return errors.New("users cannot be created")
+			}
+			if op == rest.OperationCreate && entity == "Pet" && !slices.Contains(scopes, "pets:write") {
+				return fmt.Errorf("unexpected scopes for %q: %v", entity, scopes)
+			}
+			return nil
+		},
+	})
+	t.Cleanup(func() { db.Close() })
+
+	newPetData := func() map[string]any {
+		first := gofakeit.FirstName()
+		return map[string]any{
+			"name": gofakeit.PetName(),
+			"age":  gofakeit.Number(1, 15),
+			"type": pet.TypeCat,
+			"owner": map[string]any{
+				"name":            first,
+				"email":           first + "." + gofakeit.UUID() + "@example.com",
+				"password_hashed": gofakeit.Password(true, true, true, true, true, 15),
+			},
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPost, "/pets", newPetData())
+		assert.Equal(t, http.StatusForbidden, resp.Data.Code)
+		assert.Contains(t, resp.Data.Body.String(), "users cannot be created")
+	})
+
+	t.Run("create-bulk", func(t *testing.T) {
+		data := newPetData()
+		delete(data, "owner")
+
+		resp := enttest.Request[map[string]any](ctx, s, http.MethodPost, "/pets/bulk", []map[string]any{data, newPetData()})
+		assert.Equal(t, http.StatusForbidden, resp.Data.Code)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		pets, err := json.Marshal([]map[string]any{newPetData()})
+		require.NoError(t, err)
+
+		resp := enttest.RequestWithHeaders[map[string]any](
+			ctx, s, http.MethodPost, "/users/import?mode=best-effort",
+			http.Header{"Content-Type": []string{rest.MediaTypeNDJSON}},
+			strings.NewReader(`{"name": "import-1", "password_hashed": "secret"}`+"\n"+
+				`{"name": "import-2", "password_hashed": "secret", "pets": `+string(pets)+`}`+"\n"),
+		)
+		assert.Equal(t, http.StatusForbidden, resp.Data.Code)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		// Nested entities which are only referenced by ID aren't created, so aren't authorized.
+		data := newPetData()
+		data["owner"] = newUser(db).SaveX(ctx).ID
+
+		resp := enttest.Request[ent.Pet](ctx, s, http.MethodPost, "/pets", data).Must(t)
+		assert.Equal(t, http.StatusCreated, resp.Data.Code)
+	})
+
+	assert.Equal(t, 1, db.Pet.Query().CountX(ctx))
+	assert.Equal(t, 1, db.User.Query().CountX(ctx))
+}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"entgo.io/ent/entc/gen"
 	"entgo.io/ent/schema"
@@ -60,6 +61,33 @@ func ValidateAnnotations(nodes ...*gen.Type) error {
 				return err
 			}
 		}
+		if err := validateVersionField(t); err != nil {
+			return err
+		}
+		if err := validateSoftDeleteField(t); err != nil {
+			return err
+		}
+		if err := validateUpsertFields(t); err != nil {
+			return err
+		}
+		if err := validateIdempotencyStore(t); err != nil {
+			return err
+		}
+		if err := validateWebhookStores(t); err != nil {
+			return err
+		}
+		if err := validateHistoryStore(t); err != nil {
+			return err
+		}
+		if err := validateRateLimits(t); err != nil {
+			return err
+		}
+		if err := validateSearch(t); err != nil {
+			return err
+		}
+		if err := validateAggregate(t); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -91,23 +119,46 @@ type Annotation struct {
 
 	// All others.
 
-	Pagination      *bool       `json:",omitempty" ent:"schema,edge"`
-	MinItemsPerPage int         `json:",omitempty" ent:"schema,edge"`
-	MaxItemsPerPage int         `json:",omitempty" ent:"schema,edge"`
-	ItemsPerPage    int         `json:",omitempty" ent:"schema,edge"`
-	EagerLoad       *bool       `json:",omitempty" ent:"edge"`
-	EagerLoadLimit  *int        `json:",omitempty" ent:"edge"`
-	EdgeEndpoint    *bool       `json:",omitempty" ent:"edge"`
-	EdgeUpdateBulk  bool        `json:",omitempty" ent:"edge"`
-	Filter          Predicate   `json:",omitempty" ent:"schema,edge,field"`
-	FilterGroup     string      `json:",omitempty" ent:"edge,field"`
-	DisableHandler  bool        `json:",omitempty" ent:"schema,edge"`
-	Sortable        bool        `json:",omitempty" ent:"field"`
-	DefaultSort     *string     `json:",omitempty" ent:"schema"`
-	DefaultOrder    *SortOrder  `json:",omitempty" ent:"schema"`
-	Skip            bool        `json:",omitempty" ent:"schema,edge,field"`
-	AllowClientIDs  *bool       `json:",omitempty" ent:"schema"`
-	Operations      []Operation `json:",omitempty" ent:"schema,edge"`
+	Pagination       *bool          `json:",omitempty" ent:"schema,edge"`
+	PaginationMode   PaginationMode `json:",omitempty" ent:"schema,edge"`
+	CountStrategy    CountStrategy  `json:",omitempty" ent:"schema"`
+	MinItemsPerPage  int            `json:",omitempty" ent:"schema,edge"`
+	MaxItemsPerPage  int            `json:",omitempty" ent:"schema,edge"`
+	ItemsPerPage     int            `json:",omitempty" ent:"schema,edge"`
+	EagerLoad        *bool          `json:",omitempty" ent:"edge"`
+	EagerLoadLimit   *int           `json:",omitempty" ent:"edge"`
+	Includable       bool           `json:",omitempty" ent:"edge"`
+	EdgeEndpoint     *bool          `json:",omitempty" ent:"edge"`
+	EdgeUpdateBulk   bool           `json:",omitempty" ent:"edge"`
+	EdgeMutation     bool           `json:",omitempty" ent:"edge"`
+	NestedCreate     bool           `json:",omitempty" ent:"edge"`
+	Filter           Predicate      `json:",omitempty" ent:"schema,edge,field"`
+	FilterGroup      string         `json:",omitempty" ent:"edge,field"`
+	Search           bool           `json:",omitempty" ent:"schema,field"`
+	SearchTable      string         `json:",omitempty" ent:"schema"`
+	Aggregate        Aggregation    `json:",omitempty" ent:"field"`
+	DisableHandler   bool           `json:",omitempty" ent:"schema,edge"`
+	Sortable         bool           `json:",omitempty" ent:"field"`
+	DefaultSort      *string        `json:",omitempty" ent:"schema"`
+	DefaultOrder     *SortOrder     `json:",omitempty" ent:"schema"`
+	Skip             bool           `json:",omitempty" ent:"schema,edge,field"`
+	AllowClientIDs   *bool          `json:",omitempty" ent:"schema"`
+	Operations       []Operation    `json:",omitempty" ent:"schema,edge"`
+	VersionField     string         `json:",omitempty" ent:"schema"`
+	SoftDelete       string         `json:",omitempty" ent:"schema"`
+	Upsert           []string       `json:",omitempty" ent:"schema"`
+	IdempotencyStore bool           `json:",omitempty" ent:"schema"`
+	Webhooks         bool           `json:",omitempty" ent:"schema"`
+	WebhookOutbox    bool           `json:",omitempty" ent:"schema"`
+	WebhookStore     bool           `json:",omitempty" ent:"schema"`
+	History          bool           `json:",omitempty" ent:"schema"`
+	HistoryStore     bool           `json:",omitempty" ent:"schema"`
+
+	RateLimit          *RateLimit               `json:",omitempty" ent:"schema"`
+	OperationRateLimit map[Operation]*RateLimit `json:",omitempty" ent:"schema"`
+
+	Security map[Operation]ogen.SecurityRequirements `json:",omitempty" ent:"schema,edge"`
+	Scopes   map[Operation][]string                  `json:",omitempty" ent:"schema,edge"`
 }
 
 // getSupportedType uses reflection to check if the annotation is supported on the
@@ -199,6 +250,12 @@ func (a Annotation) Merge(o schema.Annotation) schema.Annotation { // nolint:goc
 	if am.Pagination != nil {
 		a.Pagination = am.Pagination
 	}
+	if am.PaginationMode != "" {
+		a.PaginationMode = am.PaginationMode
+	}
+	if am.CountStrategy != "" {
+		a.CountStrategy = am.CountStrategy
+	}
 	if am.MinItemsPerPage != 0 {
 		a.MinItemsPerPage = am.MinItemsPerPage
 	}
@@ -214,16 +271,24 @@ func (a Annotation) Merge(o schema.Annotation) schema.Annotation { // nolint:goc
 	if am.EagerLoadLimit != nil {
 		a.EagerLoadLimit = am.EagerLoadLimit
 	}
+	a.Includable = a.Includable || am.Includable
 	if am.EdgeEndpoint != nil {
 		a.EdgeEndpoint = am.EdgeEndpoint
 	}
 	a.EdgeUpdateBulk = a.EdgeUpdateBulk || am.EdgeUpdateBulk
+	a.EdgeMutation = a.EdgeMutation || am.EdgeMutation
+	a.NestedCreate = a.NestedCreate || am.NestedCreate
 	if am.Filter != 0 {
 		a.Filter = am.Filter.Add(a.Filter)
 	}
 	if am.FilterGroup != "" {
 		a.FilterGroup = am.FilterGroup
 	}
+	a.Search = a.Search || am.Search
+	if am.SearchTable != "" {
+		a.SearchTable = am.SearchTable
+	}
+	a.Aggregate |= am.Aggregate
 	a.DisableHandler = a.DisableHandler || am.DisableHandler
 	a.Sortable = a.Sortable || am.Sortable
 	if am.DefaultSort != nil {
@@ -244,6 +309,43 @@ func (a Annotation) Merge(o schema.Annotation) schema.Annotation { // nolint:goc
 			}
 		}
 	}
+	if am.VersionField != "" {
+		a.VersionField = am.VersionField
+	}
+	if am.SoftDelete != "" {
+		a.SoftDelete = am.SoftDelete
+	}
+	if len(am.Upsert) > 0 {
+		a.Upsert = am.Upsert
+	}
+	a.IdempotencyStore = a.IdempotencyStore || am.IdempotencyStore
+	a.Webhooks = a.Webhooks || am.Webhooks
+	a.WebhookOutbox = a.WebhookOutbox || am.WebhookOutbox
+	a.WebhookStore = a.WebhookStore || am.WebhookStore
+	a.History = a.History || am.History
+	a.HistoryStore = a.HistoryStore || am.HistoryStore
+
+	if am.RateLimit != nil {
+		a.RateLimit = am.RateLimit
+	}
+	if len(am.OperationRateLimit) > 0 {
+		if a.OperationRateLimit == nil {
+			a.OperationRateLimit = make(map[Operation]*RateLimit)
+		}
+		maps.Copy(a.OperationRateLimit, am.OperationRateLimit)
+	}
+	if len(am.Security) > 0 {
+		if a.Security == nil {
+			a.Security = make(map[Operation]ogen.SecurityRequirements)
+		}
+		maps.Copy(a.Security, am.Security)
+	}
+	if len(am.Scopes) > 0 {
+		if a.Scopes == nil {
+			a.Scopes = make(map[Operation][]string)
+		}
+		maps.Copy(a.Scopes, am.Scopes)
+	}
 
 	return a
 }
@@ -277,6 +379,25 @@ func (a *Annotation) GetPagination(config *Config, edge *gen.Edge) bool {
 	return *a.Pagination
 }
 
+// GetPaginationMode returns the pagination mode for list operations (or defaults from
+// [Config.PaginationMode]). See also [GetPaginationMode], which also accounts for edges
+// and types without an ID field.
+func (a *Annotation) GetPaginationMode(config *Config) PaginationMode {
+	if a.PaginationMode == "" {
+		return config.PaginationMode
+	}
+	return a.PaginationMode
+}
+
+// GetCountStrategy returns the count strategy for paged list operations (or defaults from
+// [Config.CountStrategy]).
+func (a *Annotation) GetCountStrategy(config *Config) CountStrategy {
+	if a.CountStrategy == "" {
+		return config.CountStrategy
+	}
+	return a.CountStrategy
+}
+
 // GetMinItemsPerPage returns the minimum number of items per page for paginated calls
 // (or defaults from [Config.MinItemsPerPage]).
 func (a *Annotation) GetMinItemsPerPage(config *Config) int {
@@ -346,12 +467,39 @@ func (a *Annotation) HasOperation(config *Config, op Operation) bool {
 }
 
 // GetOperations returns the operations annotation (or defaults from
-// [Config.DefaultOperations]).
+// [Config.DefaultOperations]). If soft-delete is enabled (see [WithSoftDelete]) and
+// the delete operation is included, [OperationRestore] is also included. Similarly, if
+// upsert is enabled (see [WithUpsert]) and the create operation is included,
+// [OperationUpsert] is also included. If history is enabled (see [WithHistory]), the
+// history operations are also included, otherwise they're always excluded.
 func (a *Annotation) GetOperations(config *Config) []Operation {
-	if a.Operations == nil {
-		return config.DefaultOperations
+	ops := a.Operations
+	if ops == nil {
+		ops = config.DefaultOperations
+	}
+	if a.SoftDelete != "" && slices.Contains(ops, OperationDelete) && !slices.Contains(ops, OperationRestore) {
+		ops = append(slices.Clip(ops), OperationRestore)
 	}
-	return a.Operations
+	if len(a.Upsert) > 0 && slices.Contains(ops, OperationCreate) && !slices.Contains(ops, OperationUpsert) {
+		ops = append(slices.Clip(ops), OperationUpsert)
+	}
+	if a.History && !slices.Contains(ops, OperationHistory) {
+		ops = append(slices.Clip(ops), OperationHistory, OperationHistoryRead, OperationHistoryRestore)
+	}
+	if !a.History && slices.ContainsFunc(ops, isHistoryOperation) {
+		ops = slices.DeleteFunc(slices.Clone(ops), isHistoryOperation)
+	}
+	return ops
+}
+
+// GetRateLimit returns the rate limit of the provided operation (see [WithOperationRateLimit]),
+// falling back to the rate limit of the schema (see [WithRateLimit]), or nil if the operation
+// isn't rate limited.
+func (a *Annotation) GetRateLimit(op Operation) *RateLimit {
+	if v, ok := a.OperationRateLimit[op]; ok {
+		return v
+	}
+	return a.RateLimit
 }
 
 // GetOperationSummary returns the summary for the provided operation or an empty
@@ -460,6 +608,23 @@ func WithPagination(v bool) Annotation {
 	return Annotation{Pagination: &v}
 }
 
+// WithPaginationMode sets the pagination strategy for list operations on the schema/edge,
+// overriding [Config.PaginationMode]. When used on an edge, it only applies to the edge
+// endpoint (e.g. /users/{id}/pets). Schemas without an ID field always use offset pagination.
+//
+// When using [PaginationCursor], only the "id" field, and sortable fields which are not
+// optional or nillable, can be used as the sort field.
+func WithPaginationMode(v PaginationMode) Annotation {
+	return Annotation{PaginationMode: v}
+}
+
+// WithCountStrategy sets the strategy used to calculate the total number of results for
+// list operations on the schema (and edge endpoints which return the schema), overriding
+// [Config.CountStrategy]. Only applies to list operations using [PaginationOffset].
+func WithCountStrategy(v CountStrategy) Annotation {
+	return Annotation{CountStrategy: v}
+}
+
 // WithMinItemsPerPage sets an explicit minimum number of items per page for paginated calls.
 func WithMinItemsPerPage(v int) Annotation {
 	return Annotation{MinItemsPerPage: v}
@@ -494,6 +659,15 @@ func WithEagerLoadLimit(v int) Annotation {
 	return Annotation{EagerLoadLimit: &v}
 }
 
+// WithIncludable allows the edge to be eager-loaded on demand, by providing it in the
+// "include" query parameter of read, list, create and update operations (e.g.
+// "?include=owner"). Nested edges can be included as well (e.g. "?include=pets.owner"),
+// as long as each edge in the path is includable, up to [Config.MaxIncludeDepth]. Like
+// eager-loaded edges, included edges respect [Config.EagerLoadLimit].
+func WithIncludable(v bool) Annotation {
+	return Annotation{Includable: v}
+}
+
 // WithEdgeEndpoint sets the edge to have an endpoint. If the edge is eager-loaded,
 // and the global config is set to disable endpoints for edges which are also
 // eager-loaded, this will default to false. Not required to be provided unless
@@ -514,6 +688,28 @@ func WithEdgeUpdateBulk(v bool) Annotation {
 	return Annotation{EdgeUpdateBulk: v}
 }
 
+// WithEdgeMutation generates endpoints to attach and detach individual entities to/from
+// the edge, next to the edge read/list endpoints. For non-unique edges, "PUT" attaches
+// and "DELETE" detaches an entity (e.g. "/users/{id}/pets/{petID}"). For unique edges,
+// "PUT" sets the entity (e.g. "/pets/{id}/owner/{ownerID}"), and "DELETE" clears it (e.g.
+// "/pets/{id}/owner"), which is only supported if the edge is optional. Read-only and
+// immutable edges are not supported, and the type must support [OperationUpdate]. Both
+// endpoints are treated as [OperationUpdate] of the type, including for scopes, rate limits
+// and the authorizer.
+func WithEdgeMutation(v bool) Annotation {
+	return Annotation{EdgeMutation: v}
+}
+
+// WithNestedCreate allows the edge to accept the create parameters of the edge type in
+// place of (or alongside) IDs when creating an entity, so that related entities can be
+// created in the same request (e.g. a user with multiple pets). All entities are created
+// in a single transaction. Edges which are backed by an edge field, or use an edge schema
+// (through), are not supported, and the edge type must support [OperationCreate]. Nested
+// entities are authorized separately, with the create scopes of the edge type.
+func WithNestedCreate(v bool) Annotation {
+	return Annotation{NestedCreate: v}
+}
+
 // WithFilter sets the field to be filterable with the provided predicate(s). When applied
 // on an edge with [FilterEdge], it will include the fields associated with the edge
 // that are also filterable.
@@ -539,6 +735,48 @@ func WithFilterGroup(name string) Annotation {
 	return Annotation{FilterGroup: name}
 }
 
+// WithSearch adds a "q" query parameter to list operations, which searches across multiple
+// fields at once. When used on a field, the field is included in the search. When used on a
+// schema, all string fields (which aren't sensitive or skipped) are included. Only string
+// fields (without a custom Go type) can be searched.
+//
+// Like [WithFilterGroup], a single value is matched against all fields, however the full-text
+// search capabilities of the dialect are used where possible:
+//   - Postgres: to_tsvector/websearch_to_tsquery (no index is required, but a GIN expression
+//     index is recommended for larger tables).
+//   - MySQL: MATCH ... AGAINST, if the fields are covered by a FULLTEXT index.
+//   - SQLite: FTS5, if a table is provided with [WithSearchTable].
+//
+// Otherwise, the search falls back to a case-insensitive "contains" match on any of the
+// fields (the same as the "has" filter group predicate). A "relevance" sort field is also
+// added, which sorts results by how well they match the search query.
+func WithSearch(v bool) Annotation {
+	return Annotation{Search: v}
+}
+
+// WithSearchTable sets the SQLite FTS5 virtual table used for searching the schema (see
+// [WithSearch]). The table must be created (and kept up to date) outside of ent, e.g. an
+// external content table using the schema table as content, where the rowid matches the
+// ID of the entity. Only schemas with integer IDs are supported.
+func WithSearchTable(name string) Annotation {
+	return Annotation{SearchTable: name}
+}
+
+// WithAggregate allows the field to be used with the aggregate operation (see
+// [OperationAggregate]), which must be enabled on the schema, e.g. through
+// [WithIncludeOperations]. [AggregateGroupBy] allows grouping results by the field, and
+// [AggregateGroupNumeric] (or individual aggregations) allows calculating metrics for
+// the field (e.g. "sum:age"). Time fields are grouped by time bucket (e.g.
+// "created_at:day"), and numeric aggregations are only supported on numeric fields.
+//
+// Example:
+//
+//	entrest.WithAggregate(entrest.AggregateGroupBy) // e.g. ?group_by=type
+//	entrest.WithAggregate(entrest.AggregateGroupBy | entrest.AggregateGroupNumeric) // e.g. ?group_by=age&metrics=avg:age
+func WithAggregate(v Aggregation) Annotation {
+	return Annotation{Aggregate: v}
+}
+
 // WithHandler sets the schema/edge to have an HTTP handler generated for it. Unless a schema/edge
 // is skipped or has the specific operation disabled, an HTTP handler/endpoint will be generated for
 // it by default. This does not prevent the endpoint from being created within the spec, rather only
@@ -622,19 +860,116 @@ func WithSchema(v *ogen.Schema) Annotation {
 }
 
 // WithIncludeOperations includes the specified operations in the REST API for the
-// schema. If empty, all operations are generated (unless globally disabled).
+// schema. If empty, all operations are generated (unless globally disabled). This
+// can also be used to enable opt-in operations, like [OperationCreateBulk].
 func WithIncludeOperations(v ...Operation) Annotation {
 	return Annotation{Operations: v}
 }
 
 // WithExcludeOperations excludes the specified operations in the REST API for the
-// schema. If empty, all operations are generated (unless globally disabled).
+// schema. If empty, all operations are generated (unless globally disabled). Note that
+// this excludes from [BaseOperations], so opt-in operations are never included.
 func WithExcludeOperations(v ...Operation) Annotation {
 	var ops []Operation
-	for _, o := range AllOperations {
+	for _, o := range BaseOperations {
 		if !slices.Contains(v, o) {
 			ops = append(ops, o)
 		}
 	}
 	return Annotation{Operations: ops}
 }
+
+// WithVersionField enables optimistic concurrency control for the schema, using the
+// provided integer field as the version of each entity. The field must be read-only
+// (see [WithReadOnly]), have a default value, and is incremented on every update.
+//
+// Responses which return the entity include an "ETag" header based on the version.
+// Update, replace, delete, edge mutation (attach/detach or set/clear) and history restore
+// operations honor the "If-Match" header, returning a 412 Precondition Failed if the entity
+// has since been modified, and read
+// and list operations honor the "If-None-Match" header, returning a 304 Not Modified if
+// the entity hasn't been modified.
+func WithVersionField(name string) Annotation {
+	return Annotation{VersionField: name}
+}
+
+// WithSoftDelete enables soft-delete for the schema, using the provided time field to
+// store when the entity was deleted. The field must be optional and read-only (see
+// [WithReadOnly]).
+//
+// Delete operations set the field rather than deleting the entity, and read, list, edge
+// and eager-loaded queries exclude soft-deleted entities. A restore operation (see
+// [OperationRestore]) is also generated, and list operations accept "include_deleted"
+// and "only_deleted" parameters to return soft-deleted entities.
+func WithSoftDelete(field string) Annotation {
+	return Annotation{SoftDelete: field}
+}
+
+// WithUpsert enables the upsert operation (see [OperationUpsert]) for the schema, which
+// creates the entity, or updates the existing entity if one already exists with the same
+// values for the provided fields (e.g. "PUT /users/by-email/{email}"). The fields must
+// be covered by a unique index (or be a single unique field), and the "sql/upsert" ent
+// feature must be enabled. The response status is 201 if the entity was created, and 200
+// if it was updated. Note that this is best-effort, as the existence check and the upsert
+// are separate statements, and mutation hooks (e.g. events) see all upserts as creates.
+func WithUpsert(fields ...string) Annotation {
+	return Annotation{Upsert: fields}
+}
+
+// WithWebhooks enables outbound webhooks for the schema. Creates, updates and deletes made
+// through the ent client provided to the generated server are stored in the webhook outbox,
+// and delivered to all matching webhook subscriptions. The events are also documented in
+// the "webhooks" section of the spec (e.g. "pet.create"). See also [WebhookOutboxMixin]
+// and [WebhookSubscriptionMixin].
+func WithWebhooks(v bool) Annotation {
+	return Annotation{Webhooks: v}
+}
+
+// WithHistory enables change history for the schema. Each change made through the ent
+// client provided to the generated server is recorded as a new version of the entity
+// (see [HistoryMixin]), and the history operations are generated, which list the versions
+// of an entity ([OperationHistory]), return a single version ([OperationHistoryRead]), and
+// restore an entity to a version ([OperationHistoryRestore]). Schemas without an ID field
+// do not support history. Note that all entities changed by a mutation are loaded to record
+// their versions, so bulk changes of many entities are costly.
+func WithHistory(v bool) Annotation {
+	return Annotation{History: v}
+}
+
+// WithRateLimit limits the number of requests each client (see the "RateLimitKey" option of
+// the generated server) can make to the operations of the schema, to limit requests per
+// period. All operations of the schema (including edge operations) share the same limit,
+// unless a limit is provided for the operation (see [WithOperationRateLimit]). Rate limited
+// operations include the "X-Ratelimit-*" headers in responses, and document the 429 "Too
+// Many Requests" response in the spec.
+func WithRateLimit(limit int, period time.Duration) Annotation {
+	return Annotation{RateLimit: &RateLimit{Limit: limit, Period: period}}
+}
+
+// WithOperationRateLimit limits the number of requests each client can make to the provided
+// operation of the schema, to limit requests per period, overriding the limit of the schema
+// (see [WithRateLimit]). Each rate limited operation has its own limit.
+func WithOperationRateLimit(op Operation, limit int, period time.Duration) Annotation {
+	return Annotation{OperationRateLimit: map[Operation]*RateLimit{op: {Limit: limit, Period: period}}}
+}
+
+// WithSecurity sets the security requirements of the provided operation, overriding the
+// global security requirements of the spec (see [Config.Spec]) for the operation. Each
+// requirement maps the names of security schemes (which must be defined in the spec) to
+// the scopes they require. Use an empty requirement (e.g. ogen.SecurityRequirement{}) to
+// make authentication optional for the operation. When used on edges, it applies to the
+// operations of the edge (e.g. "/pets/{id}/owner"), overriding the security requirements
+// of the schema. See also [WithScopes].
+func WithSecurity(op Operation, requirements ...ogen.SecurityRequirement) Annotation {
+	return Annotation{Security: map[Operation]ogen.SecurityRequirements{op: requirements}}
+}
+
+// WithScopes sets the scopes required by the provided operation, which are added to each
+// security scheme (which supports scopes, e.g. "oauth2") of the security requirements of
+// the operation (see [WithSecurity]), or of the global security requirements of the spec
+// (see [Config.Spec]). The scopes are also provided to the "Authorizer" of the generated
+// server. When used on edges, it applies to the operations of the edge, overriding the
+// scopes of the schema.
+func WithScopes(op Operation, scopes ...string) Annotation {
+	return Annotation{Scopes: map[Operation][]string{op: scopes}}
+}
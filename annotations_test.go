@@ -6,8 +6,10 @@ package entrest
 
 import (
 	"testing"
+	"time"
 
 	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema/field"
 	"github.com/ogen-go/ogen"
 	"github.com/stretchr/testify/assert"
 )
@@ -83,6 +85,288 @@ func TestValidateAnnotation(t *testing.T) {
 			}},
 			wantErr: true,
 		},
+		{
+			name: "valid-version-field",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithVersionField("version")},
+				Fields: []*gen.Field{{
+					Name:        "version",
+					Type:        &field.TypeInfo{Type: field.TypeInt},
+					Default:     true,
+					Annotations: map[string]any{Annotation{}.Name(): WithReadOnly(true)},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-version-field-not-read-only",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithVersionField("version")},
+				Fields: []*gen.Field{{
+					Name:    "version",
+					Type:    &field.TypeInfo{Type: field.TypeInt},
+					Default: true,
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-version-field-type",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithVersionField("version")},
+				Fields: []*gen.Field{{
+					Name:        "version",
+					Type:        &field.TypeInfo{Type: field.TypeString},
+					Default:     true,
+					Annotations: map[string]any{Annotation{}.Name(): WithReadOnly(true)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-version-field-missing",
+			value: &gen.Type{Annotations: map[string]any{
+				Annotation{}.Name(): WithVersionField("version"),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid-soft-delete",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithSoftDelete("deleted_at")},
+				Fields: []*gen.Field{{
+					Name:        "deleted_at",
+					Type:        &field.TypeInfo{Type: field.TypeTime},
+					Optional:    true,
+					Annotations: map[string]any{Annotation{}.Name(): WithReadOnly(true)},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-soft-delete-not-optional",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithSoftDelete("deleted_at")},
+				Fields: []*gen.Field{{
+					Name:        "deleted_at",
+					Type:        &field.TypeInfo{Type: field.TypeTime},
+					Annotations: map[string]any{Annotation{}.Name(): WithReadOnly(true)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-soft-delete-type",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithSoftDelete("deleted_at")},
+				Fields: []*gen.Field{{
+					Name:        "deleted_at",
+					Type:        &field.TypeInfo{Type: field.TypeBool},
+					Optional:    true,
+					Annotations: map[string]any{Annotation{}.Name(): WithReadOnly(true)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-soft-delete-missing",
+			value: &gen.Type{Annotations: map[string]any{
+				Annotation{}.Name(): WithSoftDelete("deleted_at"),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid-upsert",
+			value: &gen.Type{
+				Config:      &gen.Config{Features: []gen.Feature{gen.FeatureUpsert}},
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithUpsert("email")},
+				Fields: []*gen.Field{{
+					Name:   "email",
+					Type:   &field.TypeInfo{Type: field.TypeString},
+					Unique: true,
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-upsert-feature-disabled",
+			value: &gen.Type{
+				Config:      &gen.Config{},
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithUpsert("email")},
+				Fields: []*gen.Field{{
+					Name:   "email",
+					Type:   &field.TypeInfo{Type: field.TypeString},
+					Unique: true,
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-upsert-not-unique",
+			value: &gen.Type{
+				Config:      &gen.Config{Features: []gen.Feature{gen.FeatureUpsert}},
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithUpsert("email")},
+				Fields: []*gen.Field{{
+					Name: "email",
+					Type: &field.TypeInfo{Type: field.TypeString},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid-upsert-unique-index",
+			value: &gen.Type{
+				Config:      &gen.Config{Features: []gen.Feature{gen.FeatureUpsert}},
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithUpsert("owner", "name")},
+				Fields: []*gen.Field{
+					{Name: "owner", Type: &field.TypeInfo{Type: field.TypeString}},
+					{Name: "name", Type: &field.TypeInfo{Type: field.TypeString}},
+				},
+				Indexes: []*gen.Index{{Unique: true, Columns: []string{"name", "owner"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-upsert-type",
+			value: &gen.Type{
+				Config:      &gen.Config{Features: []gen.Feature{gen.FeatureUpsert}},
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithUpsert("enabled")},
+				Fields: []*gen.Field{{
+					Name:   "enabled",
+					Type:   &field.TypeInfo{Type: field.TypeBool},
+					Unique: true,
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-idempotency-store-missing-fields",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): Annotation{IdempotencyStore: true}},
+				Fields: []*gen.Field{{
+					Name: "key",
+					Type: &field.TypeInfo{Type: field.TypeString},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-webhook-outbox-missing-fields",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): Annotation{WebhookOutbox: true}},
+				Fields: []*gen.Field{{
+					Name: "key",
+					Type: &field.TypeInfo{Type: field.TypeString},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-rate-limit",
+			value: &gen.Type{
+				Annotations: map[string]any{Annotation{}.Name(): WithOperationRateLimit(OperationList, 0, time.Minute)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-history-store-missing-fields",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): Annotation{HistoryStore: true}},
+				Fields: []*gen.Field{{
+					Name: "entity_type",
+					Type: &field.TypeInfo{Type: field.TypeString},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-search-field-type",
+			value: &gen.Type{
+				Fields: []*gen.Field{{
+					Name:        "age",
+					Type:        &field.TypeInfo{Type: field.TypeInt},
+					Annotations: map[string]any{Annotation{}.Name(): WithSearch(true)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-search-table-string-id",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeString}},
+				Annotations: map[string]any{Annotation{}.Name(): Annotation{Search: true, SearchTable: "pet_search"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-search-table-without-search",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithSearchTable("pet_search")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid-search-table",
+			value: &gen.Type{
+				ID:          &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+				Annotations: map[string]any{Annotation{}.Name(): WithSearchTable("pet_search")},
+				Fields: []*gen.Field{{
+					Name:        "name",
+					Type:        &field.TypeInfo{Type: field.TypeString},
+					Annotations: map[string]any{Annotation{}.Name(): WithSearch(true)},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-aggregate-group-by-json",
+			value: &gen.Type{
+				Fields: []*gen.Field{{
+					Name:        "nicknames",
+					Type:        &field.TypeInfo{Type: field.TypeJSON},
+					Annotations: map[string]any{Annotation{}.Name(): WithAggregate(AggregateGroupBy)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-aggregate-numeric-string",
+			value: &gen.Type{
+				Fields: []*gen.Field{{
+					Name:        "name",
+					Type:        &field.TypeInfo{Type: field.TypeString},
+					Annotations: map[string]any{Annotation{}.Name(): WithAggregate(AggregateGroupBy | AggregateSum)},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid-aggregate",
+			value: &gen.Type{
+				Fields: []*gen.Field{
+					{
+						Name:        "age",
+						Type:        &field.TypeInfo{Type: field.TypeInt},
+						Annotations: map[string]any{Annotation{}.Name(): WithAggregate(AggregateGroupBy | AggregateGroupNumeric)},
+					},
+					{
+						Name:        "created_at",
+						Type:        &field.TypeInfo{Type: field.TypeTime},
+						Annotations: map[string]any{Annotation{}.Name(): WithAggregate(AggregateGroupBy)},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,6 +414,40 @@ func TestAnnotation_Merge(t *testing.T) {
 				Description: "bar",
 			},
 		},
+		{
+			name: "rate-limits",
+			annotations: []Annotation{
+				WithRateLimit(10, time.Minute),
+				WithOperationRateLimit(OperationList, 5, time.Second),
+				WithOperationRateLimit(OperationRead, 20, time.Minute),
+				WithRateLimit(100, time.Hour),
+			},
+			want: Annotation{
+				RateLimit: &RateLimit{Limit: 100, Period: time.Hour},
+				OperationRateLimit: map[Operation]*RateLimit{
+					OperationList: {Limit: 5, Period: time.Second},
+					OperationRead: {Limit: 20, Period: time.Minute},
+				},
+			},
+		},
+		{
+			name: "security",
+			annotations: []Annotation{
+				WithSecurity(OperationCreate, ogen.SecurityRequirement{"ApiKey": {}}),
+				WithScopes(OperationCreate, "pets:write"),
+				WithSecurity(OperationList, ogen.SecurityRequirement{}),
+				WithScopes(OperationCreate, "pets:create"),
+			},
+			want: Annotation{
+				Security: map[Operation]ogen.SecurityRequirements{
+					OperationCreate: {{"ApiKey": {}}},
+					OperationList:   {{}},
+				},
+				Scopes: map[Operation][]string{
+					OperationCreate: {"pets:create"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -207,3 +525,72 @@ func TestAnnotation_EdgeUpdateBulk(t *testing.T) {
 	assert.NotNil(t, r.json(`$.components.schemas.PetUpdate.properties.add_friends`))
 	assert.NotNil(t, r.json(`$.components.schemas.PetUpdate.properties.remove_friends`))
 }
+
+func TestAnnotation_PaginationMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("schema", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{
+			PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+				injectAnnotations(t, g, "Category", WithPaginationMode(PaginationCursor))
+				return nil
+			},
+		})
+
+		assert.Contains(t, r.json(`$.components.schemas.CategoryList.allOf.*.$ref`), "/CursorResponse")
+		assert.Contains(t, r.json(`$.components.schemas.PetList.allOf.*.$ref`), "/PagedResponse")
+
+		// Edges default to the pagination mode of the edge type.
+		assert.Contains(t, r.json(`$.paths./pets/{petID}/categories..responses..schema.$ref`), "/CategoryList")
+		assert.Contains(t, r.json(`$.paths./pets/{petID}/categories.get.parameters.*.$ref`), "#/components/parameters/Cursor")
+	})
+
+	t.Run("edge", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{
+			PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+				injectAnnotations(t, g, "Pet.categories", WithPaginationMode(PaginationCursor))
+				return nil
+			},
+		})
+
+		assert.Contains(t, r.json(`$.components.schemas.CategoryList.allOf.*.$ref`), "/PagedResponse")
+		assert.Contains(t, r.json(`$.paths./pets/{petID}/categories..responses..schema.$ref`), "/PetCategoryList")
+		assert.Contains(t, r.json(`$.components.schemas.PetCategoryList.allOf.*.$ref`), "/CursorResponse")
+		assert.Contains(t, r.json(`$.paths./pets/{petID}/categories.get.parameters.*.$ref`), "#/components/parameters/Cursor")
+	})
+}
+
+func TestAnnotation_Includable(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet.owner", WithIncludable(true))
+			injectAnnotations(t, g, "User.pets", WithIncludable(true))
+			return nil
+		},
+	})
+
+	edges := r.json(`$.components.schemas.PetIncludableEdges.enum`)
+	assert.Contains(t, edges, "owner")
+	assert.Contains(t, edges, "owner.pets")
+	assert.NotContains(t, edges, "owner.pets.owner")
+	assert.NotContains(t, edges, "categories")
+
+	for _, path := range []string{
+		`$.paths./pets.get`,
+		`$.paths./pets.post`,
+		`$.paths./pets/{petID}.get`,
+		`$.paths./pets/{petID}.patch`,
+		`$.paths./users/{userID}/pets.get`,
+	} {
+		assert.Equal(t, "array", r.json(path+`.parameters[?(@.name == 'include')].schema.type`), path)
+	}
+
+	// Included edges are optional, and are read the same way as the edge type itself.
+	assert.Contains(t, r.json(`$.components.schemas.PetEdges.properties.owner.$ref`), "/UserRead")
+	assert.Nil(t, r.json(`$.components.schemas.PetEdges.required`))
+	assert.Nil(t, r.json(`$.paths./categories.get.parameters[?(@.name == 'include')]`))
+}
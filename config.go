@@ -37,6 +37,16 @@ type Config struct {
 	// It scan still be enabled on a per-schema basis with annotations.
 	DisablePagination bool
 
+	// PaginationMode controls the default pagination strategy for list operations.
+	// Defaults to [PaginationOffset]. This can be overridden on a per-schema or
+	// per-edge basis with annotations.
+	PaginationMode PaginationMode
+
+	// CountStrategy controls how the total number of results is calculated for list
+	// operations using [PaginationOffset]. Defaults to [CountExact]. This can be
+	// overridden on a per-schema basis with annotations.
+	CountStrategy CountStrategy
+
 	// MinItemsPerPage controls the default minimum number of items per page, for
 	// paginated calls. This can be overridden on a per-schema basis with annotations.
 	MinItemsPerPage int
@@ -83,6 +93,17 @@ type Config struct {
 	// This can be overridden on a per-edge basis with annotations.
 	EagerLoadLimit int
 
+	// MaxIncludeDepth controls the maximum depth of edges which can be provided to the
+	// "include" query parameter, for edges which are includable (see [WithIncludable]).
+	// For example, a depth of 2 allows "pets.owner", but not "pets.owner.pets". The
+	// default, when not specified, is 2.
+	MaxIncludeDepth int
+
+	// BulkCreateLimit controls the maximum number of entities which can be created in a
+	// single bulk create request (see [OperationCreateBulk]). The default, when not
+	// specified, is 100. The limit can be disabled by setting the value to -1.
+	BulkCreateLimit int
+
 	// AddEdgesToTags enables the addition of edge fields to the "tags" field in the
 	// OpenAPI spec. This is helpful to see if querying a specific entity also returns
 	// the thing you're looking for, though can be very noisy for large schemas. Note
@@ -97,7 +118,8 @@ type Config struct {
 	DefaultFilterID bool
 
 	// DefaultOperations is a list of operations to generate by default. If nil,
-	// all operations will be generated by default (unless excluded with annotations).
+	// [BaseOperations] will be generated by default (unless excluded with annotations).
+	// Opt-in operations (e.g. [OperationCreateBulk]) must be provided explicitly.
 	DefaultOperations []Operation
 
 	// GlobalRequestHeaders are headers to add to every request, which can be optional
@@ -107,7 +129,9 @@ type Config struct {
 	GlobalRequestHeaders RequestHeaders
 
 	// GlobalResponseHeaders are headers to add to every response, recommended for headers
-	// like X-Ratelimit-Limit, X-Ratelimit-Remaining, X-Ratelimit-Reset, etc.
+	// like X-Ratelimit-Limit, X-Ratelimit-Remaining, X-Ratelimit-Reset, etc. Note that
+	// operations rate limited by the generated server (see [WithRateLimit]) already
+	// include the rate limit headers.
 	GlobalResponseHeaders ResponseHeaders
 
 	// GlobalErrorResponses are status code -> response mappings for errors, which are
@@ -117,6 +141,13 @@ type Config struct {
 	// built-in auto-generated HTTP handlers (see below). Defaults to [DefaultErrorResponses].
 	GlobalErrorResponses ErrorResponses
 
+	// MediaTypes are additional media types (e.g. "application/cbor" or "application/msgpack")
+	// supported by the request and response bodies of all generated operations, in addition
+	// to JSON. Each media type is added to the spec using the same schema as JSON. These
+	// should match the encoders and decoders registered with the generated server (through
+	// "Encoders" and "Decoders" on the server config).
+	MediaTypes []string
+
 	// Handler enables the generation of HTTP handlers for the specified server/routing
 	// library. If this is disabled, no Go code will be generated, and only the OpenAPI
 	// spec will be generated.
@@ -135,6 +166,15 @@ type Config struct {
 	// with the "content" field being an empty array.
 	ListNotFound bool
 
+	// ListExport enables streaming exports of list operations, as newline-delimited JSON
+	// ("application/x-ndjson") or CSV ("text/csv"), requested through the "Accept" header
+	// or the "format" query parameter. Exports include all results matching the provided
+	// filters and sorting (pagination is ignored), which are fetched in batches using
+	// keyset iteration, rather than being buffered in memory. Only applies to schemas with
+	// an ID field. CSV exports flatten eager-loaded unique edges into columns (e.g.
+	// "owner.name").
+	ListExport bool
+
 	// DisableSpecHandler disables the generation of an OpenAPI spec handler (e.g.
 	// /openapi.json). Disabling this will also disable embedding the spec into the
 	// binary/rest generated library.
@@ -151,6 +191,14 @@ type Config struct {
 	// vectors.
 	AllowClientIDs bool
 
+	// IdempotencyKey enables support for the "Idempotency-Key" request header on all POST
+	// and PATCH operations. When a request is retried with the same key and body, the
+	// response of the original request is replayed (rather than re-running the operation),
+	// and re-using a key with a different body results in a 422 "Unprocessable Entity"
+	// response. Responses are stored using the IdempotencyStore provided to the generated
+	// server, which defaults to an in-memory store (see also [IdempotencyStoreMixin]).
+	IdempotencyKey bool
+
 	// DisablePatchJSONTag disables a ent generation hook that patches the JSON tag of all
 	// fields in the schema, removing the usage of omitempty. This helps ensure that fields
 	// that have default values and/or aren't required, still get returned in JSON response
@@ -217,6 +265,22 @@ func (c *Config) Validate() error {
 		c.ItemsPerPage = c.MaxItemsPerPage
 	}
 
+	if c.PaginationMode == "" {
+		c.PaginationMode = PaginationOffset
+	}
+
+	if !slices.Contains(AllPaginationModes, c.PaginationMode) {
+		return fmt.Errorf("unsupported pagination mode provided: %s", c.PaginationMode)
+	}
+
+	if c.CountStrategy == "" {
+		c.CountStrategy = CountExact
+	}
+
+	if !slices.Contains(AllCountStrategies, c.CountStrategy) {
+		return fmt.Errorf("unsupported count strategy provided: %s", c.CountStrategy)
+	}
+
 	if c.EagerLoadLimit < -1 {
 		c.EagerLoadLimit = -1
 	}
@@ -225,8 +289,24 @@ func (c *Config) Validate() error {
 		c.EagerLoadLimit = 1000
 	}
 
+	if c.MaxIncludeDepth < 0 {
+		return fmt.Errorf("invalid max include depth provided: %d", c.MaxIncludeDepth)
+	}
+
+	if c.MaxIncludeDepth == 0 {
+		c.MaxIncludeDepth = 2
+	}
+
+	if c.BulkCreateLimit < -1 {
+		c.BulkCreateLimit = -1
+	}
+
+	if c.BulkCreateLimit == 0 {
+		c.BulkCreateLimit = defaultBulkCreateLimit
+	}
+
 	if c.DefaultOperations == nil {
-		c.DefaultOperations = AllOperations
+		c.DefaultOperations = BaseOperations
 	}
 
 	if len(c.GlobalErrorResponses) == 0 {
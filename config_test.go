@@ -152,6 +152,124 @@ func TestConfig_DisablePagination(t *testing.T) {
 	})
 }
 
+func TestConfig_PaginationMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{})
+		assert.Contains(t, r.json(`$.components.schemas.PetList.allOf.*.$ref`), "/PagedResponse")
+		assert.Contains(t, r.json(`$.paths./pets.get.parameters.*.$ref`), "#/components/parameters/Page")
+		assert.Nil(t, r.json(`$.components.schemas.CursorResponse`))
+		assert.Nil(t, r.json(`$.components.parameters.Cursor`))
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{PaginationMode: PaginationCursor})
+		assert.Contains(t, r.json(`$.components.schemas.PetList.allOf.*.$ref`), "/CursorResponse")
+		assert.Contains(t, r.json(`$.paths./pets.get.parameters.*.$ref`), "#/components/parameters/Cursor")
+		assert.NotContains(t, r.json(`$.paths./pets.get.parameters.*.$ref`), "#/components/parameters/Page")
+		assert.Equal(t, "cursor", r.json(`$.components.parameters.Cursor.name`))
+
+		// Only the cursor fields can be used to sort cursor-paginated lists.
+		assert.Equal(t, "#/components/schemas/PetCursorSortableFields", r.json(`$.paths./pets.get.parameters[?(@.name == 'sort')].schema.$ref`))
+		assert.Contains(t, r.json(`$.components.schemas.PetCursorSortableFields.enum`), "id")
+		assert.NotContains(t, r.json(`$.components.schemas.PetCursorSortableFields.enum`), "random")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, (&Config{PaginationMode: "foo"}).Validate())
+	})
+}
+
+func TestConfig_CountStrategy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{})
+		assert.Contains(t, r.json(`$.components.schemas.PetList.allOf.*.$ref`), "#/components/schemas/PagedResponse")
+		assert.Contains(t, r.json(`$.components.schemas.PagedResponse.required`), "total_count")
+		assert.Nil(t, r.json(`$.components.schemas.PagedResponseEstimated`))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{
+			CountStrategy: CountNone,
+			PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+				injectAnnotations(t, g, "Pet", WithCountStrategy(CountEstimated))
+				return nil
+			},
+		})
+		// Counts without a count query are estimated until the last page is reached.
+		assert.Contains(t, r.json(`$.components.schemas.UserList.allOf.*.$ref`), "#/components/schemas/PagedResponseEstimated")
+		assert.Nil(t, r.json(`$.components.schemas.PagedResponse`))
+
+		assert.Contains(t, r.json(`$.components.schemas.PetList.allOf.*.$ref`), "#/components/schemas/PagedResponseEstimated")
+		assert.NotNil(t, r.json(`$.components.schemas.PagedResponseEstimated.properties.is_estimate`))
+		assert.Contains(t, r.json(`$.components.schemas.PagedResponseEstimated.required`), "total_count")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, (&Config{CountStrategy: "foo"}).Validate())
+	})
+}
+
+func TestConfig_MaxIncludeDepth(t *testing.T) {
+	t.Parallel()
+
+	hook := func(g *gen.Graph, _ *ogen.Spec) error {
+		injectAnnotations(t, g, "Pet.owner", WithIncludable(true))
+		injectAnnotations(t, g, "User.pets", WithIncludable(true))
+		return nil
+	}
+
+	t.Run("depth-1", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{MaxIncludeDepth: 1, PreGenerateHook: hook})
+		assert.Equal(t, []any{"owner"}, r.json(`$.components.schemas.PetIncludableEdges.enum`))
+	})
+
+	t.Run("depth-3", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{MaxIncludeDepth: 3, PreGenerateHook: hook})
+		assert.Equal(t, []any{"owner", "owner.pets", "owner.pets.owner"}, r.json(`$.components.schemas.PetIncludableEdges.enum`))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, (&Config{MaxIncludeDepth: -1}).Validate())
+	})
+}
+
+func TestConfig_BulkCreateLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("opt-in", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{})
+		assert.Nil(t, r.json(`$.paths./pets/bulk`))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{DefaultOperations: AllOperations})
+		assert.Equal(t, "createBulkPets", r.json(`$.paths./pets/bulk.post.operationId`))
+		assert.Equal(t, float64(defaultBulkCreateLimit), r.json(`$.components.schemas.PetCreateBulk.maxItems`))
+		assert.Equal(t, "#/components/schemas/PetRead", r.json(`$.components.schemas.PetCreateBulkResponse.items.$ref`))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		r := mustBuildSpec(t, &Config{DefaultOperations: AllOperations, BulkCreateLimit: -1})
+		assert.Nil(t, r.json(`$.components.schemas.PetCreateBulk.maxItems`))
+	})
+}
+
 func TestConfig_ItemsPerPage(t *testing.T) {
 	t.Parallel()
 
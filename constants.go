@@ -41,15 +41,83 @@ const (
 	OperationDelete Operation = "delete"
 	// OperationList represents the list operation (method: GET).
 	OperationList Operation = "list"
+	// OperationReplace represents the replace operation (method: PUT), which replaces
+	// all fields and edges of an entity, clearing any which aren't provided. This
+	// operation is opt-in.
+	OperationReplace Operation = "replace"
+	// OperationCreateBulk represents the bulk create operation (method: POST), which
+	// creates multiple entities in a single transaction. This operation is opt-in.
+	OperationCreateBulk Operation = "create-bulk"
+	// OperationUpdateBulk represents the bulk update operation (method: PATCH), which
+	// updates all entities matching the provided filters. This operation is opt-in.
+	OperationUpdateBulk Operation = "update-bulk"
+	// OperationDeleteBulk represents the bulk delete operation (method: DELETE), which
+	// deletes all entities matching the provided filters. This operation is opt-in.
+	OperationDeleteBulk Operation = "delete-bulk"
+	// OperationRestore represents the restore operation (method: POST), which restores a
+	// soft-deleted entity. This operation is only generated for schemas with soft-delete
+	// enabled (see [WithSoftDelete]), which also have the delete operation.
+	OperationRestore Operation = "restore"
+	// OperationUpsert represents the upsert operation (method: PUT), which creates an entity,
+	// or updates the existing entity with the same natural key (see [WithUpsert]). This
+	// operation is only generated for schemas with upsert enabled, which also have the
+	// create operation.
+	OperationUpsert Operation = "upsert"
+	// OperationAggregate represents the aggregate operation (method: GET), which groups
+	// entities matching the provided filters, and calculates metrics (count, sum, etc) for
+	// each group (see [WithAggregate]). This operation is opt-in.
+	OperationAggregate Operation = "aggregate"
+	// OperationImport represents the import operation (method: POST), which creates
+	// entities from an uploaded CSV or newline-delimited JSON file, returning a report of
+	// the created entities (or errors) for each row. This operation is opt-in.
+	OperationImport Operation = "import"
+	// OperationEvents represents the events operation (method: GET), which streams
+	// changes (creates, updates, and deletes) to entities as server-sent events, optionally
+	// filtered using the same filters as the list operation. This operation is opt-in.
+	OperationEvents Operation = "events"
+	// OperationHistory represents the history operation (method: GET), which lists the
+	// recorded versions of an entity, newest first. This operation (along with
+	// [OperationHistoryRead] and [OperationHistoryRestore]) is only generated for schemas
+	// with history enabled (see [WithHistory]).
+	OperationHistory Operation = "history"
+	// OperationHistoryRead represents the history read operation (method: GET), which
+	// returns a single recorded version of an entity.
+	OperationHistoryRead Operation = "history-read"
+	// OperationHistoryRestore represents the history restore operation (method: POST),
+	// which restores an entity to a recorded version, resulting in a new version.
+	OperationHistoryRestore Operation = "history-restore"
 )
 
 // AllOperations holds a list of all supported operations.
-var AllOperations = []Operation{OperationCreate, OperationRead, OperationUpdate, OperationDelete, OperationList}
+var AllOperations = []Operation{
+	OperationCreate,
+	OperationRead,
+	OperationUpdate,
+	OperationDelete,
+	OperationList,
+	OperationReplace,
+	OperationCreateBulk,
+	OperationUpdateBulk,
+	OperationDeleteBulk,
+	OperationRestore,
+	OperationUpsert,
+	OperationAggregate,
+	OperationImport,
+	OperationEvents,
+	OperationHistory,
+	OperationHistoryRead,
+	OperationHistoryRestore,
+}
+
+// BaseOperations holds the list of operations which are generated by default, which
+// excludes opt-in operations (e.g. [OperationCreateBulk]).
+var BaseOperations = []Operation{OperationCreate, OperationRead, OperationUpdate, OperationDelete, OperationList}
 
 const (
 	defaultMinItemsPerPage = 1
 	defaultMaxItemsPerPage = 100
 	defaultItemsPerPage    = 10
+	defaultBulkCreateLimit = 100
 )
 
 // HTTPHandler represents the HTTP handler to use for the HTTP server implementation.
@@ -136,6 +204,16 @@ var (
 		},
 	}
 
+	// RetryAfterHeader is the response header of 429 "Too Many Requests" responses, which
+	// is added to rate limited operations (see [WithRateLimit]).
+	RetryAfterHeader = ResponseHeaders{
+		"Retry-After": {
+			Description: "The number of seconds to wait before making another request.",
+			Required:    true,
+			Schema:      &ogen.Schema{Type: "integer"},
+		},
+	}
+
 	// RequestIDHeader is a standardized request ID request header.
 	RequestIDHeader = RequestHeaders{
 		"X-Request-Id": {
@@ -145,14 +223,30 @@ var (
 		},
 	}
 
+	// IdempotencyKeyHeader is the request header used to safely retry POST and PATCH
+	// requests (see [Config.IdempotencyKey]).
+	IdempotencyKeyHeader = RequestHeaders{
+		"Idempotency-Key": {
+			Description: "A unique client-generated key, which allows the request to be safely retried. Retries with the same key and body return the original response, and re-using the key with a different body returns a 422.",
+			Required:    false,
+			Schema:      &ogen.Schema{Type: "string", MaxLength: ptr(uint64(255))},
+		},
+	}
+
 	// DefaultErrorResponses are the default error responses for the HTTP status codes,
-	// which includes 400, 401, 403, 404, 409, 429, and 500.
+	// which includes 400, 401, 403, 404, 409, 412, 422, 429, and 500. Note that 412 is
+	// only added to operations which support the "If-Match" header (see [WithVersionField]),
+	// 422 is only added to operations which support the "Idempotency-Key" header (see
+	// [Config.IdempotencyKey]), and 429 is only added to rate limited operations (see
+	// [WithRateLimit]).
 	DefaultErrorResponses = ErrorResponses{
 		http.StatusBadRequest:          ErrorResponseObject(http.StatusBadRequest),
 		http.StatusUnauthorized:        ErrorResponseObject(http.StatusUnauthorized),
 		http.StatusForbidden:           ErrorResponseObject(http.StatusForbidden),
 		http.StatusNotFound:            ErrorResponseObject(http.StatusNotFound),
 		http.StatusConflict:            ErrorResponseObject(http.StatusConflict),
+		http.StatusPreconditionFailed:  ErrorResponseObject(http.StatusPreconditionFailed),
+		http.StatusUnprocessableEntity: ErrorResponseObject(http.StatusUnprocessableEntity),
 		http.StatusTooManyRequests:     ErrorResponseObject(http.StatusTooManyRequests),
 		http.StatusInternalServerError: ErrorResponseObject(http.StatusInternalServerError),
 	}
@@ -5,9 +5,12 @@
 package entrest
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
@@ -179,8 +182,27 @@ func (e *Extension) Generate(g *gen.Graph) (*ogen.Spec, error) {
 			}
 			specs = append(specs, tspec)
 		}
+
+		for _, edge := range GetMutableEdges(t) {
+			for _, op := range []Operation{OperationUpdate, OperationDelete} {
+				tspec, err = GetSpecEdge(t, edge, op)
+				if err != nil {
+					panic(err)
+				}
+				specs = append(specs, tspec)
+			}
+		}
 	}
 
+	// Only generated operations support idempotency keys and additional media types, so
+	// exclude any paths which were provided by the base spec (or the spec endpoint).
+	var paths []string
+	for _, tspec := range specs {
+		paths = append(paths, slices.Collect(maps.Keys(tspec.Paths))...)
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
 	if !e.config.DisableSpecHandler {
 		specs = append(specs, addOpenAPIEndpoint("/openapi.json"))
 	}
@@ -190,6 +212,15 @@ func (e *Extension) Generate(g *gen.Graph) (*ogen.Spec, error) {
 		panic(err)
 	}
 
+	err = addWebhooks(spec, GetWebhookTypes(g))
+	if err != nil {
+		return nil, err
+	}
+
+	if e.config.IdempotencyKey {
+		addOperationRequestHeaders(spec, IdempotencyKeyHeader, paths, http.MethodPost, http.MethodPatch)
+	}
+
 	if (!e.config.DisableSpecHandler && len(spec.Paths) == 1) || (e.config.DisableSpecHandler && len(spec.Paths) == 0) {
 		return nil, errors.New("spec generated no operations, thus no spec paths can be generated")
 	}
@@ -202,6 +233,8 @@ func (e *Extension) Generate(g *gen.Graph) (*ogen.Spec, error) {
 	}
 
 	addGlobalErrorResponses(e.config, spec, e.config.GlobalErrorResponses)
+	addRateLimitHeaders(spec)
+	addMediaTypes(spec, e.config.MediaTypes, paths)
 	addGlobalRequestHeaders(spec, e.config.GlobalRequestHeaders)
 	addGlobalResponseHeaders(spec, e.config.GlobalResponseHeaders)
 
@@ -215,29 +248,87 @@ func (e *Extension) writeSpec(g *gen.Graph, spec *ogen.Spec) error {
 		}
 	}
 
-	var enc *json.Encoder
+	b, err := marshalSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, b, "", "    "); err != nil {
+		return fmt.Errorf("failed to indent spec: %w", err)
+	}
+	buf.WriteByte('\n')
 
 	if e.config.Writer != nil {
-		enc = json.NewEncoder(e.config.Writer)
-	} else {
-		dir := filepath.Join(g.Target, "rest")
+		_, err = buf.WriteTo(e.config.Writer)
+		return err
+	}
 
-		err := os.MkdirAll(dir, 0o750)
+	dir := filepath.Join(g.Target, "rest")
+
+	err = os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "openapi.json"), buf.Bytes(), 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// marshalSpec marshals the spec to JSON. Unlike [json.Marshal], this also includes the
+// top-level extensions of the spec (e.g. "x-webhooks"), which ogen only supports when
+// marshalling to YAML. The extensions are appended (sorted by name) after the other
+// top-level fields of the spec, which keep their order.
+func marshalSpec(spec *ogen.Spec) ([]byte, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	if len(spec.Extensions) == 0 {
+		return b, nil
+	}
+
+	if len(b) < 2 || b[len(b)-1] != '}' {
+		return nil, errors.New("failed to marshal spec: expected an object")
+	}
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	empty := len(bytes.TrimSpace(b[1:len(b)-1])) == 0
+
+	for _, k := range slices.Sorted(maps.Keys(spec.Extensions)) {
+		node := spec.Extensions[k]
+
+		var v any
+		if err = node.Decode(&v); err != nil {
+			return nil, fmt.Errorf("failed to decode spec extension %q: %w", k, err)
+		}
+
+		kb, err := json.Marshal(k)
 		if err != nil {
-			return fmt.Errorf("failed to create directory: %w", err)
+			return nil, fmt.Errorf("failed to marshal spec extension %q: %w", k, err)
 		}
 
-		f, err := os.OpenFile(filepath.Join(dir, "openapi.json"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+		vb, err := json.Marshal(v)
 		if err != nil {
-			return fmt.Errorf("failed to open file: %w", err)
+			return nil, fmt.Errorf("failed to marshal spec extension %q: %w", k, err)
+		}
+
+		if !empty {
+			buf.WriteByte(',')
 		}
-		defer f.Close()
+		empty = false
 
-		enc = json.NewEncoder(f)
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
 	}
 
-	enc.SetIndent("", "    ")
-	return enc.Encode(spec)
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
 func (e *Extension) Annotations() []entc.Annotation {
@@ -184,7 +184,7 @@ func buildSpec(t *testing.T, config *Config) (*testSpecResult, error) {
 
 	result.ensureObj = sync.OnceFunc(func() {
 		var b []byte
-		b, err = json.Marshal(result.spec)
+		b, err = marshalSpec(result.spec)
 		if err != nil {
 			panic(fmt.Sprintf("failed to marshal spec: %v", err))
 		}
@@ -260,7 +260,7 @@ func mergeAnnotations(t *testing.T, in gen.Annotations, annotations ...Annotatio
 func validateSpec(t *testing.T, spec *ogen.Spec) {
 	t.Helper()
 
-	b, err := json.Marshal(spec)
+	b, err := marshalSpec(spec)
 	require.NoError(t, err)
 
 	doc, err := libopenapi.NewDocument(b)
@@ -0,0 +1,193 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+// RateLimit is the maximum number of requests which can be made within a period (see
+// [WithRateLimit] and [WithOperationRateLimit]).
+type RateLimit struct {
+	// Limit is the maximum number of requests within the period.
+	Limit int `json:"limit"`
+
+	// Period is the period in which the limit applies. Requests are replenished
+	// gradually throughout the period, rather than all at once.
+	Period time.Duration `json:"period"`
+}
+
+// GoString returns the Go representation of the rate limit, as used in generated code.
+func (r *RateLimit) GoString() string {
+	return fmt.Sprintf("RateLimit{Limit: %d, Period: %s}", r.Limit, goDuration(r.Period))
+}
+
+// goDuration returns the Go representation of the provided duration, e.g. "time.Minute"
+// or "30 * time.Second".
+func goDuration(d time.Duration) string {
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+	}
+
+	for _, u := range units {
+		if d%u.d != 0 {
+			continue
+		}
+		if d == u.d {
+			return u.name
+		}
+		return strconv.FormatInt(int64(d/u.d), 10) + " * " + u.name
+	}
+	return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")"
+}
+
+// GetRateLimit returns the rate limit of the provided operation on the given type (see
+// [Annotation.GetRateLimit]), or nil if the operation isn't rate limited. Skipped types
+// aren't rate limited.
+func GetRateLimit(t *gen.Type, op Operation) *RateLimit {
+	ta := GetAnnotation(t)
+	if ta.GetSkip(GetConfig(t.Config)) {
+		return nil
+	}
+	return ta.GetRateLimit(op)
+}
+
+// HasRateLimits returns true if any operation of the graph is rate limited.
+func HasRateLimits(g *gen.Graph) bool {
+	for _, t := range g.Nodes {
+		ta := GetAnnotation(t)
+		if ta.GetSkip(GetConfig(g.Config)) {
+			continue
+		}
+		if ta.RateLimit != nil || len(ta.OperationRateLimit) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRateLimits ensures that the rate limits of the given type are valid.
+func validateRateLimits(t *gen.Type) error {
+	ta := GetAnnotation(t)
+
+	limits := maps.Clone(ta.OperationRateLimit)
+	if ta.RateLimit != nil {
+		if limits == nil {
+			limits = map[Operation]*RateLimit{}
+		}
+		limits["*"] = ta.RateLimit
+	}
+
+	for _, op := range slices.Sorted(maps.Keys(limits)) {
+		limit := limits[op]
+		if limit == nil || limit.Limit < 1 || limit.Period <= 0 {
+			return fmt.Errorf("rate limit of operation %q on schema %q must have a positive limit and period", op, t.Name)
+		}
+	}
+	return nil
+}
+
+// addRateLimitResponses adds the 429 "Too Many Requests" response to all operations of the
+// provided spec, if the operation is rate limited. The response itself is added to the spec
+// by [addGlobalErrorResponses], which excludes it from all other operations.
+func addRateLimitResponses(spec *ogen.Spec, t *gen.Type, op Operation) {
+	if GetRateLimit(t, op) == nil {
+		return
+	}
+
+	for pathName, pathItem := range spec.Paths {
+		spec.Paths[pathName] = PatchOperations(pathItem, func(_ string, oper *ogen.Operation) *ogen.Operation {
+			if oper == nil {
+				return nil
+			}
+			if oper.Responses == nil {
+				oper.Responses = ogen.Responses{}
+			}
+			oper.Responses[strconv.Itoa(http.StatusTooManyRequests)] = &ogen.Response{
+				Ref: "#/components/responses/Error" + PascalCase(http.StatusText(http.StatusTooManyRequests)),
+			}
+			return oper
+		})
+	}
+}
+
+// addRateLimitHeaders adds the rate limit headers (see [RateLimitHeaders]) to all responses
+// of rate limited operations (those with a 429 "Too Many Requests" response), and the
+// "Retry-After" header (see [RetryAfterHeader]) to the shared 429 response.
+//
+// NOTE: order of operations for this function is important. It should be called after
+// [addGlobalErrorResponses].
+func addRateLimitHeaders(spec *ogen.Spec) {
+	code := strconv.Itoa(http.StatusTooManyRequests)
+	name := "Error" + PascalCase(http.StatusText(http.StatusTooManyRequests))
+
+	resp, hasResp := spec.Components.Responses[name]
+	limited := false
+
+	for pathName, pathItem := range spec.Paths {
+		spec.Paths[pathName] = PatchOperations(pathItem, func(_ string, op *ogen.Operation) *ogen.Operation {
+			if op == nil || op.Responses[code] == nil {
+				return op
+			}
+
+			if !hasResp {
+				// The 429 response was excluded from the global error responses.
+				delete(op.Responses, code)
+				return op
+			}
+
+			limited = true
+			for _, r := range op.Responses {
+				if r.Ref != "" {
+					continue
+				}
+				if r.Headers == nil {
+					r.Headers = make(map[string]*ogen.Header)
+				}
+				for k := range RateLimitHeaders {
+					r.Headers[k] = &ogen.Header{Ref: "#/components/headers/" + k}
+				}
+			}
+			return op
+		})
+	}
+
+	if !limited {
+		return
+	}
+
+	if spec.Components.Headers == nil {
+		spec.Components.Headers = make(map[string]*ogen.Header)
+	}
+	for k, v := range RateLimitHeaders.Append(RetryAfterHeader) {
+		if _, ok := spec.Components.Headers[k]; !ok {
+			spec.Components.Headers[k] = v
+		}
+	}
+
+	if resp.Headers == nil {
+		resp.Headers = make(map[string]*ogen.Header)
+	}
+	for k := range RateLimitHeaders {
+		resp.Headers[k] = &ogen.Header{Ref: "#/components/headers/" + k}
+	}
+	for k := range RetryAfterHeader {
+		resp.Headers[k] = &ogen.Header{Ref: "#/components/headers/" + k}
+	}
+}
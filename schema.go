@@ -152,7 +152,7 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 	entityName := Singularize(t.Name)
 
 	switch op {
-	case OperationCreate, OperationUpdate:
+	case OperationCreate, OperationUpdate, OperationReplace:
 		schema := &ogen.Schema{
 			Description: cmp.Or(
 				ta.GetOperationDescription(op),
@@ -207,19 +207,25 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 					schema.Properties = append(schema.Properties, *updated.ToProperty(f.Name))
 				}
 
-				if op == OperationCreate && !f.Optional && !f.Default {
+				if (op == OperationCreate && !f.Optional && !f.Default) || (op == OperationReplace && !f.Optional && !f.UpdateDefault) {
 					schema.Required = append(schema.Required, f.Name)
 				}
 			}
 		}
 
+		// Replace operations can set the same edges as update operations.
+		edgeOp := op
+		if op == OperationReplace {
+			edgeOp = OperationUpdate
+		}
+
 		for _, e := range t.Edges {
 			ea := GetAnnotation(e)
 
-			if ea.GetSkip(cfg) || ea.ReadOnly || !ea.HasOperation(cfg, op) {
+			if ea.GetSkip(cfg) || ea.ReadOnly || !ea.HasOperation(cfg, edgeOp) {
 				continue
 			}
-			if op == OperationUpdate && (e.Immutable || (e.Field() != nil && e.Field().Immutable)) {
+			if op != OperationCreate && (e.Immutable || (e.Field() != nil && e.Field().Immutable)) {
 				continue
 			}
 
@@ -233,6 +239,17 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 				panic(fmt.Sprintf("failed to generate schema for field %s: %v", e.Type.ID.StructField(), err))
 			}
 
+			if op == OperationCreate && IsNestedCreateEdge(e) {
+				// Nested create edges accept either the ID of an existing entity, or the
+				// create parameters of a new entity.
+				fieldSchema = &ogen.Schema{
+					OneOf: []*ogen.Schema{
+						fieldSchema,
+						{Ref: "#/components/schemas/" + Singularize(e.Type.Name) + "Create"},
+					},
+				}
+			}
+
 			if !e.Unique {
 				fieldSchema = fieldSchema.AsArray()
 			}
@@ -251,7 +268,9 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 					continue
 				}
 
-				if (op == OperationCreate && !e.Optional && !e.Field().Default) || (op == OperationUpdate && !e.Field().UpdateDefault) {
+				if (op == OperationCreate && !e.Optional && !e.Field().Default) ||
+					(op == OperationUpdate && !e.Field().UpdateDefault) ||
+					(op == OperationReplace && !e.Optional && !e.Field().UpdateDefault) {
 					schema.Required = append(schema.Required, e.Name)
 				}
 			}
@@ -271,7 +290,7 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 				schema.Properties = append(schema.Properties, *fieldSchema.ToProperty(e.Name))
 			}
 
-			if !slices.Contains(schema.Required, e.Name) && op == OperationCreate && !e.Optional {
+			if !slices.Contains(schema.Required, e.Name) && (op == OperationCreate || op == OperationReplace) && !e.Optional {
 				schema.Required = append(schema.Required, e.Name)
 			}
 		}
@@ -281,6 +300,8 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 			schemas[entityName+"Create"] = schema
 		case OperationUpdate:
 			schemas[entityName+"Update"] = schema
+		case OperationReplace:
+			schemas[entityName+"Replace"] = schema
 		default:
 			panic("unreachable")
 		}
@@ -346,7 +367,9 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 		for _, e := range t.Edges {
 			ea := GetAnnotation(e)
 
-			if ea.GetSkip(cfg) || !ea.GetEagerLoad(cfg) {
+			eagerLoad := ea.GetEagerLoad(cfg)
+
+			if ea.GetSkip(cfg) || (!eagerLoad && !ea.Includable) {
 				continue
 			}
 
@@ -355,6 +378,12 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 				Schema: &ogen.Schema{Ref: "#/components/schemas/" + Singularize(e.Type.Name)},
 			}
 
+			// Included edges are loaded the same way as reading the edge type directly,
+			// so they also contain the edges of the edge type.
+			if !eagerLoad {
+				prop.Schema.Ref += "Read"
+			}
+
 			if !e.Unique {
 				prop.Schema = prop.Schema.AsArray()
 
@@ -365,7 +394,7 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 				}
 			}
 
-			if !e.Optional {
+			if !e.Optional && eagerLoad {
 				// TODO: nullable?
 				// prop.Schema.Nullable = true
 				edgeSchema.Required = append(edgeSchema.Required, e.Name)
@@ -409,7 +438,13 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 			ea := GetAnnotation(edge)
 			ra := GetAnnotation(edge.Type)
 
-			if !ea.GetPagination(cfg, edge) || (!ra.GetPagination(cfg, edge) || cfg.DisableEagerLoadNonPagedOpt) {
+			// Edge endpoints can also override the pagination mode of the edge type, in
+			// which case the edge type list schema cannot be re-used either.
+			mode := GetPaginationMode(edge.Type, edge)
+			modeOverride := (ea.GetPagination(cfg, edge) || ra.GetPagination(cfg, edge)) &&
+				mode != GetPaginationMode(edge.Type, nil)
+
+			if !ea.GetPagination(cfg, edge) || (!ra.GetPagination(cfg, edge) || cfg.DisableEagerLoadNonPagedOpt) || modeOverride {
 				// This should allow setting the normal list operation as well, so don't return.
 				schema := ogen.NewSchema().SetRef("#/components/schemas/" + Singularize(edge.Type.Name) + "Read").AsArray()
 				schema.Description = fmt.Sprintf(
@@ -421,8 +456,8 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 
 				// If edge pagination is enabled, but edge type isn't paginated, we cannot re-use
 				// the paginated schema from the edge type.
-				if !ra.GetPagination(cfg, edge) && ea.GetPagination(cfg, edge) {
-					schema = toPagedSchema(schema)
+				if (!ra.GetPagination(cfg, edge) && ea.GetPagination(cfg, edge)) || modeOverride {
+					schema = toPagedSchema(schema, mode, GetCountStrategy(edge.Type))
 				}
 
 				// We're setting a specific schema for the edge response because we cannot re-use
@@ -440,12 +475,90 @@ func GetSchemaType(t *gen.Type, op Operation, edge *gen.Edge) map[string]*ogen.S
 
 		schemas[entityName+"List"] = toPagedSchema(
 			ogen.NewSchema().
-				SetRef("#/components/schemas/" + entityName + "Read").
+				SetRef("#/components/schemas/"+entityName+"Read").
 				SetDescription(fmt.Sprintf("A paginated result set of %s entities. Includes eager-loaded edges (if any) for each entity.", entityName)),
+			GetPaginationMode(t, nil),
+			GetCountStrategy(t),
 		)
 
 		dependencies = append(dependencies, OperationRead)
-	case OperationDelete:
+	case OperationCreateBulk:
+		schema := ogen.NewSchema().SetRef("#/components/schemas/" + entityName + "Create").AsArray()
+		schema.Description = fmt.Sprintf("A list of %s entities to create.", entityName)
+		schema.MinItems = ptr(uint64(1))
+		if cfg.BulkCreateLimit > 0 {
+			schema.MaxItems = ptr(uint64(cfg.BulkCreateLimit))
+		}
+		schemas[entityName+"CreateBulk"] = schema
+
+		schema = ogen.NewSchema().SetRef("#/components/schemas/" + entityName + "Read").AsArray()
+		schema.Description = fmt.Sprintf("A list of the created %s entities, in the same order as provided. Includes eager-loaded edges (if any) for each entity.", entityName)
+		schemas[entityName+"CreateBulkResponse"] = schema
+
+		dependencies = append(dependencies, OperationCreate, OperationRead)
+	case OperationUpdateBulk:
+		dependencies = append(dependencies, OperationUpdate)
+	case OperationRestore:
+		dependencies = append(dependencies, OperationRead)
+	case OperationUpsert:
+		// Upsert uses the same schema as create, except the upsert fields are provided
+		// through the path.
+		create := GetSchemaType(t, OperationCreate, nil)[entityName+"Create"]
+		upsertFields := GetUpsertFields(t)
+
+		schema := &ogen.Schema{
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				ta.Description,
+				fmt.Sprintf("A single %s entity and the fields that can be created/updated.", entityName),
+			),
+			Type:       "object",
+			Properties: ogen.Properties{},
+			Required:   []string{},
+		}
+
+		for _, prop := range create.Properties {
+			if !slices.ContainsFunc(upsertFields, func(f *gen.Field) bool { return f.Name == prop.Name }) {
+				schema.Properties = append(schema.Properties, prop)
+			}
+		}
+
+		for _, name := range create.Required {
+			if !slices.ContainsFunc(upsertFields, func(f *gen.Field) bool { return f.Name == name }) {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+
+		schemas[entityName+"Upsert"] = schema
+		dependencies = append(dependencies, OperationCreate, OperationRead)
+	case OperationAggregate:
+		schemas[entityName+"Aggregate"] = getAggregateSchema(t, schemas)
+
+		list := ogen.NewSchema().SetRef("#/components/schemas/" + entityName + "Aggregate").AsArray()
+		list.Description = fmt.Sprintf("A list of groups of aggregated %s entities.", entityName)
+		schemas[entityName+"AggregateList"] = list
+	case OperationImport:
+		schemas[entityName+"ImportResponse"] = getImportSchema(t)
+		dependencies = append(dependencies, OperationCreate)
+	case OperationEvents:
+		schemas[entityName+"Event"] = getEventSchema(t)
+		dependencies = append(dependencies, OperationRead)
+	case OperationHistory:
+		schemas[entityName+"History"] = getHistorySchema(t)
+		schemas[entityName+"HistoryList"] = toPagedSchema(
+			ogen.NewSchema().
+				SetRef("#/components/schemas/"+entityName+"History").
+				SetDescription(fmt.Sprintf("A paginated list of recorded versions of a %s entity, newest first.", entityName)),
+			PaginationOffset,
+			CountExact,
+		)
+		dependencies = append(dependencies, OperationRead)
+	case OperationHistoryRead:
+		schemas[entityName+"History"] = getHistorySchema(t)
+		dependencies = append(dependencies, OperationRead)
+	case OperationHistoryRestore:
+		dependencies = append(dependencies, OperationRead)
+	case OperationDelete, OperationDeleteBulk:
 	default:
 		panic(fmt.Sprintf("unsupported operation %q", op))
 	}
@@ -500,9 +613,10 @@ func hoistEnums(t *gen.Type, f *gen.Field, existing *ogen.Schema) (updated, asRe
 	return existing, nil, "", false
 }
 
-// toPagedSchema converts a response schema to a paged response schema, hoisting the
-// description from the response schema to the paged response schema.
-func toPagedSchema(schema *ogen.Schema) *ogen.Schema {
+// toPagedSchema converts a response schema to a paged (or cursor) response schema,
+// depending on the pagination mode, hoisting the description from the response schema
+// to the paged response schema.
+func toPagedSchema(schema *ogen.Schema, mode PaginationMode, strategy CountStrategy) *ogen.Schema {
 	desc := schema.Description
 	schema.Description = ""
 
@@ -513,7 +627,7 @@ func toPagedSchema(schema *ogen.Schema) *ogen.Schema {
 	return &ogen.Schema{
 		Description: desc,
 		AllOf: []*ogen.Schema{
-			{Ref: "#/components/schemas/PagedResponse"},
+			{Ref: "#/components/schemas/" + pagedResponseName(mode, strategy)},
 			{
 				Type: "object",
 				Properties: ogen.Properties{{
@@ -525,3 +639,16 @@ func toPagedSchema(schema *ogen.Schema) *ogen.Schema {
 		},
 	}
 }
+
+// pagedResponseName returns the name of the base response schema for the pagination mode
+// and count strategy.
+func pagedResponseName(mode PaginationMode, strategy CountStrategy) string {
+	switch {
+	case mode == PaginationCursor:
+		return "CursorResponse"
+	case strategy == CountEstimated, strategy == CountNone:
+		return "PagedResponseEstimated"
+	default:
+		return "PagedResponse"
+	}
+}
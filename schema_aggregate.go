@@ -0,0 +1,263 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+	"github.com/ogen-go/ogen/jsonschema"
+)
+
+// Aggregation represents the aggregations which can be applied to a field through the
+// aggregate operation (see [WithAggregate]).
+type Aggregation int
+
+const (
+	// AggregateGroupBy allows grouping results by the field. Time fields must be grouped
+	// using one of the time buckets (see [AggregateTimeBuckets]), e.g. "created_at:day".
+	AggregateGroupBy Aggregation = 1 << iota
+
+	AggregateSum // sum:<field>
+	AggregateAvg // avg:<field>
+	AggregateMin // min:<field>
+	AggregateMax // max:<field>
+
+	// AggregateGroupNumeric includes: sum, avg, min, max.
+	AggregateGroupNumeric = AggregateSum | AggregateAvg | AggregateMin | AggregateMax
+)
+
+// aggregateFuncs maps each numeric aggregation to the name of the metric, in the order
+// they're presented in the spec.
+var aggregateFuncs = []struct {
+	Aggregation Aggregation
+	Name        string
+}{
+	{AggregateSum, "sum"},
+	{AggregateAvg, "avg"},
+	{AggregateMin, "min"},
+	{AggregateMax, "max"},
+}
+
+// AggregateTimeBuckets are the buckets which time fields can be grouped by, e.g.
+// "created_at:week". Weeks start on Monday.
+var AggregateTimeBuckets = []string{"day", "week", "month"}
+
+// AggregateCount is the metric which counts the number of entities in each group, and
+// is the default metric if none are provided.
+const AggregateCount = "count"
+
+// Has returns if the aggregation has the provided aggregation.
+func (a Aggregation) Has(v Aggregation) bool {
+	return a&v != 0
+}
+
+// AggregateField represents a field which can be used with the aggregate operation.
+type AggregateField struct {
+	*gen.Field
+	Aggregation Aggregation
+}
+
+// GroupBy returns the "group_by" values for the field, if the field can be grouped.
+func (f *AggregateField) GroupBy() (values []string) {
+	if !f.Aggregation.Has(AggregateGroupBy) {
+		return nil
+	}
+	if !f.IsTime() {
+		return []string{f.Name}
+	}
+	for _, bucket := range AggregateTimeBuckets {
+		values = append(values, f.Name+":"+bucket)
+	}
+	return values
+}
+
+// Metrics returns the "metrics" values for the field, e.g. "sum:age".
+func (f *AggregateField) Metrics() (values []string) {
+	for _, fn := range aggregateFuncs {
+		if f.Aggregation.Has(fn.Aggregation) {
+			values = append(values, fn.Name+":"+f.Name)
+		}
+	}
+	return values
+}
+
+// isGroupableField returns true if results can be grouped by the field.
+func isGroupableField(f *gen.Field) bool {
+	switch {
+	case f.Type == nil || f.Sensitive():
+		return false
+	case f.IsTime(), f.IsEnum(), f.IsUUID():
+		return true
+	default:
+		return !f.HasGoType() && (f.IsString() || f.IsBool() || f.Type.Numeric())
+	}
+}
+
+// isNumericField returns true if numeric aggregations (sum, avg, etc) can be applied to
+// the field.
+func isNumericField(f *gen.Field) bool {
+	return f.Type != nil && !f.Sensitive() && !f.HasGoType() && f.Type.Numeric()
+}
+
+// GetAggregateFields returns the fields of the given type which can be used with the
+// aggregate operation (see [WithAggregate]).
+func GetAggregateFields(t *gen.Type) (fields []*AggregateField) {
+	cfg := GetConfig(t.Config)
+
+	for _, f := range t.Fields {
+		fa := GetAnnotation(f)
+		if fa.Aggregate == 0 || fa.GetSkip(cfg) {
+			continue
+		}
+		fields = append(fields, &AggregateField{Field: f, Aggregation: fa.Aggregate})
+	}
+	return fields
+}
+
+// getAggregateValues returns all "group_by" and "metrics" values for the given fields.
+func getAggregateValues(fields []*AggregateField) (groupBy, metrics []string) {
+	metrics = []string{AggregateCount}
+	for _, f := range fields {
+		groupBy = append(groupBy, f.GroupBy()...)
+		metrics = append(metrics, f.Metrics()...)
+	}
+	return groupBy, metrics
+}
+
+// aggregateParameters adds the "group_by" and "metrics" enums for the given type to the
+// spec, returning the associated query parameters.
+func aggregateParameters(spec *ogen.Spec, t *gen.Type) (params []*ogen.Parameter) {
+	groupBy, metrics := getAggregateValues(GetAggregateFields(t))
+
+	if len(groupBy) > 0 {
+		ref := Singularize(t.Name) + "AggregateGroupBy"
+		spec.Components.Schemas[ref] = &ogen.Schema{
+			Description: "All potential fields to group " + Singularize(t.Name) + " entities by.",
+			Type:        "string",
+			Enum:        sliceToRawMessage(groupBy),
+		}
+
+		params = append(params, &ogen.Parameter{
+			Name: "group_by",
+			In:   "query",
+			Description: "Comma-separated list of fields to group results by. Time fields are grouped " +
+				"using a time bucket (e.g. `created_at:day`), where each group contains the start " +
+				"of the bucket. If not provided, all results are aggregated into a single group.",
+			Style:   "form",
+			Explode: ptr(false),
+			Schema:  (&ogen.Schema{Ref: "#/components/schemas/" + ref}).AsArray(),
+		})
+	}
+
+	ref := Singularize(t.Name) + "AggregateMetric"
+	spec.Components.Schemas[ref] = &ogen.Schema{
+		Description: "All potential metrics to calculate for " + Singularize(t.Name) + " entities.",
+		Type:        "string",
+		Enum:        sliceToRawMessage(metrics),
+	}
+
+	schema := (&ogen.Schema{Ref: "#/components/schemas/" + ref}).AsArray()
+	schema.Default = jsonschema.RawValue(`["` + AggregateCount + `"]`)
+
+	params = append(params, &ogen.Parameter{
+		Name: "metrics",
+		In:   "query",
+		Description: "Comma-separated list of metrics to calculate for each group, in the format " +
+			"`<function>:<field>` (e.g. `sum:age`), or `count` to count the number of entities.",
+		Style:   "form",
+		Explode: ptr(false),
+		Schema:  schema,
+	})
+
+	return params
+}
+
+// getAggregateSchema returns the schema of a single group of the aggregate operation
+// for the given type. Enums of group fields are hoisted into the provided schemas.
+func getAggregateSchema(t *gen.Type, schemas map[string]*ogen.Schema) *ogen.Schema {
+	schema := &ogen.Schema{
+		Description: fmt.Sprintf(
+			"A single group of aggregated %s entities. Only the requested groups and metrics are included.",
+			Singularize(t.Name),
+		),
+		Type: "object",
+		Properties: ogen.Properties{
+			{
+				Name: AggregateCount,
+				Schema: &ogen.Schema{
+					Type:        "integer",
+					Description: "The number of entities in the group.",
+				},
+			},
+		},
+	}
+
+	fields := GetAggregateFields(t)
+
+	for _, f := range fields {
+		if !f.Aggregation.Has(AggregateGroupBy) {
+			continue
+		}
+
+		if f.IsTime() {
+			schema.Properties = append(schema.Properties, ogen.Property{
+				Name: f.Name,
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Format:      "date",
+					Description: fmt.Sprintf("The start of the time bucket of field %q.", f.Name),
+				},
+			})
+			continue
+		}
+
+		fieldSchema, err := GetSchemaField(f.Field)
+		if err != nil {
+			panic(fmt.Sprintf("failed to generate schema for field %s: %v", f.StructField(), err))
+		}
+
+		// Hoist enums into components to reduce duplication where possible.
+		if updated, asRef, ref, ok := hoistEnums(t, f.Field, fieldSchema); ok {
+			schemas[ref] = updated
+			fieldSchema = asRef
+		}
+
+		schema.Properties = append(schema.Properties, ogen.Property{Name: f.Name, Schema: fieldSchema})
+	}
+
+	for _, f := range fields {
+		for _, fn := range aggregateFuncs {
+			if !f.Aggregation.Has(fn.Aggregation) {
+				continue
+			}
+			schema.Properties = append(schema.Properties, ogen.Property{
+				Name: fn.Name + "_" + f.Name,
+				Schema: &ogen.Schema{
+					Type:        "number",
+					Description: fmt.Sprintf("The %s of field %q in the group.", fn.Name, f.Name),
+				},
+			})
+		}
+	}
+
+	return schema
+}
+
+// validateAggregate ensures that the aggregations of the fields on the given type (if
+// any) can be applied to the fields.
+func validateAggregate(t *gen.Type) error {
+	for _, f := range t.Fields {
+		a := GetAnnotation(f).Aggregate
+		if a.Has(AggregateGroupBy) && !isGroupableField(f) {
+			return fmt.Errorf("aggregate field %q on %q cannot be grouped, only non-sensitive string, bool, numeric, enum, uuid, and time fields are supported", f.Name, t.Name)
+		}
+		if a.Has(AggregateGroupNumeric) && !isNumericField(f) {
+			return fmt.Errorf("aggregate field %q on %q must be a non-sensitive numeric field to use sum, avg, min, or max", f.Name, t.Name)
+		}
+	}
+	return nil
+}
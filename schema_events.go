@@ -0,0 +1,111 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+// MediaTypeEventStream is the media type of server-sent event streams (see
+// [OperationEvents]).
+const MediaTypeEventStream = "text/event-stream"
+
+// GetEventTypes returns all types which support the events operation (see
+// [OperationEvents]). Types without an ID field do not support events.
+func GetEventTypes(g *gen.Graph) (types []*gen.Type) {
+	cfg := GetConfig(g.Config)
+
+	for _, t := range g.Nodes {
+		ta := GetAnnotation(t)
+		if t.ID == nil || ta.GetSkip(cfg) || ta.DisableHandler || !ta.HasOperation(cfg, OperationEvents) {
+			continue
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// eventParameters adds the shared "Last-Event-ID" header and "entity" query parameters
+// of the events operation to the spec, returning references to them.
+func eventParameters(spec *ogen.Spec) []*ogen.Parameter {
+	if _, ok := spec.Components.Parameters["LastEventID"]; !ok {
+		spec.Components.Parameters["LastEventID"] = &ogen.Parameter{
+			Name:        "Last-Event-ID",
+			In:          "header",
+			Description: "Resume the stream after the event with the provided ID, sending any buffered events which occurred since.",
+			Schema:      &ogen.Schema{Type: "string"},
+		}
+	}
+
+	if _, ok := spec.Components.Parameters["EventEntity"]; !ok {
+		spec.Components.Parameters["EventEntity"] = &ogen.Parameter{
+			Name:        "entity",
+			In:          "query",
+			Description: "Include the (eager-loaded) entity in create and update events.",
+			Schema: &ogen.Schema{
+				Type:    "boolean",
+				Default: ogen.Default(json.RawMessage(`false`)),
+			},
+		}
+	}
+
+	return []*ogen.Parameter{
+		{Ref: "#/components/parameters/LastEventID"},
+		{Ref: "#/components/parameters/EventEntity"},
+	}
+}
+
+// getEventSchema returns the schema of the events of the given type, which is the data
+// of each server-sent event.
+func getEventSchema(t *gen.Type) *ogen.Schema {
+	entityName := Singularize(t.Name)
+
+	idSchema, err := GetSchemaField(t.ID)
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate schema for field %s: %v", t.ID.StructField(), err))
+	}
+	idSchema.Description = fmt.Sprintf("The ID of the %s entity.", entityName)
+
+	return &ogen.Schema{
+		Description: fmt.Sprintf("A change to a %s entity.", entityName),
+		Type:        "object",
+		Properties: ogen.Properties{
+			{
+				Name: "op",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Description: "The operation which changed the entity. Soft-deleting an entity results in a delete event.",
+					Enum:        sliceToRawMessage([]string{"create", "update", "delete"}),
+				},
+			},
+			{
+				Name: "type",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Description: "The type of the entity.",
+				},
+			},
+			{Name: "id", Schema: idSchema},
+			{
+				Name: "entity",
+				// Only included if requested, and never for delete events.
+				Schema: &ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"},
+			},
+			{
+				Name: "time",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Format:      "date-time",
+					Description: "When the change occurred.",
+				},
+			},
+		},
+		Required: []string{"op", "type", "id", "time"},
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+const (
+	// MediaTypeNDJSON is the media type of newline-delimited JSON exports (see
+	// [Config.ListExport]).
+	MediaTypeNDJSON = "application/x-ndjson"
+
+	// MediaTypeCSV is the media type of CSV exports (see [Config.ListExport]).
+	MediaTypeCSV = "text/csv"
+)
+
+// HasExport returns true if the list operation of the given type supports streaming
+// exports (see [Config.ListExport]).
+func HasExport(t *gen.Type) bool {
+	cfg := GetConfig(t.Config)
+	ta := GetAnnotation(t)
+	return cfg.ListExport && t.ID != nil && !ta.GetSkip(cfg) && ta.HasOperation(cfg, OperationList)
+}
+
+// GetExportColumns returns the columns of CSV exports for the given type. This includes
+// the ID, all non-sensitive and non-skipped fields, as well as the ID and fields of any
+// eager-loaded unique edges, using dot notation (e.g. "owner.name"). Types without an ID
+// field do not support exports.
+func GetExportColumns(t *gen.Type) (columns []string) {
+	if t.ID == nil {
+		return nil
+	}
+
+	cfg := GetConfig(t.Config)
+	columns = append(columns, t.ID.Name)
+
+	for _, f := range t.Fields {
+		if GetAnnotation(f).GetSkip(cfg) || f.Sensitive() {
+			continue
+		}
+		columns = append(columns, f.Name)
+	}
+
+	for _, e := range t.Edges {
+		ea := GetAnnotation(e)
+		if !e.Unique || e.Type.ID == nil || ea.GetSkip(cfg) || !ea.GetEagerLoad(cfg) || GetAnnotation(e.Type).GetSkip(cfg) {
+			continue
+		}
+
+		columns = append(columns, e.Name+"."+e.Type.ID.Name)
+
+		for _, f := range e.Type.Fields {
+			if GetAnnotation(f).GetSkip(cfg) || f.Sensitive() {
+				continue
+			}
+			columns = append(columns, e.Name+"."+f.Name)
+		}
+	}
+
+	return columns
+}
+
+// exportParameter adds the shared "format" query parameter to the spec, returning a
+// reference to it.
+func exportParameter(spec *ogen.Spec) *ogen.Parameter {
+	if _, ok := spec.Components.Parameters["ExportFormat"]; !ok {
+		spec.Components.Parameters["ExportFormat"] = &ogen.Parameter{
+			Name: "format",
+			In:   "query",
+			Description: "The format of the response. `ndjson` and `csv` stream all results matching " +
+				"the provided filters and sorting (ignoring pagination), as newline-delimited JSON " +
+				"or CSV respectively. Can also be requested through the `Accept` header (`" +
+				MediaTypeNDJSON + "` or `" + MediaTypeCSV + "`).",
+			Schema: &ogen.Schema{
+				Type: "string",
+				Enum: sliceToRawMessage([]string{"json", "ndjson", "csv"}),
+			},
+		}
+	}
+	return &ogen.Parameter{Ref: "#/components/parameters/ExportFormat"}
+}
+
+// addExportContent adds the NDJSON and CSV export media types to the provided (list)
+// response of the given type.
+func addExportContent(resp *ogen.Response, t *gen.Type) {
+	resp.Content[MediaTypeNDJSON] = ogen.Media{
+		Schema: &ogen.Schema{
+			Ref: "#/components/schemas/" + Singularize(t.Name) + "Read",
+		},
+	}
+	resp.Content[MediaTypeCSV] = ogen.Media{
+		Schema: &ogen.Schema{
+			Type:        "string",
+			Description: "CSV export, with a header row, and one row per entity.",
+		},
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+	"github.com/ogen-go/ogen"
+)
+
+// GetHistoryTypes returns all types which have history enabled (see [WithHistory]).
+// Types without an ID field do not support history.
+func GetHistoryTypes(g *gen.Graph) (types []*gen.Type) {
+	cfg := GetConfig(g.Config)
+
+	for _, t := range g.Nodes {
+		ta := GetAnnotation(t)
+		if t.ID == nil || ta.GetSkip(cfg) || !ta.History {
+			continue
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// GetHistoryRestoreFields returns the fields of the given type which are restored by the
+// history restore operation (see [OperationHistoryRestore]). Immutable and sensitive
+// fields, fields which aren't included in the JSON representation of the entity, fields
+// with an update default (e.g. "updated_at"), the version field (see [WithVersionField])
+// and the soft-delete field (see [WithSoftDelete]) are excluded.
+func GetHistoryRestoreFields(t *gen.Type) (fields []*gen.Field) {
+	version := GetVersionField(t)
+	softDelete := GetSoftDeleteField(t)
+
+	for _, f := range t.Fields {
+		if f.Immutable || f.Sensitive() || f.UpdateDefault || (version != nil && f.Name == version.Name) {
+			continue
+		}
+		if softDelete != nil && f.Name == softDelete.Name {
+			continue
+		}
+		if name, _, _ := strings.Cut(reflect.StructTag(f.StructTag).Get("json"), ","); name == "-" {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isHistoryOperation returns true if the operation is one of the history operations,
+// which require history to be enabled (see [WithHistory]).
+func isHistoryOperation(op Operation) bool {
+	return op == OperationHistory || op == OperationHistoryRead || op == OperationHistoryRestore
+}
+
+// HistoryMixin can be used on an (otherwise empty) schema to store the history of
+// entities (see [WithHistory]) in the database. When a schema uses this mixin, the
+// generated server stores history entries using the schema (see NewEntHistoryStore),
+// within the same transaction as the change which caused them, rather than in-memory.
+// The schema is excluded from the spec and the generated endpoints.
+type HistoryMixin struct{ mixin.Schema }
+
+// historyFields are the fields (and associated types) which must exist on schemas which
+// are used to store history entries.
+var historyFields = map[string]field.Type{
+	"entity_type": field.TypeString,
+	"entity_id":   field.TypeString,
+	"version":     field.TypeInt,
+	"action":      field.TypeString,
+	"actor":       field.TypeString,
+	"request_id":  field.TypeString,
+	"entity":      field.TypeBytes,
+	"created_at":  field.TypeTime,
+}
+
+func (HistoryMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("entity_type").
+			Immutable().
+			NotEmpty().
+			Comment(`Type of the entity, e.g. "Pet".`),
+		field.String("entity_id").
+			Immutable().
+			NotEmpty().
+			Comment("ID of the entity."),
+		field.Int("version").
+			Immutable().
+			Positive().
+			Comment("Version of the entity, starting at 1."),
+		field.String("action").
+			Immutable().
+			Comment(`Action which resulted in the version, one of "create", "update" or "delete".`),
+		field.String("actor").
+			Immutable().
+			Default("").
+			Comment("Actor which made the change, if known."),
+		field.String("request_id").
+			Immutable().
+			Default("").
+			Comment("ID of the request which made the change, if known."),
+		field.Bytes("entity").
+			Immutable().
+			Comment("JSON snapshot of the entity."),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("Time in which the version was created."),
+	}
+}
+
+func (HistoryMixin) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id", "version").Unique(),
+	}
+}
+
+func (HistoryMixin) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		Annotation{HistoryStore: true, Skip: true},
+	}
+}
+
+// GetHistoryStoreType returns the type used to store history entries (see
+// [HistoryMixin]), or nil if there is no such type, or no types have history enabled.
+func GetHistoryStoreType(g *gen.Graph) *gen.Type {
+	if len(GetHistoryTypes(g)) == 0 {
+		return nil
+	}
+
+	for _, t := range g.Nodes {
+		if GetAnnotation(t).HistoryStore {
+			return t
+		}
+	}
+	return nil
+}
+
+// validateHistoryStore ensures that the given type (if used to store history entries)
+// has all of the fields required by the generated store.
+func validateHistoryStore(t *gen.Type) error {
+	if !GetAnnotation(t).HistoryStore {
+		return nil
+	}
+
+	if t.ID == nil {
+		return fmt.Errorf("history store %q must have an ID", t.Name)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(historyFields)) {
+		typ := historyFields[name]
+		idx := slices.IndexFunc(t.Fields, func(f *gen.Field) bool { return f.Name == name })
+		if idx < 0 || t.Fields[idx].Type == nil || t.Fields[idx].Type.Type != typ {
+			return fmt.Errorf("history store %q must have a %q field of type %s (see HistoryMixin)", t.Name, name, typ)
+		}
+	}
+	return nil
+}
+
+// historyVersionParameter adds the shared "version" path parameter of the history
+// operations to the spec, returning a reference to it.
+func historyVersionParameter(spec *ogen.Spec) *ogen.Parameter {
+	if _, ok := spec.Components.Parameters["HistoryVersion"]; !ok {
+		spec.Components.Parameters["HistoryVersion"] = &ogen.Parameter{
+			Name:        "version",
+			In:          "path",
+			Description: "The version of the entity, as returned by the history operation.",
+			Required:    true,
+			Schema:      ogen.Int().SetMinimum(ptr(int64(1))),
+		}
+	}
+	return &ogen.Parameter{Ref: "#/components/parameters/HistoryVersion"}
+}
+
+// getHistorySchema returns the schema of the history entries of the given type.
+func getHistorySchema(t *gen.Type) *ogen.Schema {
+	entityName := Singularize(t.Name)
+
+	return &ogen.Schema{
+		Description: fmt.Sprintf("A recorded version of a %s entity.", entityName),
+		Type:        "object",
+		Properties: ogen.Properties{
+			{
+				Name: "version",
+				Schema: &ogen.Schema{
+					Type:        "integer",
+					Description: "The version of the entity, starting at 1.",
+					Minimum:     ogen.Int().SetMinimum(ptr(int64(1))).Minimum,
+				},
+			},
+			{
+				Name: "action",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Description: "The action which resulted in the version. Soft-deleting an entity results in a delete action.",
+					Enum:        sliceToRawMessage([]string{"create", "update", "delete"}),
+				},
+			},
+			{
+				Name: "actor",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Description: "The actor which made the change, if known.",
+				},
+			},
+			{
+				Name: "request_id",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Description: "The ID of the request which made the change, if known.",
+				},
+			},
+			{
+				Name: "time",
+				Schema: &ogen.Schema{
+					Type:        "string",
+					Format:      "date-time",
+					Description: "When the change occurred.",
+				},
+			},
+			{
+				Name: "entity",
+				// Snapshots don't include edges.
+				Schema: &ogen.Schema{Ref: "#/components/schemas/" + entityName},
+			},
+		},
+		Required: []string{"version", "action", "time", "entity"},
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// IdempotencyStoreMixin can be used on an (otherwise empty) schema to store the responses
+// of requests which include an "Idempotency-Key" header (see [Config.IdempotencyKey]) in
+// the database. When a schema uses this mixin, the generated server includes an ent-backed
+// IdempotencyStore (NewEntIdempotencyStore), which can be used in place of the default
+// in-memory store, e.g. when running multiple replicas of the server. The schema is
+// excluded from the spec and the generated endpoints.
+type IdempotencyStoreMixin struct{ mixin.Schema }
+
+// idempotencyStoreFields are the fields (and associated types) which must exist on schemas
+// which are used to store idempotency keys.
+var idempotencyStoreFields = map[string]field.Type{
+	"key":         field.TypeString,
+	"hash":        field.TypeString,
+	"status_code": field.TypeInt,
+	"header":      field.TypeJSON,
+	"body":        field.TypeBytes,
+	"created_at":  field.TypeTime,
+}
+
+func (IdempotencyStoreMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			Unique().
+			Immutable().
+			NotEmpty().
+			MaxLen(64).
+			Comment("Hash of the idempotency key, scoped to the method and path of the request."),
+		field.String("hash").
+			Immutable().
+			Comment("Hash of the request, used to detect re-use of the key with a different request."),
+		field.Int("status_code").
+			Default(0).
+			Comment("Status code of the response, or zero if the request is still in progress."),
+		field.JSON("header", map[string][]string{}).
+			Optional().
+			Comment("Headers of the response."),
+		field.Bytes("body").
+			Optional().
+			Comment("Body of the response."),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("Time in which the key was initially used."),
+	}
+}
+
+func (IdempotencyStoreMixin) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		Annotation{IdempotencyStore: true, Skip: true},
+	}
+}
+
+// GetIdempotencyStoreType returns the type used to store idempotency keys (see
+// [IdempotencyStoreMixin]), or nil if there is no such type, or idempotency keys
+// aren't enabled.
+func GetIdempotencyStoreType(g *gen.Graph) *gen.Type {
+	if !GetConfig(g.Config).IdempotencyKey {
+		return nil
+	}
+
+	for _, t := range g.Nodes {
+		if GetAnnotation(t).IdempotencyStore {
+			return t
+		}
+	}
+	return nil
+}
+
+// validateIdempotencyStore ensures that the given type (if used to store idempotency
+// keys) has all of the fields required by the generated store.
+func validateIdempotencyStore(t *gen.Type) error {
+	if !GetAnnotation(t).IdempotencyStore {
+		return nil
+	}
+
+	if t.ID == nil {
+		return fmt.Errorf("idempotency store %q must have an ID", t.Name)
+	}
+
+	for name, typ := range idempotencyStoreFields {
+		idx := slices.IndexFunc(t.Fields, func(f *gen.Field) bool { return f.Name == name })
+		if idx < 0 || t.Fields[idx].Type == nil || t.Fields[idx].Type.Type != typ {
+			return fmt.Errorf("idempotency store %q must have a %q field of type %s (see IdempotencyStoreMixin)", t.Name, name, typ)
+		}
+	}
+	return nil
+}
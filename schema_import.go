@@ -0,0 +1,212 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+const (
+	// ImportAtomic is the default mode of the import operation, where either all rows are
+	// created, or none are (if any row fails).
+	ImportAtomic = "atomic"
+
+	// ImportBestEffort is the mode of the import operation where each row is created
+	// independently, so rows which fail don't prevent other rows from being created.
+	ImportBestEffort = "best-effort"
+)
+
+// isRawImportField returns true if the JSON representation of the field isn't a string,
+// in which case CSV values of the field are decoded as raw JSON.
+func isRawImportField(f *gen.Field) bool {
+	return f.Type != nil && (f.Type.Numeric() || f.IsBool() || f.IsJSON())
+}
+
+// GetImportRawFields returns the fields (and edges) of the create parameters of the given
+// type, which are decoded from CSV values as raw JSON (e.g. numbers, booleans, arrays,
+// objects, and edges with numeric IDs), rather than as strings, when using the import
+// operation (see [OperationImport]).
+func GetImportRawFields(t *gen.Type) (fields []string) {
+	cfg := GetConfig(t.Config)
+
+	if t.ID != nil && GetAnnotation(t).GetAllowClientIDs(cfg) && isRawImportField(t.ID) {
+		fields = append(fields, t.ID.Name)
+	}
+
+	for _, f := range t.Fields {
+		fa := GetAnnotation(f)
+		if fa.GetSkip(cfg) || fa.ReadOnly || !isRawImportField(f) {
+			continue
+		}
+		fields = append(fields, f.Name)
+	}
+
+	for _, e := range t.Edges {
+		ea := GetAnnotation(e)
+		if ea.GetSkip(cfg) || ea.ReadOnly || e.Type.ID == nil || !ea.HasOperation(cfg, OperationCreate) {
+			continue
+		}
+
+		// Edge fields are provided through the field itself.
+		if f := e.Field(); f != nil {
+			if isRawImportField(f) && !GetAnnotation(f).ReadOnly && !slices.Contains(fields, f.Name) {
+				fields = append(fields, f.Name)
+			}
+			continue
+		}
+
+		if !e.Unique || IsNestedCreateEdge(e) || isRawImportField(e.Type.ID) {
+			fields = append(fields, e.Name)
+		}
+	}
+
+	return fields
+}
+
+// importParameters adds the shared "dry_run" and "mode" query parameters of the import
+// operation to the spec, returning references to them.
+func importParameters(spec *ogen.Spec) []*ogen.Parameter {
+	if _, ok := spec.Components.Parameters["ImportDryRun"]; !ok {
+		spec.Components.Parameters["ImportDryRun"] = &ogen.Parameter{
+			Name:        "dry_run",
+			In:          "query",
+			Description: "Validate all rows without creating any entities. The report contains the errors (if any) of each row.",
+			Schema: &ogen.Schema{
+				Type:    "boolean",
+				Default: ogen.Default(json.RawMessage(`false`)),
+			},
+		}
+	}
+
+	if _, ok := spec.Components.Parameters["ImportMode"]; !ok {
+		spec.Components.Parameters["ImportMode"] = &ogen.Parameter{
+			Name: "mode",
+			In:   "query",
+			Description: "`" + ImportAtomic + "` creates all rows in a single transaction, so no entities are " +
+				"created if any row fails. `" + ImportBestEffort + "` creates each row independently, " +
+				"skipping rows which fail.",
+			Schema: &ogen.Schema{
+				Type:    "string",
+				Enum:    sliceToRawMessage([]string{ImportAtomic, ImportBestEffort}),
+				Default: ogen.Default(json.RawMessage(fmt.Sprintf("%q", ImportAtomic))),
+			},
+		}
+	}
+
+	return []*ogen.Parameter{
+		{Ref: "#/components/parameters/ImportDryRun"},
+		{Ref: "#/components/parameters/ImportMode"},
+	}
+}
+
+// importRequestBody returns the request body of the import operation for the given type,
+// which can either be a raw CSV or newline-delimited JSON file, or an uploaded file.
+func importRequestBody(t *gen.Type) *ogen.RequestBody {
+	entityName := Singularize(t.Name)
+
+	return &ogen.RequestBody{
+		Required: true,
+		Content: map[string]ogen.Media{
+			MediaTypeCSV: {
+				Schema: &ogen.Schema{
+					Type: "string",
+					Description: fmt.Sprintf(
+						"CSV file, with a header row of %s fields (the same as when creating a single %s), and one entity per row. Empty values are omitted.",
+						entityName,
+						entityName,
+					),
+				},
+			},
+			MediaTypeNDJSON: {
+				Schema: &ogen.Schema{Ref: "#/components/schemas/" + entityName + "Create"},
+			},
+			"multipart/form-data": {
+				Schema: &ogen.Schema{
+					Type: "object",
+					Properties: ogen.Properties{
+						{
+							Name: "file",
+							Schema: &ogen.Schema{
+								Type:        "string",
+								Format:      "binary",
+								Description: "CSV (.csv) or newline-delimited JSON (.ndjson, .jsonl) file.",
+							},
+						},
+					},
+					Required: []string{"file"},
+				},
+			},
+		},
+	}
+}
+
+// getImportSchema returns the schema of the import operation report for the given type.
+func getImportSchema(t *gen.Type) *ogen.Schema {
+	entityName := Singularize(t.Name)
+
+	idSchema, err := GetSchemaField(t.ID)
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate schema for field %s: %v", t.ID.StructField(), err))
+	}
+	idSchema.Description = fmt.Sprintf("The ID of the created %s entity. Not included for dry runs, or rows which failed.", entityName)
+
+	return &ogen.Schema{
+		Description: fmt.Sprintf("The report of an import of %s entities.", entityName),
+		Type:        "object",
+		Properties: ogen.Properties{
+			{
+				Name: "dry_run",
+				Schema: &ogen.Schema{
+					Type:        "boolean",
+					Description: "If true, no entities were created.",
+				},
+			},
+			{
+				Name: "created",
+				Schema: &ogen.Schema{
+					Type:        "integer",
+					Description: "The number of created entities (or for dry runs, the number of rows which would have been created).",
+				},
+			},
+			{
+				Name: "failed",
+				Schema: &ogen.Schema{
+					Type:        "integer",
+					Description: "The number of rows which failed.",
+				},
+			},
+			{
+				Name: "rows",
+				Schema: (&ogen.Schema{
+					Type: "object",
+					Properties: ogen.Properties{
+						{
+							Name: "row",
+							Schema: &ogen.Schema{
+								Type:        "integer",
+								Description: "The row number, starting at 1 (excluding the CSV header row).",
+							},
+						},
+						{Name: "id", Schema: idSchema},
+						{
+							Name: "error",
+							Schema: &ogen.Schema{
+								Type:        "string",
+								Description: "The reason the row failed, if it failed.",
+							},
+						},
+					},
+					Required: []string{"row"},
+				}).AsArray(),
+			},
+		},
+		Required: []string{"dry_run", "created", "failed", "rows"},
+	}
+}
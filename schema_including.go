@@ -0,0 +1,37 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"entgo.io/ent/entc/gen"
+)
+
+// GetIncludableEdges returns a list of edge paths which can be provided to the
+// "include" query parameter for the given type (e.g. "pets", "pets.owner"). Only
+// edges which are includable (see [WithIncludable]) are returned, and it recurses
+// through edges up to [Config.MaxIncludeDepth].
+func GetIncludableEdges(t *gen.Type) []string {
+	return includableEdges(t, "", GetConfig(t.Config).MaxIncludeDepth)
+}
+
+func includableEdges(t *gen.Type, prefix string, depth int) (includable []string) {
+	if depth < 1 {
+		return nil
+	}
+
+	cfg := GetConfig(t.Config)
+
+	for _, e := range t.Edges {
+		ea := GetAnnotation(e)
+		if !ea.Includable || ea.GetSkip(cfg) || GetAnnotation(e.Type).GetSkip(cfg) {
+			continue
+		}
+
+		includable = append(includable, prefix+e.Name)
+		includable = append(includable, includableEdges(e.Type, prefix+e.Name+".", depth-1)...)
+	}
+
+	return includable
+}
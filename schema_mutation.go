@@ -0,0 +1,57 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"entgo.io/ent/entc/gen"
+)
+
+// GetMutableEdges returns the edges of the given type which should have endpoints to
+// attach and detach individual entities (see [WithEdgeMutation]). Unique edges are
+// only returned if they are optional.
+func GetMutableEdges(t *gen.Type) (edges []*gen.Edge) {
+	cfg := GetConfig(t.Config)
+	ta := GetAnnotation(t)
+
+	if t.ID == nil || ta.GetSkip(cfg) || !ta.HasOperation(cfg, OperationUpdate) {
+		return nil
+	}
+
+	for _, e := range t.Edges {
+		ea := GetAnnotation(e)
+
+		if !ea.EdgeMutation || ea.GetSkip(cfg) || ea.ReadOnly || !ea.HasOperation(cfg, OperationUpdate) {
+			continue
+		}
+
+		if e.Immutable || e.Type.ID == nil || GetAnnotation(e.Type).GetSkip(cfg) || (e.Unique && !e.Optional) {
+			continue
+		}
+
+		if f := e.Field(); f != nil && (f.Immutable || GetAnnotation(f).ReadOnly) {
+			continue
+		}
+
+		edges = append(edges, e)
+	}
+
+	return edges
+}
+
+// GetPatchableJSONFields returns the JSON-typed fields of the given type which can be
+// patched by update requests (e.g. through a JSON Patch document), where the patch is
+// applied to the current value of the field.
+func GetPatchableJSONFields(t *gen.Type) (fields []*gen.Field) {
+	cfg := GetConfig(t.Config)
+
+	for _, f := range t.Fields {
+		if !f.IsJSON() || f.Nillable || f.Immutable || GetAnnotation(f).ReadOnly || GetAnnotation(f).GetSkip(cfg) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	return fields
+}
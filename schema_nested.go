@@ -0,0 +1,65 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"slices"
+
+	"entgo.io/ent/entc/gen"
+)
+
+// IsNestedCreateEdge returns true if the given edge accepts the create parameters of the
+// edge type, in place of (or alongside) IDs, when creating an entity (see [WithNestedCreate]).
+func IsNestedCreateEdge(e *gen.Edge) bool {
+	cfg := GetConfig(e.Owner.Config)
+	ea := GetAnnotation(e)
+
+	if !ea.NestedCreate || ea.GetSkip(cfg) || ea.ReadOnly || !ea.HasOperation(cfg, OperationCreate) {
+		return false
+	}
+
+	if e.Owner.ID == nil || GetAnnotation(e.Owner).GetSkip(cfg) {
+		return false
+	}
+
+	if e.Type.ID == nil || e.Through != nil || e.Field() != nil {
+		return false
+	}
+
+	ta := GetAnnotation(e.Type)
+	return !ta.GetSkip(cfg) && ta.HasOperation(cfg, OperationCreate)
+}
+
+// GetNestedCreateEdges returns the edges of the given type which accept nested create
+// parameters (see [WithNestedCreate]).
+func GetNestedCreateEdges(t *gen.Type) (edges []*gen.Edge) {
+	for _, e := range t.Edges {
+		if IsNestedCreateEdge(e) {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// GetNestedCreateTypes returns all types in the graph which are involved in nested creates,
+// either because they have edges which accept nested create parameters, or because they
+// can be created through such an edge.
+func GetNestedCreateTypes(g *gen.Graph) (types []*gen.Type) {
+	for _, t := range g.Nodes {
+		for _, e := range GetNestedCreateEdges(t) {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+			if !slices.Contains(types, e.Type) {
+				types = append(types, e.Type)
+			}
+		}
+	}
+
+	slices.SortStableFunc(types, func(a, b *gen.Type) int {
+		return slices.Index(g.Nodes, a) - slices.Index(g.Nodes, b)
+	})
+	return types
+}
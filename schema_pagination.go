@@ -0,0 +1,115 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+	"slices"
+
+	"entgo.io/ent/entc/gen"
+)
+
+// PaginationMode represents the strategy used to paginate list operations.
+type PaginationMode string
+
+const (
+	// PaginationOffset uses the "page" and "per_page" query parameters, which map to
+	// LIMIT/OFFSET queries. Responses include the total count of results, and the last
+	// page number.
+	PaginationOffset PaginationMode = "offset"
+	// PaginationCursor uses an opaque "cursor" query parameter, which encodes the sort
+	// field value and ID of the last result (keyset pagination). This is considerably
+	// faster on large tables, and results don't shift if entities are created or deleted
+	// between page requests. Responses don't include the total count of results.
+	//
+	// Sort field values are compared by the database, so time fields must be stored in
+	// a sortable format (e.g. "_time_format=sqlite" with the modernc.org/sqlite driver).
+	PaginationCursor PaginationMode = "cursor"
+)
+
+// AllPaginationModes is a list of all supported pagination modes.
+var AllPaginationModes = []PaginationMode{PaginationOffset, PaginationCursor}
+
+// CountStrategy represents the strategy used to calculate the total number of results
+// for list operations using [PaginationOffset].
+type CountStrategy string
+
+const (
+	// CountExact runs a count query for every paged list request, so responses include
+	// the exact total count of results, and the last page number.
+	CountExact CountStrategy = "exact"
+	// CountEstimated uses the CountEstimator provided to the generated server (e.g. based
+	// on Postgres table statistics) for unfiltered list requests, so the total count of
+	// results and the last page number may be approximate. Filtered requests (and requests
+	// without an estimator) fall back to an exact count.
+	CountEstimated CountStrategy = "estimated"
+	// CountNone skips the count query entirely, and detects the last page by fetching one
+	// extra result. The total count of results and the last page number are based on the
+	// results seen so far, and are marked as estimated until the last page is reached.
+	CountNone CountStrategy = "none"
+)
+
+// AllCountStrategies is a list of all supported count strategies.
+var AllCountStrategies = []CountStrategy{CountExact, CountEstimated, CountNone}
+
+// GetCountStrategy returns the count strategy used when listing the given type with
+// [PaginationOffset], which falls back to [Config.CountStrategy].
+func GetCountStrategy(t *gen.Type) CountStrategy {
+	strategy := GetAnnotation(t).GetCountStrategy(GetConfig(t.Config))
+
+	if !slices.Contains(AllCountStrategies, strategy) {
+		panic(fmt.Sprintf("unsupported count strategy %q on schema %q", strategy, t.Name))
+	}
+	return strategy
+}
+
+// GetPaginationMode returns the pagination mode used when listing the given type. If
+// an edge is provided, the edge annotation takes precedence over the type annotation,
+// and both fall back to [Config.PaginationMode]. Types without an ID field always use
+// [PaginationOffset], as the ID is required as a tie-breaker for cursors.
+func GetPaginationMode(t *gen.Type, edge *gen.Edge) PaginationMode {
+	if t.ID == nil {
+		return PaginationOffset
+	}
+
+	mode := GetAnnotation(t).GetPaginationMode(GetConfig(t.Config))
+
+	if edge != nil {
+		if ea := GetAnnotation(edge); ea.PaginationMode != "" {
+			mode = ea.PaginationMode
+		}
+	}
+
+	if !slices.Contains(AllPaginationModes, mode) {
+		panic(fmt.Sprintf("unsupported pagination mode %q on schema %q", mode, t.Name))
+	}
+	return mode
+}
+
+// GetCursorFields returns the fields of the given type which can be used as the sort
+// field for cursor-based pagination. This is a subset of the sortable fields, which
+// excludes edge-based sorting, and any fields that may be NULL (as NULL values cannot
+// be compared reliably across databases). The ID field is always included first.
+func GetCursorFields(t *gen.Type) (fields []*gen.Field) {
+	if t.ID == nil {
+		return nil
+	}
+
+	cfg := GetConfig(t.Config)
+	fields = append(fields, t.ID)
+
+	for _, f := range t.Fields {
+		fa := GetAnnotation(f)
+		if fa.GetSkip(cfg) || f.Sensitive() || !fa.Sortable || f.Optional || f.Nillable {
+			continue
+		}
+		if !f.IsString() && !f.IsTime() && !f.IsBool() && !f.IsInt() && !f.IsInt64() && !f.IsUUID() {
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	return fields
+}
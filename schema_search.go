@@ -0,0 +1,164 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+// SearchRelevance is the sort field used to sort results by how well they match the
+// search query (see [WithSearch]).
+const SearchRelevance = "relevance"
+
+// SearchGroup represents the fields of a type which are searched through the "q" query
+// parameter (see [WithSearch]). Similar to a [FilterGroup], a single value is matched
+// against all fields in the group, however the dialect-specific full-text search
+// capabilities are used where possible.
+type SearchGroup struct {
+	Type   *gen.Type
+	Fields []*gen.Field
+
+	// FullTextIndex is true if all fields are covered by a FULLTEXT index, in which case
+	// MATCH ... AGAINST is used with MySQL.
+	FullTextIndex bool
+
+	// Table is the FTS5 virtual table used with SQLite (see [WithSearchTable]).
+	Table string
+}
+
+// Parameter returns the "q" query parameter for the search group.
+func (g *SearchGroup) Parameter() *ogen.Parameter {
+	fields := make([]string, 0, len(g.Fields))
+	for _, f := range g.Fields {
+		fields = append(fields, f.Name)
+	}
+
+	return &ogen.Parameter{
+		Name: "q",
+		In:   "query",
+		Description: fmt.Sprintf(
+			"Search query, matched across multiple fields: %s. Use the %q sort field to sort results by relevance.",
+			strings.Join(fields, ", "),
+			SearchRelevance,
+		),
+		Schema: &ogen.Schema{Type: "string", MinLength: ptr(uint64(1))},
+	}
+}
+
+// ComponentName returns the name/component alias for the parameter.
+func (g *SearchGroup) ComponentName() string {
+	return PascalCase(g.Type.Name) + "Search"
+}
+
+// FallbackPredicateBuilder returns the predicate used when the dialect doesn't support
+// full-text search, which matches any of the fields containing the search query (case
+// insensitive), like the "has" predicate of a [FilterGroup].
+func (g *SearchGroup) FallbackPredicateBuilder(structName string) string {
+	fields := make([]string, 0, len(g.Fields))
+	for _, f := range g.Fields {
+		fields = append(fields, generatePredicateBuilder(g.Type, f, nil, gen.ContainsFold, structName, "Search"))
+	}
+	return fmt.Sprintf("sql.OrPredicates(\n%s,\n)", strings.Join(fields, ",\n"))
+}
+
+// isSearchableField returns true if the field can be included in a search group.
+func isSearchableField(f *gen.Field) bool {
+	return f.Type != nil && f.IsString() && !f.HasGoType() && !f.Sensitive()
+}
+
+// GetSearchGroup returns the search group for the given type (see [WithSearch]), or nil
+// if search isn't enabled on the type (or any of its fields).
+func GetSearchGroup(t *gen.Type) *SearchGroup {
+	cfg := GetConfig(t.Config)
+	ta := GetAnnotation(t)
+
+	if ta.GetSkip(cfg) {
+		return nil
+	}
+
+	g := &SearchGroup{Type: t, Table: ta.SearchTable}
+
+	for _, f := range t.Fields {
+		fa := GetAnnotation(f)
+		if fa.GetSkip(cfg) || (!ta.Search && !fa.Search) || !isSearchableField(f) {
+			continue
+		}
+		g.Fields = append(g.Fields, f)
+	}
+
+	if len(g.Fields) == 0 {
+		return nil
+	}
+
+	columns := make([]string, 0, len(g.Fields))
+	for _, f := range g.Fields {
+		columns = append(columns, f.StorageKey())
+	}
+
+	for _, idx := range t.Indexes {
+		if len(idx.Columns) != len(columns) || !isFullTextIndex(idx) || slices.ContainsFunc(columns, func(c string) bool {
+			return !slices.Contains(idx.Columns, c)
+		}) {
+			continue
+		}
+		g.FullTextIndex = true
+		break
+	}
+
+	return g
+}
+
+// isFullTextIndex returns true if the index is a MySQL FULLTEXT index, configured through
+// [entsql.IndexAnnotation].
+func isFullTextIndex(idx *gen.Index) bool {
+	v, ok := idx.Annotations[entsql.IndexAnnotation{}.Name()]
+	if !ok || v == nil {
+		return false
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+
+	ant := &entsql.IndexAnnotation{}
+	if err = json.Unmarshal(buf, ant); err != nil {
+		return false
+	}
+
+	return strings.EqualFold(ant.Type, "FULLTEXT") || strings.EqualFold(ant.Types["mysql"], "FULLTEXT")
+}
+
+// validateSearch ensures that the search fields of the given type (if any) can be
+// searched, and that the search table (if any) can be used.
+func validateSearch(t *gen.Type) error {
+	ta := GetAnnotation(t)
+
+	for _, f := range t.Fields {
+		if GetAnnotation(f).Search && !isSearchableField(f) {
+			return fmt.Errorf("search field %q on %q must be a non-sensitive string field without a custom Go type", f.Name, t.Name)
+		}
+	}
+
+	if ta.SearchTable == "" || ta.Skip {
+		return nil
+	}
+
+	if t.ID == nil || !t.ID.IsInt() && !t.ID.IsInt64() {
+		return fmt.Errorf("search table on %q requires an integer ID field", t.Name)
+	}
+
+	if !ta.Search && !slices.ContainsFunc(t.Fields, func(f *gen.Field) bool { return GetAnnotation(f).Search }) {
+		return fmt.Errorf("search table on %q requires search to be enabled on the schema or its fields", t.Name)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"entgo.io/ent/entc/gen"
+)
+
+// GetSelectableFields returns a list of fields which can be provided to the "fields"
+// query parameter (sparse fieldsets) for the given type. This includes the ID, all
+// non-sensitive and non-skipped fields, as well as any eager-loaded (or includable)
+// edges. These edges can either be selected as a whole (e.g. "owner"), or by their own
+// fields (e.g. "owner.name"). Types without an ID field do not support sparse fieldsets.
+func GetSelectableFields(t *gen.Type) (selectable []string) {
+	if t.ID == nil {
+		return nil
+	}
+
+	cfg := GetConfig(t.Config)
+	selectable = append(selectable, t.ID.Name)
+
+	for _, f := range t.Fields {
+		if GetAnnotation(f).GetSkip(cfg) || f.Sensitive() {
+			continue
+		}
+		selectable = append(selectable, f.Name)
+	}
+
+	for _, e := range t.Edges {
+		ea := GetAnnotation(e)
+		if ea.GetSkip(cfg) || (!ea.GetEagerLoad(cfg) && !ea.Includable) || GetAnnotation(e.Type).GetSkip(cfg) {
+			continue
+		}
+
+		selectable = append(selectable, e.Name)
+
+		if e.Type.ID == nil {
+			continue
+		}
+
+		selectable = append(selectable, e.Name+"."+e.Type.ID.Name)
+
+		for _, f := range e.Type.Fields {
+			if GetAnnotation(f).GetSkip(cfg) || f.Sensitive() {
+				continue
+			}
+			selectable = append(selectable, e.Name+"."+f.Name)
+		}
+	}
+
+	return selectable
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema/field"
+)
+
+// GetSoftDeleteField returns the field used to soft-delete entities of the given type
+// (see [WithSoftDelete]), or nil if soft-delete isn't enabled for the type.
+func GetSoftDeleteField(t *gen.Type) *gen.Field {
+	ta := GetAnnotation(t)
+	if ta.SoftDelete == "" || ta.GetSkip(GetConfig(t.Config)) {
+		return nil
+	}
+
+	for _, f := range t.Fields {
+		if f.Name == ta.SoftDelete {
+			return f
+		}
+	}
+	return nil
+}
+
+// validateSoftDeleteField ensures that the soft-delete field of the given type (if any)
+// exists, and can be used to soft-delete entities.
+func validateSoftDeleteField(t *gen.Type) error {
+	name := GetAnnotation(t).SoftDelete
+	if name == "" {
+		return nil
+	}
+
+	for _, f := range t.Fields {
+		if f.Name != name {
+			continue
+		}
+
+		switch {
+		case f.Type == nil || f.Type.Type != field.TypeTime:
+			return fmt.Errorf("soft-delete field %q on %q must be a time field", name, t.Name)
+		case !f.Optional || f.Immutable:
+			return fmt.Errorf("soft-delete field %q on %q must be optional, and not immutable", name, t.Name)
+		case !GetAnnotation(f).ReadOnly || GetAnnotation(f).Skip:
+			return fmt.Errorf("soft-delete field %q on %q must be read-only, and not skipped", name, t.Name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("soft-delete field %q not found on %q", name, t.Name)
+}
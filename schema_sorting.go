@@ -35,6 +35,10 @@ func GetSortableFields(t *gen.Type, edge *gen.Edge) (sortable []string) {
 
 	if edge == nil {
 		sortable = append(sortable, "random")
+
+		if GetSearchGroup(t) != nil {
+			sortable = append(sortable, SearchRelevance)
+		}
 	}
 
 	for _, f := range fields {
@@ -0,0 +1,93 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+	"slices"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema/field"
+)
+
+// GetUpsertFields returns the fields used to resolve conflicts when upserting entities
+// of the given type (see [WithUpsert]), or nil if upsert isn't enabled for the type.
+func GetUpsertFields(t *gen.Type) (fields []*gen.Field) {
+	cfg := GetConfig(t.Config)
+	ta := GetAnnotation(t)
+
+	if len(ta.Upsert) == 0 || !ta.HasOperation(cfg, OperationUpsert) {
+		return nil
+	}
+
+	for _, name := range ta.Upsert {
+		for _, f := range t.Fields {
+			if f.Name == name {
+				fields = append(fields, f)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// validateUpsertFields ensures that the upsert fields of the given type (if any) exist,
+// can be provided through the path, and are covered by a unique index.
+func validateUpsertFields(t *gen.Type) error {
+	names := GetAnnotation(t).Upsert
+	if len(names) == 0 {
+		return nil
+	}
+
+	var enabled bool
+	if t.Config != nil {
+		enabled, _ = t.Config.FeatureEnabled(gen.FeatureUpsert.Name)
+	}
+
+	if !enabled {
+		return fmt.Errorf("upsert on %q requires the %q feature to be enabled", t.Name, gen.FeatureUpsert.Name)
+	}
+
+	if t.ID == nil {
+		return fmt.Errorf("upsert on %q is not supported for types with composite IDs", t.Name)
+	}
+
+	columns := make([]string, 0, len(names))
+	unique := false
+
+	for _, name := range names {
+		idx := slices.IndexFunc(t.Fields, func(f *gen.Field) bool { return f.Name == name })
+		if idx < 0 {
+			return fmt.Errorf("upsert field %q not found on %q", name, t.Name)
+		}
+
+		f := t.Fields[idx]
+		fa := GetAnnotation(f)
+
+		switch {
+		case fa.Skip || fa.ReadOnly || f.Sensitive():
+			return fmt.Errorf("upsert field %q on %q must not be skipped, read-only or sensitive", name, t.Name)
+		case f.Type == nil || (f.Type.Type != field.TypeUUID && (f.HasGoType() || (f.Type.Type != field.TypeString && f.Type.Type != field.TypeInt))):
+			return fmt.Errorf("upsert field %q on %q must be a string, int or uuid field", name, t.Name)
+		}
+
+		columns = append(columns, f.StorageKey())
+		unique = len(names) == 1 && f.Unique
+	}
+
+	if unique {
+		return nil
+	}
+
+	for _, idx := range t.Indexes {
+		if idx.Unique && len(idx.Columns) == len(columns) && !slices.ContainsFunc(columns, func(c string) bool {
+			return !slices.Contains(idx.Columns, c)
+		}) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("upsert fields %q on %q must be unique, or covered by a unique index", names, t.Name)
+}
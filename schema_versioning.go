@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"fmt"
+
+	"entgo.io/ent/entc/gen"
+)
+
+// GetVersionField returns the field used to version entities of the given type (see
+// [WithVersionField]), or nil if the type isn't versioned.
+func GetVersionField(t *gen.Type) *gen.Field {
+	ta := GetAnnotation(t)
+	if ta.VersionField == "" || ta.GetSkip(GetConfig(t.Config)) {
+		return nil
+	}
+
+	for _, f := range t.Fields {
+		if f.Name == ta.VersionField {
+			return f
+		}
+	}
+	return nil
+}
+
+// GetVersionedTypes returns all types in the graph which are versioned (see
+// [WithVersionField]).
+func GetVersionedTypes(g *gen.Graph) (versioned []*gen.Type) {
+	for _, t := range g.Nodes {
+		if GetVersionField(t) != nil {
+			versioned = append(versioned, t)
+		}
+	}
+	return versioned
+}
+
+// validateVersionField ensures that the version field of the given type (if any) exists,
+// and can be used to version entities.
+func validateVersionField(t *gen.Type) error {
+	name := GetAnnotation(t).VersionField
+	if name == "" {
+		return nil
+	}
+
+	for _, f := range t.Fields {
+		if f.Name != name {
+			continue
+		}
+
+		switch {
+		case f.Type == nil || !f.Type.Type.Integer():
+			return fmt.Errorf("version field %q on %q must be an integer field", name, t.Name)
+		case f.Optional || f.Nillable || f.Immutable:
+			return fmt.Errorf("version field %q on %q must not be optional, nillable or immutable", name, t.Name)
+		case !f.Default:
+			return fmt.Errorf("version field %q on %q must have a default value", name, t.Name)
+		case !GetAnnotation(f).ReadOnly || GetAnnotation(f).Skip:
+			return fmt.Errorf("version field %q on %q must be read-only, and not skipped", name, t.Name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("version field %q not found on %q", name, t.Name)
+}
@@ -0,0 +1,335 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+	"github.com/go-faster/yaml"
+	"github.com/ogen-go/ogen"
+)
+
+// webhookOps are the operations (in order) which result in a webhook event.
+var webhookOps = []string{"create", "update", "delete"}
+
+// GetWebhookTypes returns all types which have webhooks enabled (see [WithWebhooks]).
+// Types without an ID field do not support webhooks.
+func GetWebhookTypes(g *gen.Graph) (types []*gen.Type) {
+	cfg := GetConfig(g.Config)
+
+	for _, t := range g.Nodes {
+		ta := GetAnnotation(t)
+		if t.ID == nil || ta.GetSkip(cfg) || !ta.Webhooks {
+			continue
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// GetWebhookName returns the name of the webhook event of the given type and operation
+// (e.g. "pet.create"), which is used in the spec, and to match webhook subscriptions.
+func GetWebhookName(t *gen.Type, op string) string {
+	return SnakeCase(Singularize(t.Name)) + "." + op
+}
+
+// WebhookOutboxMixin can be used on an (otherwise empty) schema to store webhook deliveries
+// (see [WithWebhooks]) in the database. When a schema uses this mixin, the generated server
+// includes an ent-backed WebhookOutbox (NewEntWebhookOutbox), where deliveries are stored
+// within the same transaction as the change which caused them, and are shared between
+// multiple instances of the server. The schema is excluded from the spec and the generated
+// endpoints.
+type WebhookOutboxMixin struct{ mixin.Schema }
+
+// webhookOutboxFields are the fields (and associated types) which must exist on schemas
+// which are used to store webhook deliveries.
+var webhookOutboxFields = map[string]field.Type{
+	"key":              field.TypeString,
+	"subscription_id":  field.TypeString,
+	"event":            field.TypeString,
+	"payload":          field.TypeBytes,
+	"status":           field.TypeString,
+	"attempts":         field.TypeInt,
+	"last_error":       field.TypeString,
+	"last_status_code": field.TypeInt,
+	"next_attempt_at":  field.TypeTime,
+	"created_at":       field.TypeTime,
+	"updated_at":       field.TypeTime,
+}
+
+func (WebhookOutboxMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("key").
+			Unique().
+			Immutable().
+			NotEmpty().
+			MaxLen(64).
+			Comment(`Unique ID of the delivery, which is sent in the "webhook-id" header.`),
+		field.String("subscription_id").
+			Immutable().
+			Comment("ID of the webhook subscription which the delivery is for."),
+		field.String("event").
+			Immutable().
+			Comment(`Name of the webhook event, e.g. "pet.create".`),
+		field.Bytes("payload").
+			Immutable().
+			Comment("Body of the webhook requests."),
+		field.String("status").
+			Default("pending").
+			Comment(`Status of the delivery, one of "pending", "delivered" or "failed".`),
+		field.Int("attempts").
+			Default(0).
+			Comment("Number of delivery attempts."),
+		field.String("last_error").
+			Default("").
+			Comment("Error of the last failed delivery attempt, if any."),
+		field.Int("last_status_code").
+			Default(0).
+			Comment("Response status code of the last delivery attempt, if any."),
+		field.Time("next_attempt_at").
+			Default(time.Now).
+			Comment("Time of the next delivery attempt, if pending."),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("Time in which the delivery was created."),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("Time in which the delivery was last updated."),
+	}
+}
+
+func (WebhookOutboxMixin) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_attempt_at"),
+	}
+}
+
+func (WebhookOutboxMixin) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		Annotation{WebhookOutbox: true, Skip: true},
+	}
+}
+
+// WebhookSubscriptionMixin can be used on an (otherwise empty) schema to store webhook
+// subscriptions (see [WithWebhooks]) in the database. When a schema uses this mixin, the
+// generated server includes an ent-backed WebhookSubscriptionStore
+// (NewEntWebhookSubscriptionStore), which can be used in addition to the subscriptions
+// provided to the generated server. The schema is excluded from the spec and the generated
+// endpoints, so managing subscriptions is left up to the application.
+type WebhookSubscriptionMixin struct{ mixin.Schema }
+
+// webhookSubscriptionFields are the fields (and associated types) which must exist on
+// schemas which are used to store webhook subscriptions.
+var webhookSubscriptionFields = map[string]field.Type{
+	"url":     field.TypeString,
+	"secret":  field.TypeString,
+	"events":  field.TypeJSON,
+	"enabled": field.TypeBool,
+}
+
+func (WebhookSubscriptionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("url").
+			NotEmpty().
+			Comment("URL which webhook events are delivered to."),
+		field.String("secret").
+			NotEmpty().
+			Sensitive().
+			Comment("Secret used to sign the webhook requests."),
+		field.JSON("events", []string{}).
+			Optional().
+			Comment(`Names of the webhook events to deliver (e.g. "pet.create" or "pet.*"), or all events if empty.`),
+		field.Bool("enabled").
+			Default(true).
+			Comment("Whether webhook events are delivered to the subscription."),
+	}
+}
+
+func (WebhookSubscriptionMixin) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		Annotation{WebhookStore: true, Skip: true},
+	}
+}
+
+// GetWebhookOutboxType returns the type used to store webhook deliveries (see
+// [WebhookOutboxMixin]), or nil if there is no such type, or no types have webhooks
+// enabled.
+func GetWebhookOutboxType(g *gen.Graph) *gen.Type {
+	if len(GetWebhookTypes(g)) == 0 {
+		return nil
+	}
+
+	for _, t := range g.Nodes {
+		if GetAnnotation(t).WebhookOutbox {
+			return t
+		}
+	}
+	return nil
+}
+
+// GetWebhookStoreType returns the type used to store webhook subscriptions (see
+// [WebhookSubscriptionMixin]), or nil if there is no such type, or no types have webhooks
+// enabled.
+func GetWebhookStoreType(g *gen.Graph) *gen.Type {
+	if len(GetWebhookTypes(g)) == 0 {
+		return nil
+	}
+
+	for _, t := range g.Nodes {
+		if GetAnnotation(t).WebhookStore {
+			return t
+		}
+	}
+	return nil
+}
+
+// validateWebhookStores ensures that the given type (if used to store webhook deliveries
+// or subscriptions) has all of the fields required by the generated stores.
+func validateWebhookStores(t *gen.Type) error {
+	ta := GetAnnotation(t)
+
+	var fields map[string]field.Type
+	var name, mixinName string
+
+	switch {
+	case ta.WebhookOutbox:
+		fields, name, mixinName = webhookOutboxFields, "webhook outbox", "WebhookOutboxMixin"
+	case ta.WebhookStore:
+		fields, name, mixinName = webhookSubscriptionFields, "webhook subscription store", "WebhookSubscriptionMixin"
+	default:
+		return nil
+	}
+
+	if t.ID == nil {
+		return fmt.Errorf("%s %q must have an ID", name, t.Name)
+	}
+
+	for _, fieldName := range slices.Sorted(maps.Keys(fields)) {
+		typ := fields[fieldName]
+		idx := slices.IndexFunc(t.Fields, func(f *gen.Field) bool { return f.Name == fieldName })
+		if idx < 0 || t.Fields[idx].Type == nil || t.Fields[idx].Type.Type != typ {
+			return fmt.Errorf("%s %q must have a %q field of type %s (see %s)", name, t.Name, fieldName, typ, mixinName)
+		}
+	}
+	return nil
+}
+
+// addWebhooks adds the webhook events of the given types to the "webhooks" section of the
+// spec, along with the schemas of the events. OpenAPI 3.0 doesn't support webhooks, so the
+// commonly supported "x-webhooks" extension is used instead for 3.0 specs.
+func addWebhooks(spec *ogen.Spec, types []*gen.Type) error {
+	if len(types) == 0 {
+		return nil
+	}
+
+	webhooks := map[string]*ogen.PathItem{}
+
+	for _, t := range types {
+		entityName := Singularize(t.Name)
+
+		// The event schema (and the schemas it depends on) may not exist, if the events
+		// operation isn't enabled.
+		for name, schema := range GetSchemaType(t, OperationEvents, nil) {
+			if _, ok := spec.Components.Schemas[name]; !ok {
+				spec.Components.Schemas[name] = schema
+			}
+		}
+
+		ta := GetAnnotation(t)
+
+		for _, op := range webhookOps {
+			name := GetWebhookName(t, op)
+
+			desc := fmt.Sprintf("Sent to subscriptions of the %q event when a %s is %sd.", name, entityName, op)
+			if op == "delete" {
+				desc += " Soft-deleting the entity also results in this event, and the entity isn't included."
+			}
+
+			webhooks[name] = &ogen.PathItem{
+				Post: &ogen.Operation{
+					Tags:        sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+					Summary:     fmt.Sprintf("%s %s webhook", entityName, op),
+					Description: desc,
+					OperationID: CamelCase(entityName) + PascalCase(op) + "Webhook",
+					Parameters: []*ogen.Parameter{
+						{
+							Name:        "webhook-id",
+							In:          "header",
+							Description: "Unique ID of the delivery, which is the same for all delivery attempts.",
+							Required:    true,
+							Schema:      &ogen.Schema{Type: "string"},
+						},
+						{
+							Name:        "webhook-timestamp",
+							In:          "header",
+							Description: "Unix timestamp of the delivery attempt.",
+							Required:    true,
+							Schema:      &ogen.Schema{Type: "integer"},
+						},
+						{
+							Name:        "webhook-signature",
+							In:          "header",
+							Description: `Signature of the request, in the format "v1,<signature>", where the signature is the base64-encoded HMAC-SHA256 of "<webhook-id>.<webhook-timestamp>.<body>", using the secret of the subscription.`,
+							Required:    true,
+							Schema:      &ogen.Schema{Type: "string"},
+						},
+					},
+					RequestBody: &ogen.RequestBody{
+						Required: true,
+						Content: map[string]ogen.Media{
+							"application/json": {Schema: &ogen.Schema{Ref: "#/components/schemas/" + entityName + "Event"}},
+						},
+					},
+					Responses: ogen.Responses{
+						"200": &ogen.Response{
+							Description: "Any 2xx response marks the delivery as successful. All other responses (or errors) result in the delivery being retried.",
+						},
+					},
+				},
+			}
+		}
+	}
+
+	if !strings.HasPrefix(spec.OpenAPI, "3.0") {
+		if spec.Webhooks == nil {
+			spec.Webhooks = map[string]*ogen.PathItem{}
+		}
+		maps.Copy(spec.Webhooks, webhooks)
+		return nil
+	}
+
+	b, err := json.Marshal(webhooks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhooks: %w", err)
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(b, &node); err != nil {
+		return fmt.Errorf("failed to convert webhooks: %w", err)
+	}
+	if len(node.Content) != 1 {
+		return errors.New("failed to convert webhooks: unexpected document")
+	}
+
+	if spec.Extensions == nil {
+		spec.Extensions = ogen.Extensions{}
+	}
+	spec.Extensions["x-webhooks"] = *node.Content[0]
+	return nil
+}
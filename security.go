@@ -0,0 +1,108 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package entrest
+
+import (
+	"maps"
+	"slices"
+
+	"entgo.io/ent/entc/gen"
+	"github.com/ogen-go/ogen"
+)
+
+// GetOperationScopes returns the scopes required by the provided operation on the given
+// type, or the given edge of the type (see [WithScopes]). Scopes of the edge take
+// precedence over the scopes of the type.
+func GetOperationScopes(t *gen.Type, e *gen.Edge, op Operation) []string {
+	if e != nil {
+		if scopes, ok := GetAnnotation(e).Scopes[op]; ok {
+			return scopes
+		}
+	}
+	return GetAnnotation(t).Scopes[op]
+}
+
+// GetOperationSecurity returns the security requirements of the provided operation on the
+// given type, or the given edge of the type (see [WithSecurity]), including the scopes of
+// the operation (see [WithScopes]) for schemes which support them. Returns nil if the operation uses the global security
+// requirements of the spec (see [Config.Spec]) as-is.
+func GetOperationSecurity(t *gen.Type, e *gen.Edge, op Operation) ogen.SecurityRequirements {
+	cfg := GetConfig(t.Config)
+
+	requirements, ok := GetAnnotation(t).Security[op]
+	if e != nil {
+		if v, eok := GetAnnotation(e).Security[op]; eok {
+			requirements, ok = v, true
+		}
+	}
+
+	scopes := GetOperationScopes(t, e, op)
+	if len(scopes) == 0 {
+		if !ok {
+			return nil
+		}
+		return requirements
+	}
+
+	if !ok {
+		if cfg.Spec == nil || len(cfg.Spec.Security) == 0 {
+			// Scopes are only provided to the authorizer of the generated server.
+			return nil
+		}
+		requirements = cfg.Spec.Security
+	}
+
+	out := make(ogen.SecurityRequirements, 0, len(requirements))
+	for _, req := range requirements {
+		merged := make(ogen.SecurityRequirement, len(req))
+		for _, name := range slices.Sorted(maps.Keys(req)) {
+			merged[name] = slices.Clone(req[name])
+			if !supportsScopes(cfg, name) {
+				continue
+			}
+			for _, scope := range scopes {
+				if !slices.Contains(merged[name], scope) {
+					merged[name] = append(merged[name], scope)
+				}
+			}
+		}
+		out = append(out, merged)
+	}
+	return out
+}
+
+// supportsScopes returns true if the provided security scheme supports scopes, which is
+// only the case for "oauth2" and "openIdConnect" schemes. Schemes which aren't defined in
+// [Config.Spec] (e.g. those provided through [Config.SpecFromPath]) are assumed to
+// support scopes.
+func supportsScopes(cfg *Config, name string) bool {
+	if cfg.Spec == nil || cfg.Spec.Components == nil {
+		return true
+	}
+	scheme, ok := cfg.Spec.Components.SecuritySchemes[name]
+	if !ok || scheme == nil || scheme.Ref != "" {
+		return true
+	}
+	return scheme.Type == "oauth2" || scheme.Type == "openIdConnect"
+}
+
+// addOperationSecurity sets the security requirements of all operations of the provided
+// spec (see [GetOperationSecurity]).
+func addOperationSecurity(spec *ogen.Spec, t *gen.Type, e *gen.Edge, op Operation) {
+	security := GetOperationSecurity(t, e, op)
+	if security == nil {
+		return
+	}
+
+	for pathName, pathItem := range spec.Paths {
+		spec.Paths[pathName] = PatchOperations(pathItem, func(_ string, oper *ogen.Operation) *ogen.Operation {
+			if oper == nil {
+				return nil
+			}
+			oper.Security = security
+			return oper
+		})
+	}
+}
@@ -23,7 +23,7 @@ import (
 
 const eagerLoadDepthMessage = "If the entity has eager-loaded edges, the depth of when those will be loaded is limited to a depth of 1 (entity -> edge, not entity -> edge -> edge -> etc)."
 
-func addPagination(spec *ogen.Spec, _ *Config) {
+func addPagination(spec *ogen.Spec, _ *Config, mode PaginationMode, strategy CountStrategy) {
 	if spec.Components == nil {
 		spec.Components = &ogen.Components{}
 	}
@@ -32,7 +32,58 @@ func addPagination(spec *ogen.Spec, _ *Config) {
 		spec.Components.Parameters = make(map[string]*ogen.Parameter)
 	}
 
-	if _, ok := spec.Components.Schemas["Page"]; !ok {
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = make(map[string]*ogen.Schema)
+	}
+
+	if mode == PaginationCursor {
+		if _, ok := spec.Components.Parameters["Cursor"]; !ok {
+			spec.Components.Parameters["Cursor"] = &ogen.Parameter{
+				Name:        "cursor",
+				In:          "query",
+				Description: "An opaque cursor to continue paginating from, as returned by the next_cursor or prev_cursor fields of a previous response. If not provided, the first page of results is returned. The cursor is only valid with the same sort field it was created with.",
+				Schema:      &ogen.Schema{Type: "string"},
+			}
+		}
+
+		if _, ok := spec.Components.Schemas["CursorResponse"]; ok {
+			return
+		}
+
+		spec.Components.Schemas["CursorResponse"] = &ogen.Schema{
+			Type: "object",
+			Properties: ogen.Properties{
+				{
+					Name: "next_cursor",
+					Schema: &ogen.Schema{
+						Type:        "string",
+						Description: "Cursor to retrieve the next page of results. Null if this is the last page.",
+						Nullable:    true,
+					},
+				},
+				{
+					Name: "prev_cursor",
+					Schema: &ogen.Schema{
+						Type:        "string",
+						Description: "Cursor to retrieve the previous page of results. Null if this is the first page.",
+						Nullable:    true,
+					},
+				},
+				{
+					Name: "is_last_page",
+					Schema: &ogen.Schema{
+						Type:        "boolean",
+						Description: "If true, the current results are the last page of results.",
+						Example:     jsonschema.RawValue(`false`),
+					},
+				},
+			},
+			Required: []string{"next_cursor", "prev_cursor", "is_last_page"},
+		}
+		return
+	}
+
+	if _, ok := spec.Components.Parameters["Page"]; !ok {
 		spec.Components.Parameters["Page"] = &ogen.Parameter{
 			Name:        "page",
 			In:          "query",
@@ -43,11 +94,8 @@ func addPagination(spec *ogen.Spec, _ *Config) {
 		}
 	}
 
-	if spec.Components.Schemas == nil {
-		spec.Components.Schemas = make(map[string]*ogen.Schema)
-	}
-
-	if _, ok := spec.Components.Schemas["PagedResponse"]; ok {
+	name := pagedResponseName(mode, strategy)
+	if _, ok := spec.Components.Schemas[name]; ok {
 		return
 	}
 
@@ -63,15 +111,6 @@ func addPagination(spec *ogen.Spec, _ *Config) {
 					Minimum:     ogen.Int().SetMinimum(ptr(int64(1))).Minimum,
 				},
 			},
-			{
-				Name: "last_page",
-				Schema: &ogen.Schema{
-					Type:        "integer",
-					Description: "The number of the last page of results.",
-					Example:     jsonschema.RawValue(`3`),
-					Minimum:     ogen.Int().SetMinimum(ptr(int64(1))).Minimum,
-				},
-			},
 			{
 				Name: "is_last_page",
 				Schema: &ogen.Schema{
@@ -80,20 +119,60 @@ func addPagination(spec *ogen.Spec, _ *Config) {
 					Example:     jsonschema.RawValue(`false`),
 				},
 			},
-			{
-				Name: "total_count",
-				Schema: &ogen.Schema{
-					Type:        "integer",
-					Description: "The total number of results based on the provided query.",
-					Example:     jsonschema.RawValue(`123`),
-					Minimum:     ogen.Int().SetMinimum(ptr(int64(0))).Minimum,
-				},
-			},
 		},
-		Required: []string{"page", "last_page", "is_last_page", "total_count"},
+		Required: []string{"page", "is_last_page"},
+	}
+
+	lastPage := ogen.Property{
+		Name: "last_page",
+		Schema: &ogen.Schema{
+			Type:        "integer",
+			Description: "The number of the last page of results.",
+			Example:     jsonschema.RawValue(`3`),
+			Minimum:     ogen.Int().SetMinimum(ptr(int64(1))).Minimum,
+		},
+	}
+
+	totalCount := ogen.Property{
+		Name: "total_count",
+		Schema: &ogen.Schema{
+			Type:        "integer",
+			Description: "The total number of results based on the provided query.",
+			Example:     jsonschema.RawValue(`123`),
+			Minimum:     ogen.Int().SetMinimum(ptr(int64(0))).Minimum,
+		},
+	}
+
+	if strategy != CountExact {
+		lastPage.Schema.Description += " This is approximate if is_estimate is true."
+		totalCount.Schema.Description += " This is approximate if is_estimate is true."
+	}
+
+	pagedSchema.Properties = slices.Insert(pagedSchema.Properties, 1, lastPage)
+	pagedSchema.Properties = append(pagedSchema.Properties, totalCount)
+	pagedSchema.Required = []string{"page", "last_page", "is_last_page", "total_count"}
+
+	if strategy != CountExact {
+		pagedSchema.Properties = append(pagedSchema.Properties, ogen.Property{
+			Name: "is_estimate",
+			Schema: &ogen.Schema{
+				Type:        "boolean",
+				Description: "If true, total_count and last_page are estimated, rather than exact.",
+				Example:     jsonschema.RawValue(`false`),
+			},
+		})
 	}
 
-	spec.Components.Schemas["PagedResponse"] = pagedSchema
+	spec.Components.Schemas[name] = pagedSchema
+}
+
+// paginationParameter returns a reference to the page/cursor parameter, depending on
+// the pagination mode.
+func paginationParameter(mode PaginationMode) *ogen.Parameter {
+	if mode == PaginationCursor {
+		return &ogen.Parameter{Ref: "#/components/parameters/Cursor"}
+	}
+	return &ogen.Parameter{Ref: "#/components/parameters/Page"}
 }
 
 func newBaseSpec(_ *Config) *ogen.Spec {
@@ -149,7 +228,7 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 		Description: ta.Description,
 	})
 
-	if op != OperationList && op != OperationCreate {
+	if !slices.Contains([]Operation{OperationList, OperationCreate, OperationCreateBulk, OperationUpdateBulk, OperationDeleteBulk, OperationAggregate, OperationImport, OperationEvents}, op) {
 		idSchema, err := GetSchemaField(t.ID)
 		if err != nil {
 			return nil, err
@@ -190,6 +269,51 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			},
 		}
 
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Post:        oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+	case OperationCreateBulk:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Create multiple new "+CamelCase(Pluralize(t.Name)),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Create multiple new %s entities in a single transaction. If any entity fails to be created, no entities are created. %s",
+					entityName,
+					eagerLoadDepthMessage,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			RequestBody: ogen.NewRequestBody().
+				SetRequired(true).
+				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "CreateBulk"}),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusCreated): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The created %s entities.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "CreateBulkResponse"}),
+			},
+		}
+
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
 		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
 			Summary:     oper.Summary,
 			Description: oper.Description,
@@ -199,6 +323,8 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			},
 		}
 	case OperationUpdate:
+		addJSONPatchComponents(spec)
+
 		oper := &ogen.Operation{
 			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
 			Summary: cmp.Or(
@@ -214,7 +340,9 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			Parameters:  []*ogen.Parameter{},
 			RequestBody: ogen.NewRequestBody().
 				SetRequired(true).
-				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Update"}),
+				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Update"}).
+				AddContent("application/merge-patch+json", &ogen.Schema{Ref: "#/components/schemas/" + entityName + "Update"}).
+				AddContent("application/json-patch+json", &ogen.Schema{Ref: "#/components/schemas/JSONPatch"}),
 			Responses: ogen.Responses{
 				strconv.Itoa(http.StatusOK): ogen.NewResponse().
 					SetDescription(fmt.Sprintf("The update %s entity.", entityName)).
@@ -222,6 +350,12 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			},
 		}
 
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
 		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
 			Summary:     fmt.Sprintf("Operate on a single %s entity", entityName),
 			Description: fmt.Sprintf("Operate on a single %s entity by its ID.", entityName),
@@ -231,6 +365,49 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
 			},
 		}
+	case OperationReplace:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Replace a "+CamelCase(entityName),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Replace an existing %s entity. Optional fields which aren't provided are cleared (or reset to their default), and non-unique edges are replaced. %s",
+					entityName,
+					eagerLoadDepthMessage,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  []*ogen.Parameter{},
+			RequestBody: ogen.NewRequestBody().
+				SetRequired(true).
+				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Replace"}),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The replaced %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"}),
+			},
+		}
+
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     fmt.Sprintf("Operate on a single %s entity", entityName),
+			Description: fmt.Sprintf("Operate on a single %s entity by its ID.", entityName),
+			Put:         oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+			},
+		}
 	case OperationRead:
 		oper := &ogen.Operation{
 			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
@@ -256,6 +433,16 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			oper.Tags = append(oper.Tags, edgesToTags(cfg, t)...)
 		}
 
+		if selectable := GetSelectableFields(t); len(selectable) > 0 {
+			oper.Parameters = append(oper.Parameters, fieldsParameter(spec, t, selectable))
+		}
+
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
 		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
 			Summary:     fmt.Sprintf("Operate on a single %s entity", entityName),
 			Description: fmt.Sprintf("Operate on a single %s entity by its ID.", entityName),
@@ -287,11 +474,12 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 		}
 
 		if ta.GetPagination(cfg, nil) {
-			addPagination(spec, cfg)
+			mode := GetPaginationMode(t, nil)
+			addPagination(spec, cfg, mode, GetCountStrategy(t))
 
 			oper.Parameters = append(
 				oper.Parameters,
-				&ogen.Parameter{Ref: "#/components/parameters/Page"},
+				paginationParameter(mode),
 				&ogen.Parameter{
 					Name:        "per_page",
 					In:          "query",
@@ -309,7 +497,7 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 				Name:        "sort",
 				In:          "query",
 				Description: "Sort entity results by the given field.",
-				Schema:      &ogen.Schema{Ref: "#/components/schemas/" + addSortableFields(spec, t, sortable)},
+				Schema:      &ogen.Schema{Ref: "#/components/schemas/" + addListSortableFields(spec, t, nil, sortable)},
 			}
 			if v := ta.GetDefaultSort(t.ID != nil); v != "" {
 				sortParam.Schema = sortParam.Schema.SetDefault(json.RawMessage(fmt.Sprintf("%q", v)))
@@ -323,32 +511,383 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 					Enum:    sliceToRawMessage([]string{"asc", "desc"}),
 					Default: ogen.Default(json.RawMessage(fmt.Sprintf("%q", ta.GetDefaultOrder()))),
 				},
-			}
-			oper.Parameters = append(oper.Parameters, sortParam, orderParam)
-		}
-
-		if filters := GetFilterableFields(t, nil); len(filters) > 0 {
-			oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/FilterOperation"})
-
-			for _, f := range filters {
-				name := f.ComponentName()
-				spec.Components.Parameters[name] = f.Parameter()
-				oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/" + name})
-			}
+			}
+			oper.Parameters = append(oper.Parameters, sortParam, orderParam)
+		}
+
+		if selectable := GetSelectableFields(t); len(selectable) > 0 {
+			oper.Parameters = append(oper.Parameters, fieldsParameter(spec, t, selectable))
+		}
+
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		oper.Parameters = append(oper.Parameters, filterParameters(spec, t)...)
+
+		if HasExport(t) {
+			oper.Parameters = append(oper.Parameters, exportParameter(spec))
+			addExportContent(oper.Responses[strconv.Itoa(http.StatusOK)], t)
+		}
+
+		if cfg.AddEdgesToTags {
+			oper.Tags = append(oper.Tags, edgesToTags(cfg, t)...)
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Get:         oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+	case OperationUpdateBulk, OperationDeleteBulk:
+		addBulkComponents(spec)
+
+		oper := &ogen.Operation{
+			Tags:        sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  []*ogen.Parameter{{Ref: "#/components/parameters/BulkAll"}},
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The number of affected %s entities.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/BulkResponse"}),
+			},
+		}
+
+		oper.Parameters = append(oper.Parameters, filterParameters(spec, t)...)
+
+		pathItem := &ogen.PathItem{
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+
+		if op == OperationUpdateBulk {
+			oper.Summary = cmp.Or(ta.GetOperationSummary(op), "Update multiple "+CamelCase(Pluralize(t.Name)))
+			oper.Description = cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("Update all %s entities matching the provided filters. If no filters are provided, all=true must be provided to update all entities.", entityName),
+			)
+			oper.RequestBody = ogen.NewRequestBody().
+				SetRequired(true).
+				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Update"})
+			pathItem.Patch = oper
+		} else {
+			oper.Summary = cmp.Or(ta.GetOperationSummary(op), "Delete multiple "+CamelCase(Pluralize(t.Name)))
+			oper.Description = cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("Delete all %s entities matching the provided filters. If no filters are provided, all=true must be provided to delete all entities.", entityName),
+			)
+			pathItem.Delete = oper
+		}
+
+		spec.Paths[GetPathName(op, t, nil, true)] = pathItem
+	case OperationDelete:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Delete a "+CamelCase(entityName),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("Delete a single %s entity by its ID.", entityName),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  []*ogen.Parameter{},
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusNoContent): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The requested %s entity.", entityName)),
+			},
+		}
+
+		if GetSoftDeleteField(t) != nil && ta.GetOperationDescription(op) == "" {
+			oper.Description += " The entity is soft-deleted, and can be restored."
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     fmt.Sprintf("Operate on a single %s entity", entityName),
+			Description: fmt.Sprintf("Operate on a single %s entity by its ID.", entityName),
+			Delete:      oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+			},
+		}
+	case OperationRestore:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Restore a "+CamelCase(entityName),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("Restore a single soft-deleted %s entity by its ID. %s", entityName, eagerLoadDepthMessage),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  []*ogen.Parameter{},
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The restored %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"}),
+			},
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Post:        oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+			},
+		}
+	case OperationUpsert:
+		upsertFields := GetUpsertFields(t)
+		names := make([]string, len(upsertFields))
+		for i, f := range upsertFields {
+			names[i] = f.Name
+		}
+
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Create or update a "+CamelCase(entityName),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Create a new %s entity, or update the existing entity with the same %s. %s",
+					entityName,
+					strings.Join(names, " and "),
+					eagerLoadDepthMessage,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			RequestBody: ogen.NewRequestBody().
+				SetRequired(true).
+				SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Upsert"}),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The updated %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"}),
+				strconv.Itoa(http.StatusCreated): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The created %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"}),
+			},
+		}
+
+		if includable := GetIncludableEdges(t); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, t, includable))
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		pathItem := &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Put:         oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+
+		for _, f := range upsertFields {
+			fieldSchema, err := GetSchemaField(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate schema for field %s: %w", f.StructField(), err)
+			}
+
+			pathItem.Parameters = append(pathItem.Parameters, &ogen.Parameter{
+				Name:        CamelCase(f.Name),
+				In:          "path",
+				Description: fmt.Sprintf("The %s of the %s to create or update.", f.Name, entityName),
+				Required:    true,
+				Schema:      fieldSchema,
+			})
+		}
+
+		spec.Paths[GetPathName(op, t, nil, true)] = pathItem
+	case OperationAggregate:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Aggregate "+CamelCase(Pluralize(t.Name)),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Group %s entities matching the provided filters, and calculate metrics (count, sum, etc) for each group. Groups are sorted by the grouped fields.",
+					entityName,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  aggregateParameters(spec, t),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The aggregated groups of %s entities.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "AggregateList"}),
+			},
+		}
+
+		oper.Parameters = append(oper.Parameters, filterParameters(spec, t)...)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Get:         oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+	case OperationImport:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Import "+CamelCase(Pluralize(t.Name)),
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Create %s entities from a CSV or newline-delimited JSON file, where each row uses the same fields as when creating a single %s. Returns the created ID, or the error, of each row.",
+					entityName,
+					entityName,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  importParameters(spec),
+			RequestBody: importRequestBody(t),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription("The import report, if no entities were created (e.g. dry runs, or failed imports).").
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "ImportResponse"}),
+				strconv.Itoa(http.StatusCreated): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The import report, if any %s entities were created.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "ImportResponse"}),
+			},
+		}
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Post:        oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+			},
+		}
+	case OperationEvents:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Stream "+CamelCase(Singularize(t.Name))+" events",
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf(
+					"Stream changes (creates, updates, and deletes) to %s entities as server-sent events, where the event type is the operation, and the data is the event. Create and update events are only sent for entities matching the provided filters, while delete events are always sent. Streams can be resumed using the Last-Event-ID header.",
+					entityName,
+				),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters:  eventParameters(spec),
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("A stream of %s events.", entityName)).
+					SetContent(map[string]ogen.Media{
+						MediaTypeEventStream: {
+							Schema: &ogen.Schema{Ref: "#/components/schemas/" + entityName + "Event"},
+						},
+					}),
+			},
+		}
+
+		oper.Parameters = append(oper.Parameters, filterParameters(spec, t)...)
+
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Get:         oper,
+		}
+	case OperationHistory:
+		addPagination(spec, cfg, PaginationOffset, CountExact)
+
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"List "+CamelCase(entityName)+" history",
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("List the recorded versions of a single %s entity by its ID, newest first. Versions are still available after the entity is deleted.", entityName),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Parameters: []*ogen.Parameter{
+				paginationParameter(PaginationOffset),
+				{
+					Name:        "per_page",
+					In:          "query",
+					Description: "The number of versions to retrieve per page.",
+					Schema: ogen.Int().
+						SetMinimum(ptr(int64(ta.GetMinItemsPerPage(cfg)))).
+						SetMaximum(ptr(int64(ta.GetMaxItemsPerPage(cfg)))).
+						SetDefault(json.RawMessage(strconv.Itoa(ta.GetItemsPerPage(cfg)))),
+				},
+			},
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The recorded versions of the %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "HistoryList"}),
+			},
 		}
 
-		if groups := GetFilterGroups(t, nil); len(groups) > 0 {
-			for _, g := range groups {
-				for _, op := range g.Operations {
-					name := g.ComponentName(op)
-					spec.Components.Parameters[name] = g.Parameter(op)
-					oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/" + name})
-				}
-			}
+		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Get:         oper,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+			},
 		}
-
-		if cfg.AddEdgesToTags {
-			oper.Tags = append(oper.Tags, edgesToTags(cfg, t)...)
+	case OperationHistoryRead:
+		oper := &ogen.Operation{
+			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
+			Summary: cmp.Or(
+				ta.GetOperationSummary(op),
+				"Get a "+CamelCase(entityName)+" version",
+			),
+			Description: cmp.Or(
+				ta.GetOperationDescription(op),
+				fmt.Sprintf("Get a single recorded version of a %s entity by its ID and version.", entityName),
+			),
+			OperationID: GetOperationIDName(op, t, nil),
+			Deprecated:  ta.Deprecated,
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The requested version of the %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "History"}),
+			},
 		}
 
 		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
@@ -357,40 +896,53 @@ func GetSpecType(t *gen.Type, op Operation) (*ogen.Spec, error) { // nolint:funl
 			Get:         oper,
 			Parameters: []*ogen.Parameter{
 				{Ref: "#/components/parameters/PrettyResponse"},
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+				historyVersionParameter(spec),
 			},
 		}
-	case OperationDelete:
+	case OperationHistoryRestore:
 		oper := &ogen.Operation{
 			Tags: sliceCompact(sliceOr(ta.Tags, append([]string{Pluralize(t.Name)}, ta.AdditionalTags...))),
 			Summary: cmp.Or(
 				ta.GetOperationSummary(op),
-				"Delete a "+CamelCase(entityName),
+				"Restore a "+CamelCase(entityName)+" version",
 			),
 			Description: cmp.Or(
 				ta.GetOperationDescription(op),
-				fmt.Sprintf("Delete a single %s entity by its ID.", entityName),
+				fmt.Sprintf(
+					"Restore a single %s entity to a recorded version, which results in a new version. Immutable and sensitive fields, and edges, aren't restored. Deleted (and soft-deleted) entities can't be restored. %s",
+					entityName,
+					eagerLoadDepthMessage,
+				),
 			),
 			OperationID: GetOperationIDName(op, t, nil),
 			Deprecated:  ta.Deprecated,
 			Parameters:  []*ogen.Parameter{},
 			Responses: ogen.Responses{
-				strconv.Itoa(http.StatusNoContent): ogen.NewResponse().
-					SetDescription(fmt.Sprintf("The requested %s entity.", entityName)),
+				strconv.Itoa(http.StatusOK): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The restored %s entity.", entityName)).
+					SetJSONContent(&ogen.Schema{Ref: "#/components/schemas/" + entityName + "Read"}),
 			},
 		}
 
+		addVersionComponents(spec, t, op, oper)
+
 		spec.Paths[GetPathName(op, t, nil, true)] = &ogen.PathItem{
-			Summary:     fmt.Sprintf("Operate on a single %s entity", entityName),
-			Description: fmt.Sprintf("Operate on a single %s entity by its ID.", entityName),
-			Delete:      oper,
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Post:        oper,
 			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/PrettyResponse"},
 				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+				historyVersionParameter(spec),
 			},
 		}
 	default:
 		panic(fmt.Sprintf("unsupported operation %q", op))
 	}
 
+	addRateLimitResponses(spec, t, op)
+	addOperationSecurity(spec, t, nil, op)
 	return spec, nil
 }
 
@@ -407,7 +959,7 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 		return nil, errors.New("edge is skipped")
 	}
 
-	if !ea.GetEdgeEndpoint(cfg) {
+	if (op == OperationRead || op == OperationList) && !ea.GetEdgeEndpoint(cfg) {
 		return nil, errors.New("edge has endpoint disabled or edge is eager-loaded with global config to disable endpoints for edges which are also eager-loaded")
 	}
 
@@ -441,7 +993,9 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 		Schema:      idSchema,
 	}
 
-	maps.Copy(spec.Components.Schemas, GetSchemaType(t, op, e))
+	if op == OperationRead || op == OperationList {
+		maps.Copy(spec.Components.Schemas, GetSchemaType(t, op, e))
+	}
 
 	switch op {
 	case OperationRead: // Unique.
@@ -476,6 +1030,16 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 			},
 		}
 
+		if selectable := GetSelectableFields(e.Type); len(selectable) > 0 {
+			oper.Parameters = append(oper.Parameters, fieldsParameter(spec, e.Type, selectable))
+		}
+
+		if includable := GetIncludableEdges(e.Type); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, e.Type, includable))
+		}
+
+		addVersionComponents(spec, e.Type, op, oper)
+
 		spec.Paths[GetPathName(op, t, e, true)] = &ogen.PathItem{
 			Summary:     oper.Summary,     // Will probably always be the same.
 			Description: oper.Description, // Will probably always be the same.
@@ -520,9 +1084,10 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 		code := strconv.Itoa(http.StatusOK)
 
 		if ea.GetPagination(cfg, e) || ra.GetPagination(cfg, e) {
-			addPagination(spec, cfg)
+			mode := GetPaginationMode(e.Type, e)
+			addPagination(spec, cfg, mode, GetCountStrategy(e.Type))
 			oper.Parameters = append(oper.Parameters,
-				&ogen.Parameter{Ref: "#/components/parameters/Page"},
+				paginationParameter(mode),
 				&ogen.Parameter{
 					Name:        "per_page",
 					In:          "query",
@@ -534,9 +1099,9 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 				},
 			)
 
-			// If edge pagination is enabled, but edge type is not paginated, we cannot re-use
-			// the paginated schema from the edge type.
-			if !ra.GetPagination(cfg, e) {
+			// If edge pagination is enabled, but edge type is not paginated (or is paginated
+			// differently), we cannot re-use the paginated schema from the edge type.
+			if !ra.GetPagination(cfg, e) || mode != GetPaginationMode(e.Type, nil) {
 				oper.Responses[code] = oper.Responses[code].SetJSONContent(&ogen.Schema{
 					Ref: "#/components/schemas/" + rootEntityName + entityName + "List",
 				})
@@ -554,7 +1119,7 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 				Name:        "sort",
 				In:          "query",
 				Description: "Sort entity results by the given field.",
-				Schema:      &ogen.Schema{Ref: "#/components/schemas/" + addSortableFields(spec, e.Type, sortable)},
+				Schema:      &ogen.Schema{Ref: "#/components/schemas/" + addListSortableFields(spec, e.Type, e, sortable)},
 			}
 			if v := ra.GetDefaultSort(t.ID != nil && (e == nil || e.Field() == nil)); v != "" {
 				sortParam.Schema = sortParam.Schema.SetDefault(json.RawMessage(fmt.Sprintf("%q", v)))
@@ -572,30 +1137,22 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 			oper.Parameters = append(oper.Parameters, sortParam, orderParam)
 		}
 
-		if filters := GetFilterableFields(e.Type, nil); len(filters) > 0 {
-			oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/FilterOperation"})
-
-			for _, f := range filters {
-				name := f.ComponentName()
-				spec.Components.Parameters[name] = f.Parameter()
-				oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/" + name})
-			}
+		if selectable := GetSelectableFields(e.Type); len(selectable) > 0 {
+			oper.Parameters = append(oper.Parameters, fieldsParameter(spec, e.Type, selectable))
 		}
 
-		if groups := GetFilterGroups(e.Type, nil); len(groups) > 0 {
-			for _, g := range groups {
-				for _, op := range g.Operations {
-					name := g.ComponentName(op)
-					spec.Components.Parameters[name] = g.Parameter(op)
-					oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/" + name})
-				}
-			}
+		if includable := GetIncludableEdges(e.Type); len(includable) > 0 {
+			oper.Parameters = append(oper.Parameters, includeParameter(spec, e.Type, includable))
 		}
 
+		oper.Parameters = append(oper.Parameters, filterParameters(spec, e.Type)...)
+
 		if cfg.AddEdgesToTags {
 			oper.Tags = append(oper.Tags, edgesToTags(cfg, e.Type)...)
 		}
 
+		addVersionComponents(spec, e.Type, op, oper)
+
 		spec.Paths[GetPathName(op, t, e, true)] = &ogen.PathItem{
 			Summary:     oper.Summary,
 			Description: oper.Description,
@@ -605,10 +1162,86 @@ func GetSpecEdge(t *gen.Type, e *gen.Edge, op Operation) (*ogen.Spec, error) { /
 				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
 			},
 		}
+	case OperationUpdate, OperationDelete: // Attach/detach (non-unique), or set/clear (unique).
+		if !slices.Contains(GetMutableEdges(t), e) {
+			return nil, errors.New("edge does not support mutation endpoints")
+		}
+
+		var summary, description string
+		switch {
+		case op == OperationUpdate && e.Unique:
+			summary = fmt.Sprintf("Set a %s associated %s", Pluralize(CamelCase(t.Name)), CamelCase(e.Name))
+			description = fmt.Sprintf("Set the %s (%s entity type) associated with a %s, replacing any existing %s.", CamelCase(e.Name), refEntityName, rootEntityName, CamelCase(e.Name))
+		case op == OperationUpdate:
+			summary = fmt.Sprintf("Add a %s associated %s", Pluralize(CamelCase(t.Name)), CamelCase(entityName))
+			description = fmt.Sprintf("Add a single %s (%s entity type) to the %s associated with a %s.", CamelCase(entityName), refEntityName, Pluralize(CamelCase(e.Name)), rootEntityName)
+		case e.Unique:
+			summary = fmt.Sprintf("Clear a %s associated %s", Pluralize(CamelCase(t.Name)), CamelCase(e.Name))
+			description = fmt.Sprintf("Clear the %s (%s entity type) associated with a %s.", CamelCase(e.Name), refEntityName, rootEntityName)
+		default:
+			summary = fmt.Sprintf("Remove a %s associated %s", Pluralize(CamelCase(t.Name)), CamelCase(entityName))
+			description = fmt.Sprintf("Remove a single %s (%s entity type) from the %s associated with a %s.", CamelCase(entityName), refEntityName, Pluralize(CamelCase(e.Name)), rootEntityName)
+		}
+
+		oper := &ogen.Operation{
+			Tags:        sliceCompact(sliceOr(ea.Tags, append([]string{Pluralize(t.Name), Pluralize(e.Type.Name)}, ea.AdditionalTags...))),
+			Summary:     cmp.Or(ea.GetOperationSummary(op), summary),
+			Description: cmp.Or(ea.GetOperationDescription(op), description),
+			OperationID: GetOperationIDName(op, t, e),
+			Deprecated:  ta.Deprecated || ea.Deprecated || ra.Deprecated,
+			Responses: ogen.Responses{
+				strconv.Itoa(http.StatusNoContent): ogen.NewResponse().
+					SetDescription(fmt.Sprintf("The %s was successfully updated.", CamelCase(e.Name))),
+			},
+		}
+
+		addVersionComponents(spec, t, op, oper)
+
+		pathItem := &ogen.PathItem{
+			Summary:     oper.Summary,
+			Description: oper.Description,
+			Parameters: []*ogen.Parameter{
+				{Ref: "#/components/parameters/" + Singularize(t.Name) + "ID"},
+			},
+		}
+
+		if op == OperationUpdate || !e.Unique {
+			refIDSchema, err := GetSchemaField(e.Type.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			spec.Components.Parameters[rootEntityName+entityName+"ID"] = &ogen.Parameter{
+				Name:        CamelCase(entityName) + "ID",
+				In:          "path",
+				Description: fmt.Sprintf("The ID of the %s (%s entity type) to act upon.", CamelCase(entityName), refEntityName),
+				Required:    true,
+				Schema:      refIDSchema,
+			}
+			pathItem.Parameters = append(pathItem.Parameters, &ogen.Parameter{
+				Ref: "#/components/parameters/" + rootEntityName + entityName + "ID",
+			})
+		}
+
+		if op == OperationUpdate {
+			pathItem.Put = oper
+		} else {
+			pathItem.Delete = oper
+		}
+
+		spec.Paths[GetPathName(op, t, e, true)] = pathItem
 	default:
 		panic(fmt.Sprintf("unsupported operation %q", op))
 	}
 
+	if e != nil && op == OperationDelete {
+		// Detaching (or clearing) edges updates the parent entity, which is how the server
+		// applies rate limits and security to them.
+		op = OperationUpdate
+	}
+
+	addRateLimitResponses(spec, t, op)
+	addOperationSecurity(spec, t, e, op)
 	return spec, nil
 }
 
@@ -641,6 +1274,256 @@ func addSortableFields(spec *ogen.Spec, t *gen.Type, fields []string) (ref strin
 	return ref
 }
 
+// addListSortableFields adds the sortable fields enum used when listing the given type
+// (optionally through the provided edge) to the spec, returning the schema name. Lists
+// which use cursor-based pagination can only be sorted by the cursor fields (see
+// [GetCursorFields]).
+func addListSortableFields(spec *ogen.Spec, t *gen.Type, edge *gen.Edge, fields []string) (ref string) {
+	if !GetAnnotation(t).GetPagination(GetConfig(t.Config), nil) || GetPaginationMode(t, edge) != PaginationCursor {
+		return addSortableFields(spec, t, fields)
+	}
+
+	ref = Singularize(t.Name) + "CursorSortableFields"
+
+	fields = nil
+	for _, f := range GetCursorFields(t) {
+		fields = append(fields, f.Name)
+	}
+
+	spec.Components.Schemas[ref] = &ogen.Schema{
+		Description: "All potential sortable fields for " + Singularize(t.Name) + " entities, when using cursor-based pagination.",
+		Type:        "string",
+		Enum:        sliceToRawMessage(fields),
+		Default:     jsonschema.RawValue(`"id"`),
+	}
+	return ref
+}
+
+// addSelectableFields adds the selectable fields enum for the given type to the
+// spec, returning the schema name.
+func addSelectableFields(spec *ogen.Spec, t *gen.Type, fields []string) (ref string) {
+	ref = Singularize(t.Name) + "SelectableFields"
+
+	spec.Components.Schemas[ref] = &ogen.Schema{
+		Description: "All potential selectable fields for " + Singularize(t.Name) + " entities.",
+		Type:        "string",
+		Enum:        sliceToRawMessage(fields),
+	}
+	return ref
+}
+
+// fieldsParameter returns the "fields" (sparse fieldsets) query parameter for the
+// given type.
+func fieldsParameter(spec *ogen.Spec, t *gen.Type, fields []string) *ogen.Parameter {
+	return &ogen.Parameter{
+		Name: "fields",
+		In:   "query",
+		Description: "Comma-separated list of fields to include in the response. Fields of " +
+			"eager-loaded edges can be selected using dot notation (e.g. `owner.name`). If not " +
+			"provided, all fields are returned.",
+		Style:   "form",
+		Explode: ptr(false),
+		Schema:  (&ogen.Schema{Ref: "#/components/schemas/" + addSelectableFields(spec, t, fields)}).AsArray(),
+	}
+}
+
+// addIncludableEdges adds the includable edges enum for the given type to the spec,
+// returning the schema name.
+func addIncludableEdges(spec *ogen.Spec, t *gen.Type, edges []string) (ref string) {
+	ref = Singularize(t.Name) + "IncludableEdges"
+
+	spec.Components.Schemas[ref] = &ogen.Schema{
+		Description: "All potential includable edges for " + Singularize(t.Name) + " entities.",
+		Type:        "string",
+		Enum:        sliceToRawMessage(edges),
+	}
+	return ref
+}
+
+// includeParameter returns the "include" query parameter for the given type, which
+// allows eager-loading includable edges on demand.
+func includeParameter(spec *ogen.Spec, t *gen.Type, edges []string) *ogen.Parameter {
+	return &ogen.Parameter{
+		Name: "include",
+		In:   "query",
+		Description: "Comma-separated list of edges to eager-load in the response. Nested edges " +
+			"can be included using dot notation (e.g. `pets.owner`).",
+		Style:   "form",
+		Explode: ptr(false),
+		Schema:  (&ogen.Schema{Ref: "#/components/schemas/" + addIncludableEdges(spec, t, edges)}).AsArray(),
+	}
+}
+
+// filterParameters adds all filter (and filter group) parameters for the given type to
+// the spec, returning references to those parameters.
+func filterParameters(spec *ogen.Spec, t *gen.Type) (params []*ogen.Parameter) {
+	if filters := GetFilterableFields(t, nil); len(filters) > 0 {
+		params = append(params, &ogen.Parameter{Ref: "#/components/parameters/FilterOperation"})
+
+		for _, f := range filters {
+			name := f.ComponentName()
+			spec.Components.Parameters[name] = f.Parameter()
+			params = append(params, &ogen.Parameter{Ref: "#/components/parameters/" + name})
+		}
+	}
+
+	if groups := GetFilterGroups(t, nil); len(groups) > 0 {
+		for _, g := range groups {
+			for _, op := range g.Operations {
+				name := g.ComponentName(op)
+				spec.Components.Parameters[name] = g.Parameter(op)
+				params = append(params, &ogen.Parameter{Ref: "#/components/parameters/" + name})
+			}
+		}
+	}
+
+	if g := GetSearchGroup(t); g != nil {
+		name := g.ComponentName()
+		spec.Components.Parameters[name] = g.Parameter()
+		params = append(params, &ogen.Parameter{Ref: "#/components/parameters/" + name})
+	}
+
+	if GetSoftDeleteField(t) != nil {
+		spec.Components.Parameters["IncludeDeleted"] = &ogen.Parameter{
+			Name:        "include_deleted",
+			In:          "query",
+			Description: "If set to true, soft-deleted entities are also included.",
+			Schema:      ogen.Bool(),
+		}
+		spec.Components.Parameters["OnlyDeleted"] = &ogen.Parameter{
+			Name:        "only_deleted",
+			In:          "query",
+			Description: "If set to true, only soft-deleted entities are included.",
+			Schema:      ogen.Bool(),
+		}
+		params = append(
+			params,
+			&ogen.Parameter{Ref: "#/components/parameters/IncludeDeleted"},
+			&ogen.Parameter{Ref: "#/components/parameters/OnlyDeleted"},
+		)
+	}
+
+	return params
+}
+
+// addVersionComponents documents the entity tag request and response headers of the
+// provided operation (and the associated responses), if the type is versioned (see
+// [WithVersionField]).
+func addVersionComponents(spec *ogen.Spec, t *gen.Type, op Operation, oper *ogen.Operation) {
+	if GetVersionField(t) == nil {
+		return
+	}
+
+	if spec.Components.Headers == nil {
+		spec.Components.Headers = make(map[string]*ogen.Header)
+	}
+
+	spec.Components.Headers["ETag"] = &ogen.Header{
+		Description: "The entity tag of the returned entity (or page of entities), based on its version.",
+		Schema:      &ogen.Schema{Type: "string"},
+	}
+
+	switch op {
+	case OperationRead, OperationList:
+		spec.Components.Parameters["IfNoneMatch"] = &ogen.Parameter{
+			Name:        "If-None-Match",
+			In:          "header",
+			Description: "If the entity tag matches, a 304 Not Modified is returned instead of the response body.",
+			Schema:      &ogen.Schema{Type: "string"},
+		}
+		oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/IfNoneMatch"})
+		oper.Responses[strconv.Itoa(http.StatusNotModified)] = ogen.NewResponse().
+			SetDescription("Not Modified (http status code 304), the provided entity tag matches.")
+	case OperationUpdate, OperationReplace, OperationDelete, OperationHistoryRestore:
+		spec.Components.Parameters["IfMatch"] = &ogen.Parameter{
+			Name:        "If-Match",
+			In:          "header",
+			Description: "Only apply the operation if the entity tag matches the current version of the entity, otherwise a 412 Precondition Failed is returned.",
+			Schema:      &ogen.Schema{Type: "string"},
+		}
+		oper.Parameters = append(oper.Parameters, &ogen.Parameter{Ref: "#/components/parameters/IfMatch"})
+	}
+
+	for _, code := range []int{http.StatusOK, http.StatusCreated} {
+		if resp, ok := oper.Responses[strconv.Itoa(code)]; ok && resp.Ref == "" {
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]*ogen.Header)
+			}
+			resp.Headers["ETag"] = &ogen.Header{Ref: "#/components/headers/ETag"}
+		}
+	}
+}
+
+// addBulkComponents adds the shared "all" parameter and response schema used by bulk
+// update and delete operations.
+func addBulkComponents(spec *ogen.Spec) {
+	if _, ok := spec.Components.Parameters["BulkAll"]; !ok {
+		spec.Components.Parameters["BulkAll"] = &ogen.Parameter{
+			Name:        "all",
+			In:          "query",
+			Description: "Must be set to true to apply the operation to all entities, when no filters are provided.",
+			Schema:      ogen.Bool(),
+		}
+	}
+
+	if _, ok := spec.Components.Schemas["BulkResponse"]; ok {
+		return
+	}
+
+	spec.Components.Schemas["BulkResponse"] = &ogen.Schema{
+		Type: "object",
+		Properties: ogen.Properties{
+			{
+				Name: "affected",
+				Schema: &ogen.Schema{
+					Type:        "integer",
+					Description: "The number of entities affected by the operation.",
+					Example:     jsonschema.RawValue(`5`),
+					Minimum:     ogen.Int().SetMinimum(ptr(int64(0))).Minimum,
+				},
+			},
+		},
+		Required: []string{"affected"},
+	}
+}
+
+func addJSONPatchComponents(spec *ogen.Spec) {
+	if _, ok := spec.Components.Schemas["JSONPatch"]; ok {
+		return
+	}
+
+	spec.Components.Schemas["JSONPatch"] = &ogen.Schema{
+		Description: "A JSON Patch document (RFC 6902). Operations on non-unique edges (e.g. add /pets/- or remove /pets/{id}) add or remove the referenced entities.",
+		Type:        "array",
+		Items: &ogen.Items{Item: &ogen.Schema{
+			Type: "object",
+			Properties: ogen.Properties{
+				{
+					Name: "op",
+					Schema: &ogen.Schema{
+						Type:        "string",
+						Description: "The operation to perform.",
+						Enum:        sliceToRawMessage([]string{"add", "remove", "replace", "move", "copy", "test"}),
+					},
+				},
+				{
+					Name:   "path",
+					Schema: &ogen.Schema{Type: "string", Description: "A JSON pointer (RFC 6901) to the target location.", Example: jsonschema.RawValue(`"/name"`)},
+				},
+				{
+					Name:   "from",
+					Schema: &ogen.Schema{Type: "string", Description: "A JSON pointer to the source location, used by move and copy operations."},
+				},
+				{
+					Name:   "value",
+					Schema: SchemaAny,
+				},
+			},
+			Required: []string{"op", "path"},
+		}},
+	}
+}
+
 // addGlobalRequestHeaders adds the given headers to shared component parameters,
 // then adds each of those parameters to each path root (rather than each request,
 // to deduplicate references for those headers).
@@ -662,6 +1545,33 @@ func addGlobalRequestHeaders(spec *ogen.Spec, headers map[string]*ogen.Header) {
 	}
 }
 
+// addOperationRequestHeaders adds the given headers to shared component parameters, then
+// adds each of those parameters to the operations of the provided paths which use one of
+// the provided methods.
+func addOperationRequestHeaders(spec *ogen.Spec, headers RequestHeaders, paths []string, methods ...string) {
+	for k, v := range headers {
+		spec.Components.Parameters[k] = v.InHeader().SetName(k)
+	}
+
+	for _, pathName := range paths {
+		pathItem, ok := spec.Paths[pathName]
+		if !ok {
+			continue
+		}
+
+		spec.Paths[pathName] = PatchOperations(pathItem, func(method string, op *ogen.Operation) *ogen.Operation {
+			if op == nil || !slices.Contains(methods, method) {
+				return op
+			}
+
+			for k := range headers {
+				op.Parameters = append(op.Parameters, &ogen.Parameter{Ref: "#/components/parameters/" + k})
+			}
+			return op
+		})
+	}
+}
+
 // addGlobalResponseHeaders adds the given headers to shared component headers,
 // then adds each of those headers to every single response body.
 //
@@ -706,6 +1616,59 @@ func addGlobalResponseHeaders(spec *ogen.Spec, headers map[string]*ogen.Header)
 	}
 }
 
+// addMediaTypes adds the provided media types to the request bodies and responses of
+// all operations of the provided paths, as well as all shared component responses, using
+// the same schema as the JSON content.
+func addMediaTypes(spec *ogen.Spec, mediaTypes, paths []string) {
+	if len(mediaTypes) == 0 {
+		return
+	}
+
+	addContent := func(content map[string]ogen.Media) {
+		media, ok := content["application/json"]
+		if !ok {
+			return
+		}
+
+		for _, mediaType := range mediaTypes {
+			if _, ok := content[mediaType]; !ok {
+				content[mediaType] = media
+			}
+		}
+	}
+
+	for _, pathName := range paths {
+		pathItem, ok := spec.Paths[pathName]
+		if !ok {
+			continue
+		}
+
+		spec.Paths[pathName] = PatchOperations(pathItem, func(_ string, op *ogen.Operation) *ogen.Operation {
+			if op == nil {
+				return nil
+			}
+
+			if op.RequestBody != nil && op.RequestBody.Ref == "" {
+				addContent(op.RequestBody.Content)
+			}
+
+			for _, resp := range op.Responses {
+				if resp.Ref == "" {
+					addContent(resp.Content)
+				}
+			}
+
+			return op
+		})
+	}
+
+	for _, resp := range spec.Components.Responses {
+		if resp.Ref == "" {
+			addContent(resp.Content)
+		}
+	}
+}
+
 // addGlobalErrorResponses adds the given error responses to shared component
 // responses, then adds each of those responses to all responses.
 //
@@ -746,7 +1709,24 @@ func addGlobalErrorResponses(cfg *Config, spec *ogen.Spec, responses map[int]*og
 				switch {
 				case strings.HasPrefix(op.OperationID, "list") && k == http.StatusNotFound && !cfg.ListNotFound:
 					continue
-				case !strings.HasPrefix(op.OperationID, "create") && !strings.HasPrefix(op.OperationID, "update") && k == http.StatusConflict:
+				case slices.ContainsFunc([]string{"aggregate", "import", "stream"}, func(prefix string) bool {
+					return strings.HasPrefix(op.OperationID, prefix)
+				}) && k == http.StatusNotFound:
+					continue
+				case k == http.StatusConflict && !slices.ContainsFunc([]string{"create", "update", "upsert", "add", "set"}, func(prefix string) bool {
+					return strings.HasPrefix(op.OperationID, prefix)
+				}):
+					continue
+				case k == http.StatusPreconditionFailed && !slices.ContainsFunc(op.Parameters, func(p *ogen.Parameter) bool {
+					return p.Ref == "#/components/parameters/IfMatch"
+				}):
+					continue
+				case k == http.StatusUnprocessableEntity && !slices.ContainsFunc(op.Parameters, func(p *ogen.Parameter) bool {
+					return p.Ref == "#/components/parameters/Idempotency-Key"
+				}):
+					continue
+				case k == http.StatusTooManyRequests && op.Responses[strconv.Itoa(k)] == nil:
+					// Only rate limited operations (see addRateLimitResponse).
 					continue
 				}
 
@@ -825,6 +1805,16 @@ func GetOperationIDName(op Operation, t *gen.Type, e *gen.Edge) string {
 			return "get" + Singularize(t.Name) + Singularize(PascalCase(e.Name))
 		case OperationList:
 			return "list" + Singularize(t.Name) + Pluralize(PascalCase(e.Name))
+		case OperationUpdate:
+			if e.Unique {
+				return "set" + Singularize(t.Name) + Singularize(PascalCase(e.Name))
+			}
+			return "add" + Singularize(t.Name) + Singularize(PascalCase(e.Name))
+		case OperationDelete:
+			if e.Unique {
+				return "clear" + Singularize(t.Name) + Singularize(PascalCase(e.Name))
+			}
+			return "remove" + Singularize(t.Name) + Singularize(PascalCase(e.Name))
 		default:
 			panic(fmt.Sprintf("unsupported operation %q", op))
 		}
@@ -839,12 +1829,36 @@ func GetOperationIDName(op Operation, t *gen.Type, e *gen.Edge) string {
 		return "create" + Singularize(t.Name)
 	case OperationUpdate:
 		return "update" + Singularize(t.Name)
+	case OperationReplace:
+		return "replace" + Singularize(t.Name)
 	case OperationRead:
 		return "get" + Singularize(t.Name)
 	case OperationList:
 		return "list" + Pluralize(t.Name)
 	case OperationDelete:
 		return "delete" + Singularize(t.Name)
+	case OperationCreateBulk:
+		return "createBulk" + Pluralize(t.Name)
+	case OperationUpdateBulk:
+		return "updateBulk" + Pluralize(t.Name)
+	case OperationDeleteBulk:
+		return "deleteBulk" + Pluralize(t.Name)
+	case OperationRestore:
+		return "restore" + Singularize(t.Name)
+	case OperationUpsert:
+		return "upsert" + Singularize(t.Name)
+	case OperationAggregate:
+		return "aggregate" + Pluralize(t.Name)
+	case OperationImport:
+		return "import" + Pluralize(t.Name)
+	case OperationEvents:
+		return "stream" + Singularize(t.Name) + "Events"
+	case OperationHistory:
+		return "list" + Singularize(t.Name) + "History"
+	case OperationHistoryRead:
+		return "get" + Singularize(t.Name) + "HistoryVersion"
+	case OperationHistoryRestore:
+		return "restore" + Singularize(t.Name) + "HistoryVersion"
 	default:
 		panic(fmt.Sprintf("unsupported operation %q", op))
 	}
@@ -860,19 +1874,53 @@ func GetPathName(op Operation, t *gen.Type, e *gen.Edge, useUniqueID bool) strin
 	}
 
 	if e != nil {
+		edgeID := "{edgeID}"
+		if useUniqueID {
+			edgeID = "{" + CamelCase(Singularize(PascalCase(e.Name))) + "ID}"
+		}
+
 		switch op {
 		case OperationRead, OperationList:
 			return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/" + KebabCase(e.Name)
+		case OperationUpdate:
+			return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/" + KebabCase(e.Name) + "/" + edgeID
+		case OperationDelete:
+			if e.Unique {
+				return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/" + KebabCase(e.Name)
+			}
+			return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/" + KebabCase(e.Name) + "/" + edgeID
 		default:
 			panic(fmt.Sprintf("unsupported operation %q", op))
 		}
 	}
 
 	switch op {
-	case OperationRead, OperationUpdate, OperationDelete:
+	case OperationRead, OperationUpdate, OperationReplace, OperationDelete:
 		return "/" + Pluralize(KebabCase(t.Name)) + "/" + id
-	case OperationCreate, OperationList:
+	case OperationCreate, OperationList, OperationUpdateBulk, OperationDeleteBulk:
 		return "/" + Pluralize(KebabCase(t.Name))
+	case OperationCreateBulk:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/bulk"
+	case OperationRestore:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/restore"
+	case OperationUpsert:
+		path := "/" + Pluralize(KebabCase(t.Name))
+		for _, f := range GetUpsertFields(t) {
+			path += "/by-" + KebabCase(f.Name) + "/{" + CamelCase(f.Name) + "}"
+		}
+		return path
+	case OperationAggregate:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/aggregate"
+	case OperationImport:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/import"
+	case OperationEvents:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/events"
+	case OperationHistory:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/history"
+	case OperationHistoryRead:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/history/{version}"
+	case OperationHistoryRestore:
+		return "/" + Pluralize(KebabCase(t.Name)) + "/" + id + "/history/{version}/restore"
 	default:
 		panic(fmt.Sprintf("unsupported operation %q", op))
 	}
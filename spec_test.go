@@ -5,11 +5,13 @@
 package entrest
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"entgo.io/ent/entc/gen"
 	"github.com/ogen-go/ogen"
@@ -129,6 +131,583 @@ func TestSpec_Sensitive(t *testing.T) {
 	assert.NotContains(t, string(b), `"password_hashed"`)
 }
 
+func TestSpec_SelectableFields(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{DefaultEagerLoad: true})
+
+	for _, path := range []string{
+		`$.paths./pets.get`,
+		`$.paths./pets/{petID}.get`,
+		`$.paths./users/{userID}/pets.get`,
+		`$.paths./pets/{petID}/owner.get`,
+	} {
+		assert.Equal(t, "form", r.json(path+`.parameters[?(@.name == 'fields')].style`), path)
+		assert.Equal(t, false, r.json(path+`.parameters[?(@.name == 'fields')].explode`), path)
+		assert.Equal(t, "array", r.json(path+`.parameters[?(@.name == 'fields')].schema.type`), path)
+	}
+
+	assert.Contains(t, r.json(`$.paths./pets.get.parameters[?(@.name == 'fields')].schema.items.$ref`), "/PetSelectableFields")
+
+	fields := r.json(`$.components.schemas.PetSelectableFields.enum`)
+	assert.Contains(t, fields, "id")
+	assert.Contains(t, fields, "name")
+	assert.Contains(t, fields, "owner")
+	assert.Contains(t, fields, "owner.name")
+	assert.NotContains(t, fields, "owner.password_hashed")
+	assert.NotContains(t, r.json(`$.components.schemas.UserSelectableFields.enum`), "password_hashed")
+}
+
+func TestSpec_BulkOperations(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		DefaultFilterID: true,
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithIncludeOperations(OperationList, OperationUpdateBulk, OperationDeleteBulk))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "updateBulkPets", r.json(`$.paths./pets.patch.operationId`))
+	assert.Equal(t, "deleteBulkPets", r.json(`$.paths./pets.delete.operationId`))
+	assert.Equal(t, "#/components/schemas/PetUpdate", r.json(`$.paths./pets.patch.requestBody.content.application/json.schema.$ref`))
+	assert.Equal(t, "#/components/schemas/BulkResponse", r.json(`$.paths./pets.delete.responses.200.content.application/json.schema.$ref`))
+	assert.Nil(t, r.json(`$.paths./users.patch`))
+
+	for _, method := range []string{"patch", "delete"} {
+		params := r.json(`$.paths./pets.` + method + `.parameters[*].$ref`)
+		assert.Contains(t, params, "#/components/parameters/BulkAll", method)
+		assert.Contains(t, params, "#/components/parameters/PetIDEQ", method)
+		assert.Contains(t, params, "#/components/parameters/FilterOperation", method)
+	}
+}
+
+func TestSpec_ReplaceOperation(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithIncludeOperations(OperationRead, OperationList, OperationReplace))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "replacePet", r.json(`$.paths./pets/{petID}.put.operationId`))
+	assert.Equal(t, "#/components/schemas/PetReplace", r.json(`$.paths./pets/{petID}.put.requestBody.content.application/json.schema.$ref`))
+	assert.Nil(t, r.json(`$.paths./users/{userID}.put`))
+
+	// Only non-optional fields are required, and non-unique edges are replaced as a whole.
+	assert.Equal(t, "name", r.json(`$.components.schemas.PetReplace.required[*]`))
+	assert.NotNil(t, r.json(`$.components.schemas.PetReplace.properties.friends`))
+	assert.Nil(t, r.json(`$.components.schemas.PetReplace.properties.add_friends`))
+}
+
+func TestSpec_UpdatePatchContent(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{})
+
+	content := `$.paths./pets/{petID}.patch.requestBody.content`
+	assert.Equal(t, "#/components/schemas/PetUpdate", r.json(content+`.application/json.schema.$ref`))
+	assert.Equal(t, "#/components/schemas/PetUpdate", r.json(content+`.application/merge-patch+json.schema.$ref`))
+	assert.Equal(t, "#/components/schemas/JSONPatch", r.json(content+`.application/json-patch+json.schema.$ref`))
+	assert.Equal(t, "array", r.json(`$.components.schemas.JSONPatch.type`))
+}
+
+func TestSpec_VersionField(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithVersionField("age"))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "#/components/headers/ETag", r.json(`$.paths./pets/{petID}.get.responses.200.headers.ETag.$ref`))
+	assert.Equal(t, "#/components/headers/ETag", r.json(`$.paths./pets.get.responses.200.headers.ETag.$ref`))
+	assert.Equal(t, "#/components/headers/ETag", r.json(`$.paths./pets.post.responses.201.headers.ETag.$ref`))
+	assert.NotNil(t, r.json(`$.paths./pets/{petID}.get.responses.304`))
+	assert.Equal(t, "#/components/parameters/IfNoneMatch", r.json(`$.paths./pets/{petID}.get.parameters[?(@.$ref == '#/components/parameters/IfNoneMatch')].$ref`))
+
+	// Only update and delete operations support If-Match, and may return a 412.
+	for _, method := range []string{"patch", "delete"} {
+		assert.Equal(t, "#/components/parameters/IfMatch", r.json(`$.paths./pets/{petID}.`+method+`.parameters[?(@.$ref == '#/components/parameters/IfMatch')].$ref`))
+		assert.NotNil(t, r.json(`$.paths./pets/{petID}.`+method+`.responses.412`))
+	}
+	assert.Nil(t, r.json(`$.paths./pets/{petID}.get.responses.412`))
+
+	// Types without a version field aren't affected.
+	assert.Nil(t, r.json(`$.paths./users/{userID}.get.responses.200.headers.ETag`))
+	assert.Nil(t, r.json(`$.paths./users/{userID}.patch.responses.412`))
+}
+
+func TestSpec_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithSoftDelete("age"))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "restorePet", r.json(`$.paths./pets/{petID}/restore.post.operationId`))
+	assert.Equal(t, "#/components/schemas/PetRead", r.json(`$.paths./pets/{petID}/restore.post.responses.200.content.application/json.schema.$ref`))
+	assert.Nil(t, r.json(`$.paths./pets/{petID}/restore.post.responses.201`))
+
+	for _, name := range []string{"IncludeDeleted", "OnlyDeleted"} {
+		assert.Equal(t, "#/components/parameters/"+name, r.json(`$.paths./pets.get.parameters[?(@.$ref == '#/components/parameters/`+name+`')].$ref`))
+	}
+
+	// Types without soft-delete aren't affected.
+	assert.Nil(t, r.json(`$.paths./users/{userID}/restore`))
+	assert.Nil(t, r.json(`$.paths./users.get.parameters[?(@.$ref == '#/components/parameters/IncludeDeleted')].$ref`))
+}
+
+func TestSpec_EdgeMutation(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet.friends", WithEdgeMutation(true))
+			injectAnnotations(t, g, "Pet.owner", WithEdgeMutation(true))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "addPetFriend", r.json(`$.paths./pets/{petID}/friends/{friendID}.put.operationId`))
+	assert.Equal(t, "removePetFriend", r.json(`$.paths./pets/{petID}/friends/{friendID}.delete.operationId`))
+	assert.NotNil(t, r.json(`$.paths./pets/{petID}/friends/{friendID}.put.responses.204`))
+	assert.Equal(t, "friendID", r.json(`$.components.parameters.PetFriendID.name`))
+
+	// Unique edges are set with the ID, and cleared without it.
+	assert.Equal(t, "setPetOwner", r.json(`$.paths./pets/{petID}/owner/{ownerID}.put.operationId`))
+	assert.Equal(t, "clearPetOwner", r.json(`$.paths./pets/{petID}/owner.delete.operationId`))
+	assert.Equal(t, "getPetOwner", r.json(`$.paths./pets/{petID}/owner.get.operationId`))
+
+	// Edges without the annotation aren't affected.
+	assert.Nil(t, r.json(`$.paths./pets/{petID}/categories/{categoryID}`))
+}
+
+func TestSpec_NestedCreate(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet.categories", WithNestedCreate(true))
+			injectAnnotations(t, g, "Pet.owner", WithNestedCreate(true))
+			return nil
+		},
+	})
+
+	// Non-unique edges accept an array of IDs or nested objects.
+	assert.Equal(t, "array", r.json(`$.components.schemas.PetCreate.properties.categories.type`))
+	assert.Equal(t, "integer", r.json(`$.components.schemas.PetCreate.properties.categories.items.oneOf[0].type`))
+	assert.Equal(t, "#/components/schemas/CategoryCreate", r.json(`$.components.schemas.PetCreate.properties.categories.items.oneOf[1].$ref`))
+
+	// Unique edges accept a single ID or nested object.
+	assert.Equal(t, "#/components/schemas/UserCreate", r.json(`$.components.schemas.PetCreate.properties.owner.oneOf[1].$ref`))
+
+	// Only create operations accept nested objects.
+	assert.Nil(t, r.json(`$.components.schemas.PetUpdate.properties.add_categories.items.oneOf`))
+	assert.Nil(t, r.json(`$.components.schemas.PetCreate.properties.friends.items.oneOf`))
+}
+
+func TestSpec_Upsert(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithUpsert("name"))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "upsertPet", r.json(`$.paths./pets/by-name/{name}.put.operationId`))
+	assert.Equal(t, "#/components/schemas/PetUpsert", r.json(`$.paths./pets/by-name/{name}.put.requestBody.content.application/json.schema.$ref`))
+	assert.NotNil(t, r.json(`$.paths./pets/by-name/{name}.put.responses.200`))
+	assert.NotNil(t, r.json(`$.paths./pets/by-name/{name}.put.responses.201`))
+	assert.NotNil(t, r.json(`$.paths./pets/by-name/{name}.put.responses.409`))
+	assert.Equal(t, "path", r.json(`$.paths./pets/by-name/{name}.parameters[?(@.name == "name")].in`))
+
+	// The upsert fields are provided through the path, not the request body.
+	assert.Nil(t, r.json(`$.components.schemas.PetUpsert.properties.name`))
+	assert.NotNil(t, r.json(`$.components.schemas.PetUpsert.properties.age`))
+	assert.Nil(t, r.json(`$.paths./users/by-name/{name}`))
+}
+
+func TestSpec_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{IdempotencyKey: true})
+
+	assert.Equal(t, "Idempotency-Key", r.json(`$.components.parameters.Idempotency-Key.name`))
+	assert.Equal(t, "header", r.json(`$.components.parameters.Idempotency-Key.in`))
+
+	ref := "#/components/parameters/Idempotency-Key"
+	assert.Equal(t, ref, r.json(`$.paths./pets.post.parameters[*].$ref`))
+	assert.Equal(t, ref, r.json(`$.paths./pets/{petID}.patch.parameters[*].$ref`))
+	assert.NotNil(t, r.json(`$.paths./pets.post.responses.422`))
+	assert.NotNil(t, r.json(`$.paths./pets/{petID}.patch.responses.422`))
+
+	// Only POST and PATCH operations support idempotency keys.
+	assert.Nil(t, r.json(`$.paths./pets.get.parameters[?(@.$ref == "`+ref+`")]`))
+	assert.Nil(t, r.json(`$.paths./pets.get.responses.422`))
+	assert.Nil(t, r.json(`$.paths./pets/{petID}.delete.responses.422`))
+
+	r = mustBuildSpec(t, &Config{})
+	assert.Nil(t, r.json(`$.components.parameters.Idempotency-Key`))
+	assert.Nil(t, r.json(`$.paths./pets.post.responses.422`))
+}
+
+func TestSpec_Search(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet.name", WithSearch(true))
+			injectAnnotations(t, g, "User", WithSearch(true))
+			return nil
+		},
+	})
+
+	ref := "#/components/parameters/PetSearch"
+	assert.Equal(t, "q", r.json(`$.components.parameters.PetSearch.name`))
+	assert.Equal(t, "query", r.json(`$.components.parameters.PetSearch.in`))
+	assert.Equal(t, ref, r.json(`$.paths./pets.get.parameters[?(@.$ref == "`+ref+`")].$ref`))
+	assert.Contains(t, r.json(`$.components.schemas.PetSortableFields.enum`), "relevance")
+
+	// Schema-level search includes all string fields (except sensitive ones).
+	desc := r.json(`$.components.parameters.UserSearch.description`)
+	assert.Contains(t, desc, "description")
+	assert.Contains(t, desc, "email")
+	assert.NotContains(t, desc, "password_hashed")
+
+	assert.Nil(t, r.json(`$.components.parameters.CategorySearch`))
+	assert.NotContains(t, r.json(`$.components.schemas.CategorySortableFields.enum`), "relevance")
+}
+
+func TestSpec_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		DefaultFilterID: true,
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "User", WithIncludeOperations(OperationList, OperationAggregate))
+			injectAnnotations(t, g, "User.type", WithAggregate(AggregateGroupBy))
+			injectAnnotations(t, g, "User.created_at", WithAggregate(AggregateGroupBy))
+			injectAnnotations(t, g, "Pet.age", WithAggregate(AggregateGroupBy|AggregateGroupNumeric))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "aggregateUsers", r.json(`$.paths./users/aggregate.get.operationId`))
+	assert.Equal(
+		t,
+		"#/components/schemas/UserAggregateList",
+		r.json(`$.paths./users/aggregate.get.responses.200.content.application/json.schema.$ref`),
+	)
+	assert.Nil(t, r.json(`$.paths./users/aggregate.get.responses.404`))
+
+	assert.Equal(
+		t,
+		[]any{"type", "created_at:day", "created_at:week", "created_at:month"},
+		r.json(`$.components.schemas.UserAggregateGroupBy.enum`),
+	)
+	assert.Equal(t, []any{"count"}, r.json(`$.components.schemas.UserAggregateMetric.enum`))
+	assert.Equal(t, "#/components/schemas/UserTypeEnum", r.json(`$.components.schemas.UserAggregate.properties.type.$ref`))
+	assert.Equal(t, "date", r.json(`$.components.schemas.UserAggregate.properties.created_at.format`))
+
+	assert.Equal(t, []any{"group_by", "metrics"}, r.json(`$.paths./users/aggregate.get.parameters[*].name`))
+	assert.Contains(t, r.json(`$.paths./users/aggregate.get.parameters[*].$ref`), "#/components/parameters/UserIDEQ")
+
+	// Aggregate is opt-in, so field annotations alone don't enable it.
+	assert.Nil(t, r.json(`$.paths./pets/aggregate`))
+	assert.Nil(t, r.json(`$.components.schemas.PetAggregate`))
+}
+
+func TestSpec_ListExport(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{ListExport: true})
+
+	assert.Equal(
+		t,
+		"#/components/schemas/PetRead",
+		r.json(`$.paths./pets.get.responses.200.content.application/x-ndjson.schema.$ref`),
+	)
+	assert.Equal(t, "string", r.json(`$.paths./pets.get.responses.200.content.text/csv.schema.type`))
+	assert.Contains(t, r.json(`$.paths./pets.get.parameters[*].$ref`), "#/components/parameters/ExportFormat")
+	assert.Equal(t, []any{"json", "ndjson", "csv"}, r.json(`$.components.parameters.ExportFormat.schema.enum`))
+
+	r = mustBuildSpec(t, &Config{})
+	assert.Nil(t, r.json(`$.paths./pets.get.responses.200.content.text/csv`))
+	assert.Nil(t, r.json(`$.components.parameters.ExportFormat`))
+}
+
+func TestSpec_Import(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "User", WithIncludeOperations(OperationList, OperationCreate, OperationImport))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "importUsers", r.json(`$.paths./users/import.post.operationId`))
+	assert.Equal(
+		t,
+		"#/components/schemas/UserCreate",
+		r.json(`$.paths./users/import.post.requestBody.content.application/x-ndjson.schema.$ref`),
+	)
+	assert.Equal(t, "string", r.json(`$.paths./users/import.post.requestBody.content.text/csv.schema.type`))
+	assert.Equal(
+		t,
+		"binary",
+		r.json(`$.paths./users/import.post.requestBody.content.multipart/form-data.schema.properties.file.format`),
+	)
+	assert.Equal(
+		t,
+		"#/components/schemas/UserImportResponse",
+		r.json(`$.paths./users/import.post.responses.201.content.application/json.schema.$ref`),
+	)
+	assert.Nil(t, r.json(`$.paths./users/import.post.responses.404`))
+	assert.Equal(
+		t,
+		[]any{"#/components/parameters/ImportDryRun", "#/components/parameters/ImportMode"},
+		r.json(`$.paths./users/import.post.parameters[*].$ref`),
+	)
+	assert.Equal(t, []any{"atomic", "best-effort"}, r.json(`$.components.parameters.ImportMode.schema.enum`))
+
+	// Import is opt-in.
+	assert.Nil(t, r.json(`$.paths./pets/import`))
+	assert.Nil(t, r.json(`$.components.schemas.PetImportResponse`))
+}
+
+func TestSpec_Events(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithIncludeOperations(OperationRead, OperationList, OperationEvents))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "streamPetEvents", r.json(`$.paths./pets/events.get.operationId`))
+	assert.Equal(
+		t,
+		"#/components/schemas/PetEvent",
+		r.json(`$.paths./pets/events.get.responses.200.content.text/event-stream.schema.$ref`),
+	)
+	assert.Nil(t, r.json(`$.paths./pets/events.get.responses.200.content.application/json`))
+	assert.Nil(t, r.json(`$.paths./pets/events.get.responses.404`))
+	assert.Subset(
+		t,
+		r.json(`$.paths./pets/events.get.parameters[*].$ref`),
+		[]any{"#/components/parameters/LastEventID", "#/components/parameters/EventEntity"},
+	)
+	assert.Equal(t, "Last-Event-ID", r.json(`$.components.parameters.LastEventID.name`))
+	assert.Equal(t, []any{"create", "update", "delete"}, r.json(`$.components.schemas.PetEvent.properties.op.enum`))
+	assert.Equal(t, "#/components/schemas/PetRead", r.json(`$.components.schemas.PetEvent.properties.entity.$ref`))
+
+	// Events are opt-in.
+	assert.Nil(t, r.json(`$.paths./users/events`))
+	assert.Nil(t, r.json(`$.components.schemas.UserEvent`))
+}
+
+func TestSpec_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithWebhooks(true))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "petCreateWebhook", r.json(`$['x-webhooks']['pet.create'].post.operationId`))
+	assert.Equal(
+		t,
+		"#/components/schemas/PetEvent",
+		r.json(`$['x-webhooks']['pet.delete'].post.requestBody.content.application/json.schema.$ref`),
+	)
+	assert.Equal(
+		t,
+		[]any{"webhook-id", "webhook-timestamp", "webhook-signature"},
+		r.json(`$['x-webhooks']['pet.update'].post.parameters[*].name`),
+	)
+	assert.Equal(t, "#/components/schemas/PetRead", r.json(`$.components.schemas.PetEvent.properties.entity.$ref`))
+
+	// Webhooks are opt-in, and don't add any paths.
+	assert.Nil(t, r.json(`$['x-webhooks']['user.create']`))
+	assert.Nil(t, r.json(`$.paths./pets/events`))
+
+	// Extensions are added after the other top-level fields, which keep their order.
+	b, err := marshalSpec(r.spec)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte(`{"openapi":`)))
+	assert.Less(t, bytes.Index(b, []byte(`"paths":`)), bytes.Index(b, []byte(`"x-webhooks":`)))
+
+	// OpenAPI 3.1 supports webhooks natively.
+	r = mustBuildSpec(t, &Config{
+		Spec: &ogen.Spec{OpenAPI: "3.1.0"},
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithWebhooks(true))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "petCreateWebhook", r.json(`$.webhooks['pet.create'].post.operationId`))
+	assert.Nil(t, r.json(`$['x-webhooks']`))
+}
+
+func TestSpec_History(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(t, g, "Pet", WithHistory(true))
+			return nil
+		},
+	})
+
+	assert.Equal(t, "listPetHistory", r.json(`$.paths./pets/{petID}/history.get.operationId`))
+	assert.Equal(t, "getPetHistoryVersion", r.json(`$.paths./pets/{petID}/history/{version}.get.operationId`))
+	assert.Equal(t, "restorePetHistoryVersion", r.json(`$.paths./pets/{petID}/history/{version}/restore.post.operationId`))
+	assert.Equal(
+		t,
+		"#/components/schemas/PetHistoryList",
+		r.json(`$.paths./pets/{petID}/history.get.responses.200.content.application/json.schema.$ref`),
+	)
+	assert.Equal(
+		t,
+		"#/components/schemas/PetHistory",
+		r.json(`$.components.schemas.PetHistoryList.allOf[1].properties.content.items.$ref`),
+	)
+	assert.Equal(
+		t,
+		"#/components/schemas/PetRead",
+		r.json(`$.paths./pets/{petID}/history/{version}/restore.post.responses.200.content.application/json.schema.$ref`),
+	)
+	assert.Equal(t, "#/components/schemas/Pet", r.json(`$.components.schemas.PetHistory.properties.entity.$ref`))
+
+	// History is opt-in, even if the operations are explicitly included.
+	assert.Nil(t, r.json(`$.paths./users/{userID}/history`))
+
+	r = mustBuildSpec(t, &Config{DefaultOperations: AllOperations})
+	assert.Nil(t, r.json(`$.paths./pets/{petID}/history`))
+}
+
+func TestSpec_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(
+				t, g, "Pet",
+				WithRateLimit(100, time.Minute),
+				WithOperationRateLimit(OperationCreate, 5, time.Minute),
+			)
+			return nil
+		},
+	})
+
+	assert.Equal(t, "#/components/responses/ErrorTooManyRequests", r.json(`$.paths./pets.get.responses.429.$ref`))
+	assert.Equal(t, "#/components/responses/ErrorTooManyRequests", r.json(`$.paths./pets.post.responses.429.$ref`))
+	assert.Equal(t, "#/components/responses/ErrorTooManyRequests", r.json(`$.paths./pets/{petID}/owner.get.responses.429.$ref`))
+	assert.Contains(t, r.json(`$.paths./pets.get.responses.200.headers`), "X-Ratelimit-Remaining")
+	assert.Contains(t, r.json(`$.components.responses.ErrorTooManyRequests.headers`), "Retry-After")
+	assert.Contains(t, r.json(`$.components.headers`), "X-Ratelimit-Reset")
+
+	// Only rate limited operations document the 429 response.
+	assert.Nil(t, r.json(`$.paths./users.get.responses.429`))
+	assert.Nil(t, r.json(`$.paths./users.get.responses.200.headers`))
+
+	r = mustBuildSpec(t, &Config{})
+	assert.Nil(t, r.json(`$.paths./pets.get.responses.429`))
+	assert.Nil(t, r.json(`$.components.headers['Retry-After']`))
+}
+
+func TestSpec_Security(t *testing.T) {
+	t.Parallel()
+
+	spec := ogen.NewSpec()
+	spec.Security = ogen.SecurityRequirements{{"OAuth2": {"read"}}}
+	spec.Components = &ogen.Components{
+		SecuritySchemes: map[string]*ogen.SecurityScheme{
+			"ApiKey": {Type: "apiKey", Name: "X-Api-Key", In: "header"},
+			"OAuth2": {Type: "oauth2", Flows: &ogen.OAuthFlows{}},
+		},
+	}
+
+	r := mustBuildSpec(t, &Config{
+		Spec: spec,
+		PreGenerateHook: func(g *gen.Graph, _ *ogen.Spec) error {
+			injectAnnotations(
+				t, g, "Pet",
+				WithSecurity(OperationCreate, ogen.SecurityRequirement{"ApiKey": {}}, ogen.SecurityRequirement{"OAuth2": {}}),
+				WithScopes(OperationCreate, "pets:write"),
+				WithScopes(OperationUpdate, "pets:write"),
+				WithSecurity(OperationList, ogen.SecurityRequirement{}),
+				WithScopes(OperationRead, "pets:read"),
+			)
+			injectAnnotations(t, g, "Pet.owner", WithScopes(OperationRead, "users:read"))
+			return nil
+		},
+	})
+
+	// Scopes are only added to schemes which support them.
+	assert.Equal(t, []any{}, r.json(`$.paths./pets.post.security[0].ApiKey`))
+	assert.Equal(t, []any{"pets:write"}, r.json(`$.paths./pets.post.security[1].OAuth2`))
+
+	// Scopes are added to the global security requirements, if the operation doesn't have
+	// its own.
+	assert.Equal(t, []any{"read", "pets:write"}, r.json(`$.paths./pets/{petID}.patch.security[0].OAuth2`))
+
+	// Empty requirements make authentication optional.
+	assert.Equal(t, []any{map[string]any{}}, r.json(`$.paths./pets.get.security`))
+
+	// Scopes of edges override the scopes of the schema.
+	assert.Equal(t, []any{"read", "pets:read"}, r.json(`$.paths./pets/{petID}.get.security[0].OAuth2`))
+	assert.Equal(t, []any{"read", "users:read"}, r.json(`$.paths./pets/{petID}/owner.get.security[0].OAuth2`))
+
+	// Operations without security annotations use the global security requirements.
+	assert.Nil(t, r.json(`$.paths./users.get.security`))
+	assert.Len(t, r.json(`$.security`), 1)
+}
+
+func TestSpec_MediaTypes(t *testing.T) {
+	t.Parallel()
+
+	r := mustBuildSpec(t, &Config{MediaTypes: []string{"application/cbor"}})
+
+	assert.Equal(
+		t,
+		"#/components/schemas/PetCreate",
+		r.json(`$.paths./pets.post.requestBody.content.application/cbor.schema.$ref`),
+	)
+	assert.Equal(
+		t,
+		"#/components/schemas/PetRead",
+		r.json(`$.paths./pets/{petID}.get.responses.200.content.application/cbor.schema.$ref`),
+	)
+	assert.Equal(
+		t,
+		"#/components/schemas/ErrorBadRequest",
+		r.json(`$.components.responses.ErrorBadRequest.content.application/cbor.schema.$ref`),
+	)
+
+	// The spec endpoint always responds with JSON.
+	assert.Nil(t, r.json(`$.paths./openapi.json.get.responses.200.content.application/cbor`))
+
+	r = mustBuildSpec(t, &Config{})
+	assert.Nil(t, r.json(`$.paths./pets.post.requestBody.content.application/cbor`))
+}
+
 var testRequiredMethods = []string{
 	http.MethodGet,
 	http.MethodPost,
@@ -22,12 +22,42 @@ var (
 
 		// Use this function when you want to invoke annotation functions (which are
 		// often created if they depend on [Config]).
-		"getAnnotation":       GetAnnotation,
-		"getSortableFields":   GetSortableFields,
-		"getFilterableFields": GetFilterableFields,
-		"getFilterGroups":     GetFilterGroups,
-		"getOperationIDName":  GetOperationIDName,
-		"getPathName":         GetPathName,
+		"getAnnotation":           GetAnnotation,
+		"getSortableFields":       GetSortableFields,
+		"getFilterableFields":     GetFilterableFields,
+		"getFilterGroups":         GetFilterGroups,
+		"getSearchGroup":          GetSearchGroup,
+		"getAggregateFields":      GetAggregateFields,
+		"getPaginationMode":       GetPaginationMode,
+		"getCursorFields":         GetCursorFields,
+		"getSelectableFields":     GetSelectableFields,
+		"getExportColumns":        GetExportColumns,
+		"hasExport":               HasExport,
+		"getImportRawFields":      GetImportRawFields,
+		"getEventTypes":           GetEventTypes,
+		"getIncludableEdges":      GetIncludableEdges,
+		"getOperationIDName":      GetOperationIDName,
+		"getPathName":             GetPathName,
+		"getVersionField":         GetVersionField,
+		"getVersionedTypes":       GetVersionedTypes,
+		"getSoftDeleteField":      GetSoftDeleteField,
+		"getMutableEdges":         GetMutableEdges,
+		"getPatchableJSONFields":  GetPatchableJSONFields,
+		"isNestedCreateEdge":      IsNestedCreateEdge,
+		"getNestedCreateEdges":    GetNestedCreateEdges,
+		"getNestedCreateTypes":    GetNestedCreateTypes,
+		"getCountStrategy":        GetCountStrategy,
+		"getUpsertFields":         GetUpsertFields,
+		"getIdempotencyStoreType": GetIdempotencyStoreType,
+		"getWebhookTypes":         GetWebhookTypes,
+		"getWebhookOutboxType":    GetWebhookOutboxType,
+		"getWebhookStoreType":     GetWebhookStoreType,
+		"getHistoryTypes":         GetHistoryTypes,
+		"getHistoryStoreType":     GetHistoryStoreType,
+		"getHistoryRestoreFields": GetHistoryRestoreFields,
+		"getRateLimit":            GetRateLimit,
+		"hasRateLimits":           HasRateLimits,
+		"getScopes":               GetOperationScopes,
 	}
 
 	//go:embed templates